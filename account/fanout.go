@@ -0,0 +1,248 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	stripe "github.com/stripe/stripe-go/v81"
+)
+
+//
+// Public constants
+//
+
+const (
+	// DefaultFanOutConcurrency is the number of connected accounts that FanOut
+	// processes at the same time when FanOutParams.Concurrency isn't set.
+	DefaultFanOutConcurrency = 10
+
+	// DefaultFanOutMaxRateLimitRetries is the number of times FanOut retries a
+	// function that failed because of rate limiting when
+	// FanOutParams.MaxRateLimitRetries isn't set.
+	DefaultFanOutMaxRateLimitRetries = 3
+)
+
+//
+// Public types
+//
+
+// FanOutFunc is the function run by FanOut for every connected account.
+//
+// The given Params has its StripeAccount set to the connected account and
+// its Context set to the one of the fan-out. It can be embedded into any
+// other parameters struct so that calls are made on behalf of the account:
+//
+//	func(params *stripe.Params) (interface{}, error) {
+//		return balance.Get(&stripe.BalanceParams{Params: *params})
+//	}
+//
+// A fresh Params is passed on every invocation, so it's safe to modify.
+type FanOutFunc func(params *stripe.Params) (interface{}, error)
+
+// FanOutParams configures a fan-out across connected accounts.
+type FanOutParams struct {
+	// Context used for the fan-out. When it's canceled, no new accounts are
+	// started and the accounts not yet processed are reported with the
+	// context's error. It's also passed on to every FanOutFunc.
+	Context context.Context
+
+	// AccountIDs is the list of connected accounts to run against. If left
+	// empty, every account returned by listing accounts with ListParams is
+	// used instead.
+	AccountIDs []string
+
+	// Concurrency is the maximum number of accounts processed at the same
+	// time.
+	//
+	// Defaults to DefaultFanOutConcurrency.
+	Concurrency int
+
+	// ListParams are the parameters used to list connected accounts when
+	// AccountIDs is empty.
+	ListParams *stripe.AccountListParams
+
+	// MaxRateLimitRetries is the maximum number of times a FanOutFunc is
+	// retried after it returned a rate limiting error. The backend itself
+	// doesn't retry those, so that contention isn't made worse, but a fan-out
+	// can afford to wait and try again. Set to a negative value to disable.
+	//
+	// Defaults to DefaultFanOutMaxRateLimitRetries.
+	MaxRateLimitRetries int
+
+	// RateLimitBackoff is the delay before the first retry of a rate limited
+	// FanOutFunc. It doubles on every subsequent retry.
+	//
+	// Defaults to one second.
+	RateLimitBackoff time.Duration
+}
+
+// FanOutResult is the outcome of running a FanOutFunc for one account.
+type FanOutResult struct {
+	// AccountID is the ID of the connected account.
+	AccountID string
+
+	// Err is the error returned by the FanOutFunc, if any.
+	Err error
+
+	// Value is the value returned by the FanOutFunc.
+	Value interface{}
+}
+
+// FanOutResults is the list of results of a fan-out, in the order in which
+// accounts were given or listed.
+type FanOutResults []*FanOutResult
+
+// Errors returns the errors of the failed accounts keyed by account ID.
+func (r FanOutResults) Errors() map[string]error {
+	errs := make(map[string]error)
+	for _, res := range r {
+		if res.Err != nil {
+			errs[res.AccountID] = res.Err
+		}
+	}
+	return errs
+}
+
+// Failed returns the results of the accounts for which the FanOutFunc
+// returned an error.
+func (r FanOutResults) Failed() FanOutResults {
+	var failed FanOutResults
+	for _, res := range r {
+		if res.Err != nil {
+			failed = append(failed, res)
+		}
+	}
+	return failed
+}
+
+//
+// Public functions
+//
+
+// FanOut runs fn once for each connected account. See Client.FanOut.
+func FanOut(params *FanOutParams, fn FanOutFunc) (FanOutResults, error) {
+	return getC().FanOut(params, fn)
+}
+
+// FanOut runs fn once for each connected account, either those listed in
+// params.AccountIDs or all the accounts returned by List, with a bounded
+// number of concurrent invocations.
+//
+// Errors returned by fn are collected per account in the results and don't
+// stop the fan-out. The returned error is only set when listing accounts
+// failed, in which case the results of the accounts listed so far are still
+// returned.
+func (c Client) FanOut(params *FanOutParams, fn FanOutFunc) (FanOutResults, error) {
+	if params == nil {
+		params = &FanOutParams{}
+	}
+
+	ctx := params.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	concurrency := params.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultFanOutConcurrency
+	}
+
+	maxRetries := params.MaxRateLimitRetries
+	if maxRetries == 0 {
+		maxRetries = DefaultFanOutMaxRateLimitRetries
+	}
+
+	backoff := params.RateLimitBackoff
+	if backoff <= 0 {
+		backoff = time.Second
+	}
+
+	var results FanOutResults
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+
+	dispatch := func(accountID string) {
+		res := &FanOutResult{AccountID: accountID}
+		results = append(results, res)
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			res.Err = ctx.Err()
+			return
+		}
+
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			res.Value, res.Err = runWithRateLimitRetries(ctx, accountID, fn, maxRetries, backoff)
+		}()
+	}
+
+	var listErr error
+	if len(params.AccountIDs) > 0 {
+		for _, id := range params.AccountIDs {
+			dispatch(id)
+		}
+	} else {
+		// Copy the list params so that setting their context doesn't change
+		// those of the caller.
+		listParams := &stripe.AccountListParams{}
+		if params.ListParams != nil {
+			*listParams = *params.ListParams
+		}
+		if listParams.Context == nil {
+			listParams.Context = ctx
+		}
+
+		// Iterators aren't thread-safe, so accounts are listed from this
+		// goroutine only and handed over to workers by ID.
+		i := c.List(listParams)
+		for i.Next() {
+			dispatch(i.Account().ID)
+		}
+		listErr = i.Err()
+	}
+
+	wg.Wait()
+
+	return results, listErr
+}
+
+//
+// Private functions
+//
+
+// isRateLimitError returns whether err is a Stripe rate limiting error. Lock
+// timeouts are also returned with a 429, but those are already retried by the
+// backend.
+func isRateLimitError(err error) bool {
+	var stripeErr *stripe.Error
+	if !errors.As(err, &stripeErr) {
+		return false
+	}
+	return stripeErr.HTTPStatusCode == 429 && stripeErr.Code != stripe.ErrorCodeLockTimeout
+}
+
+func runWithRateLimitRetries(ctx context.Context, accountID string, fn FanOutFunc, maxRetries int, backoff time.Duration) (interface{}, error) {
+	for retry := 0; ; retry++ {
+		value, err := fn(&stripe.Params{
+			Context:       ctx,
+			StripeAccount: stripe.String(accountID),
+		})
+		if err == nil || retry >= maxRetries || !isRateLimitError(err) {
+			return value, err
+		}
+
+		select {
+		case <-time.After(backoff << uint(retry)):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package account_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+	stripe "github.com/stripe/stripe-go/v81"
+	"github.com/stripe/stripe-go/v81/account"
+	"github.com/stripe/stripe-go/v81/stripetest"
+)
+
+func TestFanOutAccountIDs(t *testing.T) {
+	var mu sync.Mutex
+	var seen []string
+
+	results, err := account.FanOut(&account.FanOutParams{
+		AccountIDs:  []string{"acct_1", "acct_2", "acct_3"},
+		Concurrency: 2,
+	}, func(params *stripe.Params) (interface{}, error) {
+		mu.Lock()
+		seen = append(seen, *params.StripeAccount)
+		mu.Unlock()
+
+		if *params.StripeAccount == "acct_2" {
+			return nil, errors.New("boom")
+		}
+		return "ok " + *params.StripeAccount, nil
+	})
+	assert.NoError(t, err)
+
+	sort.Strings(seen)
+	assert.Equal(t, []string{"acct_1", "acct_2", "acct_3"}, seen)
+
+	assert.Equal(t, 3, len(results))
+	assert.Equal(t, "acct_1", results[0].AccountID)
+	assert.Equal(t, "ok acct_1", results[0].Value)
+	assert.Equal(t, "acct_3", results[2].AccountID)
+
+	failed := results.Failed()
+	assert.Equal(t, 1, len(failed))
+	assert.Equal(t, "acct_2", failed[0].AccountID)
+	assert.EqualError(t, results.Errors()["acct_2"], "boom")
+}
+
+func TestFanOutListsAccounts(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/accounts", r.URL.Path)
+		if r.URL.Query().Get("starting_after") == "" {
+			fmt.Fprint(w, `{"object":"list","has_more":true,"data":[{"id":"acct_1"},{"id":"acct_2"}]}`)
+		} else {
+			assert.Equal(t, "acct_2", r.URL.Query().Get("starting_after"))
+			fmt.Fprint(w, `{"object":"list","has_more":false,"data":[{"id":"acct_3"}]}`)
+		}
+	}))
+	defer ts.Close()
+
+	c := account.Client{B: stripetest.NewBackend(stripe.APIBackend, ts.URL), Key: "sk_test_123"}
+	params := &account.FanOutParams{ListParams: &stripe.AccountListParams{}}
+	results, err := c.FanOut(params, func(params *stripe.Params) (interface{}, error) {
+		return *params.StripeAccount, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(results))
+	for i, res := range results {
+		assert.Equal(t, fmt.Sprintf("acct_%d", i+1), res.Value)
+	}
+	assert.Nil(t, params.ListParams.Context)
+}
+
+func TestFanOutRetriesRateLimits(t *testing.T) {
+	attempts := 0
+	results, err := account.FanOut(&account.FanOutParams{
+		AccountIDs:       []string{"acct_1"},
+		RateLimitBackoff: time.Millisecond,
+	}, func(params *stripe.Params) (interface{}, error) {
+		attempts++
+		if attempts < 3 {
+			return nil, &stripe.Error{HTTPStatusCode: 429, Code: stripe.ErrorCodeRateLimit}
+		}
+		return "ok", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+	assert.Nil(t, results[0].Err)
+	assert.Equal(t, "ok", results[0].Value)
+}
+
+func TestFanOutGivesUpOnRateLimits(t *testing.T) {
+	attempts := 0
+	results, err := account.FanOut(&account.FanOutParams{
+		AccountIDs:          []string{"acct_1"},
+		MaxRateLimitRetries: 1,
+		RateLimitBackoff:    time.Millisecond,
+	}, func(params *stripe.Params) (interface{}, error) {
+		attempts++
+		return nil, &stripe.Error{HTTPStatusCode: 429, Code: stripe.ErrorCodeRateLimit}
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+	assert.Error(t, results[0].Err)
+}
@@ -283,7 +283,7 @@ type BankAccountParams struct {
 	// Four digit number representing the card's expiration year.
 	ExpYear *string `form:"exp_year"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format. Individual keys can be unset by posting an empty value to them. All keys can be unset by posting an empty value to `metadata`.
-	Metadata map[string]string `form:"metadata"`
+	Metadata Metadata `form:"metadata"`
 	// Cardholder name.
 	Name *string `form:"name"`
 	// The ID of a Payment Method with a `type` of `us_bank_account`. The Payment Method's bank account information will be copied and
@@ -371,7 +371,7 @@ func (p *BankAccountParams) AddExpand(f string) {
 // AddMetadata adds a new key-value pair to the Metadata.
 func (p *BankAccountParams) AddMetadata(key string, value string) {
 	if p.Metadata == nil {
-		p.Metadata = make(map[string]string)
+		p.Metadata = make(Metadata)
 	}
 
 	p.Metadata[key] = value
@@ -490,7 +490,7 @@ type BankAccount struct {
 	// The last four digits of the bank account number.
 	Last4 string `json:"last4"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format.
-	Metadata map[string]string `json:"metadata"`
+	Metadata Metadata `json:"metadata"`
 	// String representing the object's type. Objects of the same type share the same value.
 	Object string `json:"object"`
 	// Information about the requirements for the bank account, including what information needs to be collected.
@@ -101,7 +101,7 @@ type BillingCreditGrantParams struct {
 	// The time when the billing credits created by this credit grant expire. If set to empty, the billing credits never expire.
 	ExpiresAt *int64 `form:"expires_at"`
 	// Set of key-value pairs that you can attach to an object. You can use this to store additional information about the object (for example, cost basis) in a structured format.
-	Metadata map[string]string `form:"metadata"`
+	Metadata Metadata `form:"metadata"`
 	// A descriptive name shown in the Dashboard.
 	Name *string `form:"name"`
 	// The desired priority for applying this credit grant. If not specified, it will be set to the default value of 50. The highest priority is 0 and the lowest is 100.
@@ -116,7 +116,7 @@ func (p *BillingCreditGrantParams) AddExpand(f string) {
 // AddMetadata adds a new key-value pair to the Metadata.
 func (p *BillingCreditGrantParams) AddMetadata(key string, value string) {
 	if p.Metadata == nil {
-		p.Metadata = make(map[string]string)
+		p.Metadata = make(Metadata)
 	}
 
 	p.Metadata[key] = value
@@ -197,7 +197,7 @@ type BillingCreditGrant struct {
 	// Has the value `true` if the object exists in live mode or the value `false` if the object exists in test mode.
 	Livemode bool `json:"livemode"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format.
-	Metadata map[string]string `json:"metadata"`
+	Metadata Metadata `json:"metadata"`
 	// A descriptive name shown in dashboard.
 	Name string `json:"name"`
 	// String representing the object's type. Objects of the same type share the same value.
@@ -222,7 +222,7 @@ type BillingPortalConfigurationParams struct {
 	// The hosted login page for this configuration. Learn more about the portal login page in our [integration docs](https://stripe.com/docs/billing/subscriptions/integrating-customer-portal#share).
 	LoginPage *BillingPortalConfigurationLoginPageParams `form:"login_page"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format. Individual keys can be unset by posting an empty value to them. All keys can be unset by posting an empty value to `metadata`.
-	Metadata map[string]string `form:"metadata"`
+	Metadata Metadata `form:"metadata"`
 }
 
 // AddExpand appends a new field to expand.
@@ -233,7 +233,7 @@ func (p *BillingPortalConfigurationParams) AddExpand(f string) {
 // AddMetadata adds a new key-value pair to the Metadata.
 func (p *BillingPortalConfigurationParams) AddMetadata(key string, value string) {
 	if p.Metadata == nil {
-		p.Metadata = make(map[string]string)
+		p.Metadata = make(Metadata)
 	}
 
 	p.Metadata[key] = value
@@ -342,7 +342,7 @@ type BillingPortalConfiguration struct {
 	Livemode  bool                                 `json:"livemode"`
 	LoginPage *BillingPortalConfigurationLoginPage `json:"login_page"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format.
-	Metadata map[string]string `json:"metadata"`
+	Metadata Metadata `json:"metadata"`
 	// String representing the object's type. Objects of the same type share the same value.
 	Object string `json:"object"`
 	// Time at which the object was last updated. Measured in seconds since the Unix epoch.
@@ -0,0 +1,316 @@
+// Package bulk provides an executor for applying an operation to a large
+// number of Stripe objects, like migrating every subscription to a new price.
+//
+// Items come from a Source, which is implemented by every list iterator
+// (for example the one returned by subscription.List) and by Slice. Every
+// item is given a deterministic idempotency key derived from the job and item
+// IDs, so an interrupted job can safely be run again: items recorded in the
+// job's CheckpointStore are skipped, and requests that went through but
+// weren't checkpointed are deduplicated by Stripe.
+//
+// Stripe keeps the response of a request for 24 hours after it's made with an
+// idempotency key, including error responses, so items that failed are
+// replayed with the same error when the job is run again within that time. Set
+// Job.Attempt to a new value to retry them for real.
+package bulk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+
+	stripe "github.com/stripe/stripe-go/v81"
+)
+
+//
+// Public constants
+//
+
+// DefaultConcurrency is the number of items processed at the same time when
+// Job.Concurrency isn't set.
+const DefaultConcurrency = 10
+
+//
+// Public types
+//
+
+// IDFunc returns the ID of an item.
+type IDFunc func(item interface{}) (string, error)
+
+// Job describes a bulk operation.
+type Job struct {
+	// Attempt is mixed into the idempotency keys of items when it's not 0.
+	// Rerunning a job with the same Attempt replays the responses of requests
+	// made in the last 24 hours, including failed ones, whereas a new Attempt
+	// retries items that failed. Items which went through but weren't
+	// checkpointed are made again too, so it should only be changed along
+	// with a CheckpointStore, or for operations that are safe to repeat.
+	Attempt int
+
+	// Checkpoints records which items have been processed so that a job can be
+	// resumed. If left unset, no checkpoints are recorded.
+	Checkpoints CheckpointStore
+
+	// Concurrency is the maximum number of items processed at the same time.
+	//
+	// Defaults to DefaultConcurrency.
+	Concurrency int
+
+	// Context used for the job. When it's canceled, no new items are started
+	// and Run returns the context's error. It's also passed on to every
+	// Operation through Params.Context.
+	Context context.Context
+
+	// DryRun makes the job go through every item and report it with
+	// StatusDryRun without invoking the Operation or recording checkpoints.
+	DryRun bool
+
+	// ID identifies the job. It's used to derive idempotency keys and to
+	// record checkpoints, so it must be stable across runs of the same job
+	// and different between jobs.
+	ID string
+
+	// ItemID returns the ID of an item.
+	//
+	// Defaults to using items that are strings as their own ID, and the `ID`
+	// field of items that are pointers to structs, like all Stripe objects.
+	ItemID IDFunc
+
+	// Operation is applied to every item.
+	Operation Operation
+}
+
+// Operation is the function applied to every item of a job.
+//
+// The given Params has its IdempotencyKey and Context set, and should be
+// embedded into the parameters of any mutating call so that retries of the
+// job are safe:
+//
+//	func(item interface{}, params *stripe.Params) (interface{}, error) {
+//		sub := item.(*stripe.Subscription)
+//		return subscription.Update(sub.ID, &stripe.SubscriptionParams{
+//			Params: *params,
+//			...
+//		})
+//	}
+//
+// Operations that make more than one mutating call should derive a separate
+// key for each of them, for example with stripe.NewDeterministicIdempotencyKey.
+type Operation func(item interface{}, params *stripe.Params) (interface{}, error)
+
+// Source is a stream of items. It's implemented by all list and search
+// iterators.
+type Source interface {
+	Current() interface{}
+	Err() error
+	Next() bool
+}
+
+//
+// Public functions
+//
+
+// Slice returns a Source over the elements of a slice of any type, like
+// []string or []*stripe.Subscription. It panics if items isn't a slice.
+func Slice(items interface{}) Source {
+	v := reflect.ValueOf(items)
+	if v.Kind() != reflect.Slice {
+		panic(fmt.Errorf("bulk: Slice expects a slice, got %T", items))
+	}
+	return &sliceSource{v: v, i: -1}
+}
+
+// Run applies the job's Operation to every item of src and returns a report
+// of the outcome for each of them.
+//
+// Errors returned by the Operation are recorded in the report and don't stop
+// the job. The returned error is set when the source, the checkpoint store or
+// the job's context failed, in which case the report covers the items
+// processed up to that point.
+func (j *Job) Run(src Source) (*Report, error) {
+	if j.ID == "" {
+		return nil, errors.New("bulk: Job.ID must be set")
+	}
+	if j.Operation == nil && !j.DryRun {
+		return nil, errors.New("bulk: Job.Operation must be set")
+	}
+
+	ctx := j.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	concurrency := j.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+
+	itemID := j.ItemID
+	if itemID == nil {
+		itemID = defaultItemID
+	}
+
+	report := &Report{JobID: j.ID, DryRun: j.DryRun}
+
+	var mu sync.Mutex
+	var firstErr error
+	setErr := func(err error) {
+		mu.Lock()
+		if firstErr == nil {
+			firstErr = err
+		}
+		mu.Unlock()
+	}
+	getErr := func() error {
+		mu.Lock()
+		defer mu.Unlock()
+		return firstErr
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+
+	for getErr() == nil && src.Next() {
+		if err := ctx.Err(); err != nil {
+			setErr(err)
+			break
+		}
+
+		item := src.Current()
+
+		id, err := itemID(item)
+		if err != nil {
+			setErr(err)
+			break
+		}
+
+		result := &Result{
+			IdempotencyKey: j.idempotencyKey(id),
+			ItemID:         id,
+		}
+
+		if j.Checkpoints != nil {
+			done, err := j.Checkpoints.IsDone(j.ID, id)
+			if err != nil {
+				setErr(fmt.Errorf("bulk: reading checkpoint for %s: %v", id, err))
+				break
+			}
+			if done {
+				result.Status = StatusSkipped
+				report.Results = append(report.Results, result)
+				continue
+			}
+		}
+
+		if j.DryRun {
+			result.Status = StatusDryRun
+			report.Results = append(report.Results, result)
+			continue
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			setErr(ctx.Err())
+		}
+		if getErr() != nil {
+			break
+		}
+
+		report.Results = append(report.Results, result)
+
+		wg.Add(1)
+		go func(item interface{}, result *Result) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			value, err := j.Operation(item, &stripe.Params{
+				Context:        ctx,
+				IdempotencyKey: stripe.String(result.IdempotencyKey),
+			})
+			result.Value = value
+			if err != nil {
+				result.Status = StatusFailed
+				result.Err = err
+				result.Error = err.Error()
+				var stripeErr *stripe.Error
+				if errors.As(err, &stripeErr) {
+					result.Error = stripeErr.Msg
+					result.RequestID = stripeErr.RequestID
+				}
+				return
+			}
+
+			result.Status = StatusSucceeded
+			if j.Checkpoints != nil {
+				if err := j.Checkpoints.MarkDone(j.ID, result.ItemID); err != nil {
+					setErr(fmt.Errorf("bulk: writing checkpoint for %s: %v", result.ItemID, err))
+				}
+			}
+		}(item, result)
+	}
+
+	wg.Wait()
+
+	if err := getErr(); err != nil {
+		return report, err
+	}
+	return report, src.Err()
+}
+
+//
+// Private types
+//
+
+type sliceSource struct {
+	i int
+	v reflect.Value
+}
+
+func (s *sliceSource) Current() interface{} {
+	return s.v.Index(s.i).Interface()
+}
+
+func (s *sliceSource) Err() error {
+	return nil
+}
+
+func (s *sliceSource) Next() bool {
+	if s.i+1 >= s.v.Len() {
+		return false
+	}
+	s.i++
+	return true
+}
+
+//
+// Private functions
+//
+
+func defaultItemID(item interface{}) (string, error) {
+	if id, ok := item.(string); ok {
+		return id, nil
+	}
+
+	v := reflect.ValueOf(item)
+	if v.Kind() == reflect.Ptr && !v.IsNil() && v.Elem().Kind() == reflect.Struct {
+		if f := v.Elem().FieldByName("ID"); f.IsValid() && f.Kind() == reflect.String && f.String() != "" {
+			return f.String(), nil
+		}
+	}
+	return "", fmt.Errorf("bulk: can't determine the ID of item of type %T, set Job.ItemID", item)
+}
+
+// idempotencyKey returns the idempotency key of an item, derived from the
+// job ID, the item ID and the attempt if set.
+func (j *Job) idempotencyKey(id string) string {
+	if j.Attempt == 0 {
+		return stripe.NewDeterministicIdempotencyKey(j.ID, id)
+	}
+	return stripe.NewDeterministicIdempotencyKey(j.ID, id, strconv.Itoa(j.Attempt))
+}
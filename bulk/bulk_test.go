@@ -0,0 +1,237 @@
+package bulk
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+	stripe "github.com/stripe/stripe-go/v81"
+	"github.com/stripe/stripe-go/v81/form"
+)
+
+func TestJobRun(t *testing.T) {
+	var mu sync.Mutex
+	keys := make(map[string]string)
+
+	job := &Job{
+		ID:          "job_1",
+		Concurrency: 2,
+		Operation: func(item interface{}, params *stripe.Params) (interface{}, error) {
+			sub := item.(*stripe.Subscription)
+			mu.Lock()
+			keys[sub.ID] = *params.IdempotencyKey
+			mu.Unlock()
+
+			if sub.ID == "sub_2" {
+				return nil, &stripe.Error{Msg: "No such price", RequestID: "req_123"}
+			}
+			return sub, nil
+		},
+	}
+
+	report, err := job.Run(Slice([]*stripe.Subscription{{ID: "sub_1"}, {ID: "sub_2"}, {ID: "sub_3"}}))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(report.Results))
+	assert.Equal(t, 2, report.Count(StatusSucceeded))
+
+	failed := report.Failed()
+	assert.Equal(t, 1, len(failed))
+	assert.Equal(t, "sub_2", failed[0].ItemID)
+	assert.Equal(t, "No such price", failed[0].Error)
+	assert.Equal(t, "req_123", failed[0].RequestID)
+
+	assert.Equal(t, stripe.NewDeterministicIdempotencyKey("job_1", "sub_1"), keys["sub_1"])
+	assert.Equal(t, report.Results[0].IdempotencyKey, keys["sub_1"])
+}
+
+func TestJobRunIter(t *testing.T) {
+	pages := [][]interface{}{
+		{&stripe.Subscription{ID: "sub_1"}, &stripe.Subscription{ID: "sub_2"}},
+		{&stripe.Subscription{ID: "sub_3"}},
+	}
+	iter := stripe.GetIter(nil, func(*stripe.Params, *form.Values) ([]interface{}, stripe.ListContainer, error) {
+		page := pages[0]
+		pages = pages[1:]
+		return page, &stripe.ListMeta{HasMore: len(pages) > 0}, nil
+	})
+
+	var count int32
+	var mu sync.Mutex
+	job := &Job{
+		ID: "job_1",
+		Operation: func(item interface{}, params *stripe.Params) (interface{}, error) {
+			mu.Lock()
+			count++
+			mu.Unlock()
+			return nil, nil
+		},
+	}
+
+	report, err := job.Run(iter)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), count)
+	assert.Equal(t, 3, report.Count(StatusSucceeded))
+}
+
+func TestJobRunSourceError(t *testing.T) {
+	iter := stripe.GetIter(nil, func(*stripe.Params, *form.Values) ([]interface{}, stripe.ListContainer, error) {
+		return nil, &stripe.ListMeta{}, errors.New("list failed")
+	})
+
+	job := &Job{ID: "job_1", Operation: func(interface{}, *stripe.Params) (interface{}, error) { return nil, nil }}
+	_, err := job.Run(iter)
+	assert.EqualError(t, err, "list failed")
+}
+
+func TestJobRunCheckpoints(t *testing.T) {
+	store := NewMemoryCheckpointStore()
+	assert.NoError(t, store.MarkDone("job_1", "sub_1"))
+
+	var processed []string
+	job := &Job{
+		Checkpoints: store,
+		Concurrency: 1,
+		ID:          "job_1",
+		Operation: func(item interface{}, params *stripe.Params) (interface{}, error) {
+			processed = append(processed, item.(string))
+			return nil, nil
+		},
+	}
+
+	report, err := job.Run(Slice([]string{"sub_1", "sub_2"}))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sub_2"}, processed)
+	assert.Equal(t, StatusSkipped, report.Results[0].Status)
+	assert.Equal(t, StatusSucceeded, report.Results[1].Status)
+
+	done, err := store.IsDone("job_1", "sub_2")
+	assert.NoError(t, err)
+	assert.True(t, done)
+
+	// A second run doesn't process anything.
+	processed = nil
+	report, err = job.Run(Slice([]string{"sub_1", "sub_2"}))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(processed))
+	assert.Equal(t, 2, report.Count(StatusSkipped))
+}
+
+func TestJobRunDryRun(t *testing.T) {
+	job := &Job{
+		DryRun: true,
+		ID:     "job_1",
+		Operation: func(interface{}, *stripe.Params) (interface{}, error) {
+			t.Fatal("operation should not be called")
+			return nil, nil
+		},
+	}
+
+	report, err := job.Run(Slice([]string{"sub_1", "sub_2"}))
+	assert.NoError(t, err)
+	assert.True(t, report.DryRun)
+	assert.Equal(t, 2, report.Count(StatusDryRun))
+}
+
+func TestJobRunUnknownItemID(t *testing.T) {
+	job := &Job{ID: "job_1", Operation: func(interface{}, *stripe.Params) (interface{}, error) { return nil, nil }}
+	_, err := job.Run(Slice([]int{1}))
+	assert.Error(t, err)
+}
+
+func TestFileCheckpointStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bulk")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "checkpoints")
+
+	store, err := NewFileCheckpointStore(path)
+	assert.NoError(t, err)
+	assert.NoError(t, store.MarkDone("job_1", "sub_1"))
+	assert.NoError(t, store.Close())
+
+	store, err = NewFileCheckpointStore(path)
+	assert.NoError(t, err)
+	defer store.Close()
+
+	done, err := store.IsDone("job_1", "sub_1")
+	assert.NoError(t, err)
+	assert.True(t, done)
+
+	done, err = store.IsDone("job_2", "sub_1")
+	assert.NoError(t, err)
+	assert.False(t, done)
+}
+
+func TestCheckpointKeyCollisions(t *testing.T) {
+	store := NewMemoryCheckpointStore()
+	assert.NoError(t, store.MarkDone("a b", "c"))
+
+	done, err := store.IsDone("a", "b c")
+	assert.NoError(t, err)
+	assert.False(t, done)
+
+	done, err = store.IsDone("a b", "c")
+	assert.NoError(t, err)
+	assert.True(t, done)
+}
+
+func TestReportWrite(t *testing.T) {
+	report := &Report{
+		JobID: "job_1",
+		Results: []*Result{
+			{ItemID: "sub_1", Status: StatusSucceeded, IdempotencyKey: "key_1"},
+			{ItemID: "sub_2", Status: StatusFailed, IdempotencyKey: "key_2", Error: "bad, things", RequestID: "req_1"},
+		},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, report.WriteCSV(&buf))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, []string{
+		"job_id,item_id,status,idempotency_key,request_id,error",
+		"job_1,sub_1,succeeded,key_1,,",
+		`job_1,sub_2,failed,key_2,req_1,"bad, things"`,
+	}, lines)
+
+	buf.Reset()
+	assert.NoError(t, report.WriteJSON(&buf))
+	var decoded Report
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, "job_1", decoded.JobID)
+	assert.Equal(t, StatusFailed, decoded.Results[1].Status)
+	assert.Equal(t, "bad, things", decoded.Results[1].Error)
+}
+
+func TestJobRunAttempt(t *testing.T) {
+	var keys []string
+	job := &Job{
+		ID:          "job_1",
+		Concurrency: 1,
+		Operation: func(item interface{}, params *stripe.Params) (interface{}, error) {
+			keys = append(keys, *params.IdempotencyKey)
+			return nil, nil
+		},
+	}
+	_, err := job.Run(Slice([]string{"sub_1"}))
+	assert.NoError(t, err)
+
+	job.Attempt = 1
+	_, err = job.Run(Slice([]string{"sub_1"}))
+	assert.NoError(t, err)
+	job.Attempt = 2
+	_, err = job.Run(Slice([]string{"sub_1"}))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{
+		stripe.NewDeterministicIdempotencyKey("job_1", "sub_1"),
+		stripe.NewDeterministicIdempotencyKey("job_1", "sub_1", "1"),
+		stripe.NewDeterministicIdempotencyKey("job_1", "sub_1", "2"),
+	}, keys)
+}
@@ -0,0 +1,128 @@
+package bulk
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sync"
+)
+
+//
+// Public types
+//
+
+// CheckpointStore records which items of a job have been processed
+// successfully. Implementations must be safe for concurrent use.
+type CheckpointStore interface {
+	// IsDone returns whether the item was already processed by the job.
+	IsDone(jobID, itemID string) (bool, error)
+
+	// MarkDone records that the item was processed by the job.
+	MarkDone(jobID, itemID string) error
+}
+
+// FileCheckpointStore is a CheckpointStore that appends checkpoints to a
+// file, one per line, so that they survive a restart of the process.
+type FileCheckpointStore struct {
+	done map[string]bool
+	f    *os.File
+	mu   sync.Mutex
+}
+
+// MemoryCheckpointStore is a CheckpointStore that keeps checkpoints in
+// memory. It's mostly useful for tests and for retrying failed items within
+// the same process.
+type MemoryCheckpointStore struct {
+	done map[string]bool
+	mu   sync.Mutex
+}
+
+//
+// Public functions
+//
+
+// NewFileCheckpointStore opens the checkpoint file at path, creating it if
+// needed, and loads the checkpoints it already contains.
+func NewFileCheckpointStore(path string) (*FileCheckpointStore, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	done := make(map[string]bool)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			done[line] = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return &FileCheckpointStore{done: done, f: f}, nil
+}
+
+// NewMemoryCheckpointStore returns an empty MemoryCheckpointStore.
+func NewMemoryCheckpointStore() *MemoryCheckpointStore {
+	return &MemoryCheckpointStore{done: make(map[string]bool)}
+}
+
+// Close closes the underlying checkpoint file.
+func (s *FileCheckpointStore) Close() error {
+	return s.f.Close()
+}
+
+// IsDone returns whether the item was already processed by the job.
+func (s *FileCheckpointStore) IsDone(jobID, itemID string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.done[checkpointKey(jobID, itemID)], nil
+}
+
+// MarkDone records that the item was processed by the job and syncs it to
+// disk.
+func (s *FileCheckpointStore) MarkDone(jobID, itemID string) error {
+	key := checkpointKey(jobID, itemID)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.done[key] {
+		return nil
+	}
+	if _, err := fmt.Fprintln(s.f, key); err != nil {
+		return err
+	}
+	if err := s.f.Sync(); err != nil {
+		return err
+	}
+	s.done[key] = true
+	return nil
+}
+
+// IsDone returns whether the item was already processed by the job.
+func (s *MemoryCheckpointStore) IsDone(jobID, itemID string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.done[checkpointKey(jobID, itemID)], nil
+}
+
+// MarkDone records that the item was processed by the job.
+func (s *MemoryCheckpointStore) MarkDone(jobID, itemID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.done[checkpointKey(jobID, itemID)] = true
+	return nil
+}
+
+//
+// Private functions
+//
+
+// checkpointKey returns the key of an item of a job. The job ID is length
+// prefixed so that ("a b", "c") and ("a", "b c") don't collide.
+func checkpointKey(jobID, itemID string) string {
+	return fmt.Sprintf("%d:%s %s", len(jobID), jobID, itemID)
+}
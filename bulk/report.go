@@ -0,0 +1,95 @@
+package bulk
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+)
+
+//
+// Public types
+//
+
+// Status is the outcome of processing an item.
+type Status string
+
+// List of values that Status can take.
+const (
+	StatusDryRun    Status = "dry_run"
+	StatusFailed    Status = "failed"
+	StatusSkipped   Status = "skipped"
+	StatusSucceeded Status = "succeeded"
+)
+
+// Report is the outcome of running a job.
+type Report struct {
+	DryRun  bool      `json:"dry_run"`
+	JobID   string    `json:"job_id"`
+	Results []*Result `json:"results"`
+}
+
+// Result is the outcome of processing a single item.
+type Result struct {
+	// Err is the error returned by the Operation, if any.
+	Err error `json:"-"`
+
+	// Error is the message of Err. For Stripe errors, it's the API's error
+	// message.
+	Error string `json:"error,omitempty"`
+
+	IdempotencyKey string `json:"idempotency_key"`
+	ItemID         string `json:"item_id"`
+
+	// RequestID is the ID of the failed request when the Operation returned
+	// a Stripe error.
+	RequestID string `json:"request_id,omitempty"`
+
+	Status Status `json:"status"`
+
+	// Value is the value returned by the Operation.
+	Value interface{} `json:"-"`
+}
+
+// Count returns the number of results with the given status.
+func (r *Report) Count(status Status) int {
+	n := 0
+	for _, res := range r.Results {
+		if res.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// Failed returns the results of the items for which the Operation returned
+// an error.
+func (r *Report) Failed() []*Result {
+	var failed []*Result
+	for _, res := range r.Results {
+		if res.Status == StatusFailed {
+			failed = append(failed, res)
+		}
+	}
+	return failed
+}
+
+// WriteCSV writes the report as CSV with a header row and one row per item.
+func (r *Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"job_id", "item_id", "status", "idempotency_key", "request_id", "error"}); err != nil {
+		return err
+	}
+	for _, res := range r.Results {
+		record := []string{r.JobID, res.ItemID, string(res.Status), res.IdempotencyKey, res.RequestID, res.Error}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSON writes the report as a JSON object.
+func (r *Report) WriteJSON(w io.Writer) error {
+	return json.NewEncoder(w).Encode(r)
+}
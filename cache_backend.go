@@ -0,0 +1,358 @@
+package stripe
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stripe/stripe-go/v81/form"
+)
+
+//
+// Public constants
+//
+
+// DefaultCacheMaxEntries is the maximum number of responses kept by a
+// CachingBackend when CacheConfig.MaxEntries isn't set.
+const DefaultCacheMaxEntries = 1000
+
+//
+// Public types
+//
+
+// CacheConfig is used to configure a CachingBackend.
+type CacheConfig struct {
+	// DefaultTTL is how long responses for objects whose type isn't listed in
+	// TTLs are cached.
+	//
+	// Defaults to zero, which means that only the object types listed in TTLs
+	// are cached.
+	DefaultTTL time.Duration
+
+	// MaxEntries is the maximum number of responses kept in the cache. When
+	// it's reached, the least recently used response is evicted.
+	//
+	// Defaults to DefaultCacheMaxEntries.
+	MaxEntries int
+
+	// TTLs is how long responses are cached, keyed by the type of object they
+	// contain, like `price` or `tax_rate`. A zero TTL disables caching for the
+	// type.
+	TTLs map[string]time.Duration
+}
+
+// CachingBackend is a wrapper for stripe.Backend that caches the responses of
+// GET requests retrieving a single object, so that objects read often but
+// rarely changed, like prices or tax rates, don't need a round trip to
+// Stripe on every use.
+//
+// Responses are keyed by API key, Stripe-Account, path and parameters, and
+// are kept for the TTL configured for the type of object they contain. Any
+// POST or DELETE request made through the backend invalidates the responses
+// cached for its path, and InvalidateEvent can be called from a webhook
+// handler so that changes made elsewhere are picked up too.
+//
+// List requests, streaming requests and uploads are never cached.
+type CachingBackend struct {
+	B Backend
+
+	config  CacheConfig
+	entries map[string]*list.Element
+	lru     *list.List
+	mu      sync.Mutex
+	now     func() time.Time
+}
+
+// NewCachingBackend returns a CachingBackend wrapping b.
+func NewCachingBackend(b Backend, config *CacheConfig) *CachingBackend {
+	var cfg CacheConfig
+	if config != nil {
+		cfg = *config
+	}
+	if cfg.MaxEntries <= 0 {
+		cfg.MaxEntries = DefaultCacheMaxEntries
+	}
+
+	return &CachingBackend{
+		B:       b,
+		config:  cfg,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+		now:     time.Now,
+	}
+}
+
+// Call is the Backend.Call implementation which serves GET requests from the
+// cache when possible.
+func (c *CachingBackend) Call(method, path, key string, params ParamsContainer, v LastResponseSetter) error {
+	if method != http.MethodGet {
+		err := c.B.Call(method, path, key, params, v)
+		c.invalidatePath(path)
+		return err
+	}
+
+	formValues, commonParams, err := extractParams(params)
+	if err != nil {
+		return err
+	}
+
+	cacheKey := newRequestKey(path, key, formValues, commonParams)
+	if entry := c.get(cacheKey); entry != nil {
+		if err := json.Unmarshal(entry.response.RawJSON, v); err != nil {
+			return err
+		}
+		v.SetLastResponse(copyResponse(entry.response))
+		return nil
+	}
+
+	setter := &capturingResponseSetter{LastResponseSetter: v}
+	if err := c.B.Call(method, path, key, params, setter); err != nil {
+		return err
+	}
+	if setter.response != nil {
+		c.add(cacheKey, path, setter.response)
+	}
+	return nil
+}
+
+// CallMultipart is the Backend.CallMultipart implementation. Uploads are
+// never cached.
+func (c *CachingBackend) CallMultipart(method, path, key, boundary string, body *bytes.Buffer, params *Params, v LastResponseSetter) error {
+	return c.B.CallMultipart(method, path, key, boundary, body, params, v)
+}
+
+// CallMultipartStreaming is the
+// MultipartStreamingBackend.CallMultipartStreaming implementation. Uploads
+// are never cached.
+func (c *CachingBackend) CallMultipartStreaming(method, path, key string, body *MultipartBody, params *Params, v LastResponseSetter) error {
+	return CallMultipartStreaming(c.B, method, path, key, body, params, v)
+}
+
+// CallRaw is the Backend.CallRaw implementation. It's used by list requests,
+// which are never cached.
+func (c *CachingBackend) CallRaw(method, path, key string, body *form.Values, params *Params, v LastResponseSetter) error {
+	err := c.B.CallRaw(method, path, key, body, params, v)
+	if method != http.MethodGet {
+		c.invalidatePath(path)
+	}
+	return err
+}
+
+// CallStreaming is the Backend.CallStreaming implementation. Streaming
+// responses are never cached.
+func (c *CachingBackend) CallStreaming(method, path, key string, params ParamsContainer, v StreamingLastResponseSetter) error {
+	return c.B.CallStreaming(method, path, key, params, v)
+}
+
+// Invalidate removes all cached responses containing the object with the
+// given ID.
+func (c *CachingBackend) Invalidate(id string) {
+	c.removeWhere(func(e *cacheEntry) bool {
+		return e.objectID == id
+	})
+}
+
+// InvalidateEvent removes all cached responses containing the object that
+// the event is about. It's meant to be called for every event received by a
+// webhook handler, like `price.updated` or `product.deleted`.
+func (c *CachingBackend) InvalidateEvent(event *Event) {
+	if event == nil || event.Data == nil {
+		return
+	}
+	if id := event.GetObjectValue("id"); id != "" {
+		c.Invalidate(id)
+	}
+}
+
+// InvalidateType removes all cached responses containing objects of the given
+// type, like `price`.
+func (c *CachingBackend) InvalidateType(objectType string) {
+	c.removeWhere(func(e *cacheEntry) bool {
+		return e.objectType == objectType
+	})
+}
+
+// Len returns the number of responses currently cached.
+func (c *CachingBackend) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// Purge removes all cached responses.
+func (c *CachingBackend) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]*list.Element)
+	c.lru.Init()
+}
+
+// SetMaxNetworkRetries sets max number of retries on failed requests of the
+// wrapped backend.
+func (c *CachingBackend) SetMaxNetworkRetries(maxNetworkRetries int64) {
+	c.B.SetMaxNetworkRetries(maxNetworkRetries)
+}
+
+//
+// Private types
+//
+
+type cacheEntry struct {
+	expiresAt  time.Time
+	key        string
+	objectID   string
+	objectType string
+	path       string
+	response   *APIResponse
+}
+
+// capturingResponseSetter captures the response of a request so that it can be
+// cached or shared, while still setting it on the wrapped LastResponseSetter.
+type capturingResponseSetter struct {
+	LastResponseSetter
+	response *APIResponse
+}
+
+func (s *capturingResponseSetter) SetLastResponse(response *APIResponse) {
+	s.response = response
+	s.LastResponseSetter.SetLastResponse(response)
+}
+
+func (s *capturingResponseSetter) UnmarshalJSON(b []byte) error {
+	return json.Unmarshal(b, s.LastResponseSetter)
+}
+
+//
+// Private functions
+//
+
+// copyResponse copies a response, including its Header and RawJSON, so that
+// callers changing the responses they're given don't change the cache.
+func copyResponse(response *APIResponse) *APIResponse {
+	responseCopy := *response
+	responseCopy.Header = response.Header.Clone()
+	responseCopy.RawJSON = append([]byte(nil), response.RawJSON...)
+	return &responseCopy
+}
+
+// newRequestKey derives a key identifying a GET request from everything that
+// can change its response. The API key is hashed along with the rest so that
+// it isn't kept around in memory in the clear.
+func newRequestKey(path, key string, formValues *form.Values, params *Params) string {
+	var account, headers string
+	if params != nil {
+		if params.StripeAccount != nil {
+			account = strings.TrimSpace(*params.StripeAccount)
+		}
+		if len(params.Headers) > 0 {
+			var buf bytes.Buffer
+			params.Headers.Write(&buf)
+			headers = buf.String()
+		}
+	}
+
+	var query string
+	if formValues != nil {
+		query = formValues.Encode()
+	}
+
+	h := sha256.New()
+	for _, part := range []string{key, account, headers, path, query} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (c *CachingBackend) add(key, path string, response *APIResponse) {
+	var object struct {
+		ID     string `json:"id"`
+		Object string `json:"object"`
+	}
+	if err := json.Unmarshal(response.RawJSON, &object); err != nil || object.Object == "" || object.Object == "list" {
+		return
+	}
+
+	ttl, ok := c.config.TTLs[object.Object]
+	if !ok {
+		ttl = c.config.DefaultTTL
+	}
+	if ttl <= 0 {
+		return
+	}
+
+	entry := &cacheEntry{
+		expiresAt:  c.now().Add(ttl),
+		key:        key,
+		objectID:   object.ID,
+		objectType: object.Object,
+		path:       path,
+		response:   copyResponse(response),
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.lru.Remove(elem)
+	}
+	c.entries[key] = c.lru.PushFront(entry)
+
+	for c.lru.Len() > c.config.MaxEntries {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+func (c *CachingBackend) get(key string) *cacheEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+
+	entry := elem.Value.(*cacheEntry)
+	if !c.now().Before(entry.expiresAt) {
+		c.lru.Remove(elem)
+		delete(c.entries, key)
+		return nil
+	}
+
+	c.lru.MoveToFront(elem)
+	return entry
+}
+
+// invalidatePath removes the cached responses for a path that was just
+// written to, like `/v1/prices/price_123` after updating that price.
+func (c *CachingBackend) invalidatePath(path string) {
+	if i := strings.Index(path, "?"); i >= 0 {
+		path = path[:i]
+	}
+	c.removeWhere(func(e *cacheEntry) bool {
+		return e.path == path || strings.HasPrefix(path, e.path+"/")
+	})
+}
+
+func (c *CachingBackend) removeWhere(match func(*cacheEntry) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for elem := c.lru.Front(); elem != nil; {
+		next := elem.Next()
+		entry := elem.Value.(*cacheEntry)
+		if match(entry) {
+			c.lru.Remove(elem)
+			delete(c.entries, entry.key)
+		}
+		elem = next
+	}
+}
@@ -0,0 +1,148 @@
+package stripe
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestCachingBackend(t *testing.T) {
+	var mu sync.Mutex
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		n := requests
+		mu.Unlock()
+
+		switch r.URL.Path {
+		case "/v1/prices/price_123":
+			fmt.Fprintf(w, `{"id":"price_123","object":"price","nickname":"v%d"}`, n)
+		case "/v1/customers/cus_123":
+			fmt.Fprintf(w, `{"id":"cus_123","object":"customer","name":"v%d"}`, n)
+		}
+	}))
+	defer ts.Close()
+
+	backend := NewCachingBackend(newTestBackend(ts.URL), &CacheConfig{
+		TTLs: map[string]time.Duration{"price": time.Minute},
+	})
+	now := time.Unix(1600000000, 0)
+	backend.now = func() time.Time { return now }
+
+	getPrice := func(params *PriceParams) *Price {
+		price := &Price{}
+		err := backend.Call(http.MethodGet, "/v1/prices/price_123", "sk_test_123", params, price)
+		assert.NoError(t, err)
+		return price
+	}
+
+	t.Run("Hit", func(t *testing.T) {
+		first := getPrice(nil)
+		second := getPrice(nil)
+		assert.Equal(t, "v1", first.Nickname)
+		assert.Equal(t, "v1", second.Nickname)
+		assert.NotNil(t, second.LastResponse)
+		assert.Equal(t, 1, requests)
+		assert.Equal(t, 1, backend.Len())
+	})
+
+	t.Run("ResponsesCopied", func(t *testing.T) {
+		first := getPrice(nil)
+		first.LastResponse.Header.Set("Request-Id", "req_changed")
+		first.LastResponse.RawJSON[0] = 'x'
+
+		second := getPrice(nil)
+		assert.NotEqual(t, "req_changed", second.LastResponse.Header.Get("Request-Id"))
+		assert.Equal(t, byte('{'), second.LastResponse.RawJSON[0])
+		second.LastResponse.RawJSON[0] = 'x'
+		assert.Equal(t, "v1", getPrice(nil).Nickname)
+		assert.Equal(t, 1, requests)
+	})
+
+	t.Run("KeyedByParamsAndAccount", func(t *testing.T) {
+		params := &PriceParams{}
+		params.AddExpand("product")
+		assert.Equal(t, "v2", getPrice(params).Nickname)
+
+		params = &PriceParams{}
+		params.SetStripeAccount("acct_123")
+		assert.Equal(t, "v3", getPrice(params).Nickname)
+
+		assert.Equal(t, "v1", getPrice(nil).Nickname)
+	})
+
+	t.Run("Expiry", func(t *testing.T) {
+		now = now.Add(2 * time.Minute)
+		assert.Equal(t, "v4", getPrice(nil).Nickname)
+		assert.Equal(t, "v4", getPrice(nil).Nickname)
+	})
+
+	t.Run("InvalidateEvent", func(t *testing.T) {
+		var event Event
+		err := json.Unmarshal([]byte(`{"type":"price.updated","data":{"object":{"id":"price_123","object":"price"}}}`), &event)
+		assert.NoError(t, err)
+
+		backend.InvalidateEvent(&event)
+		assert.Equal(t, "v5", getPrice(nil).Nickname)
+	})
+
+	t.Run("InvalidatedByWrite", func(t *testing.T) {
+		err := backend.Call(http.MethodPost, "/v1/prices/price_123", "sk_test_123", &PriceParams{}, &Price{})
+		assert.NoError(t, err)
+		assert.Equal(t, "v7", getPrice(nil).Nickname)
+	})
+
+	t.Run("UncachedType", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			err := backend.Call(http.MethodGet, "/v1/customers/cus_123", "sk_test_123", nil, &Customer{})
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, 9, requests)
+	})
+
+	t.Run("Purge", func(t *testing.T) {
+		backend.Purge()
+		assert.Equal(t, 0, backend.Len())
+	})
+}
+
+func TestCachingBackendEviction(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"id":"%s","object":"tax_rate"}`, r.URL.Path[len("/v1/tax_rates/"):])
+	}))
+	defer ts.Close()
+
+	backend := NewCachingBackend(newTestBackend(ts.URL), &CacheConfig{
+		DefaultTTL: time.Minute,
+		MaxEntries: 2,
+	})
+
+	for _, id := range []string{"txr_1", "txr_2", "txr_3"} {
+		err := backend.Call(http.MethodGet, "/v1/tax_rates/"+id, "sk_test_123", nil, &TaxRate{})
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 2, backend.Len())
+
+	backend.Invalidate("txr_3")
+	assert.Equal(t, 1, backend.Len())
+
+	backend.InvalidateType("tax_rate")
+	assert.Equal(t, 0, backend.Len())
+}
+
+// newTestBackend is stripetest.NewBackend for the tests of this package,
+// which can't import stripetest since it imports this package.
+func newTestBackend(url string) Backend {
+	return GetBackendWithConfig(APIBackend, &BackendConfig{
+		LeveledLogger:     &LeveledLogger{Level: LevelNull},
+		MaxNetworkRetries: Int64(0),
+		URL:               String(url),
+	})
+}
@@ -147,7 +147,7 @@ type CardParams struct {
 	// Four digit number representing the card's expiration year.
 	ExpYear *string `form:"exp_year"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format. Individual keys can be unset by posting an empty value to them. All keys can be unset by posting an empty value to `metadata`.
-	Metadata map[string]string `form:"metadata"`
+	Metadata Metadata `form:"metadata"`
 	// Cardholder name.
 	Name *string `form:"name"`
 	// The card number, as a string without any separators.
@@ -261,7 +261,7 @@ func (p *CardParams) AddExpand(f string) {
 // AddMetadata adds a new key-value pair to the Metadata.
 func (p *CardParams) AddMetadata(key string, value string) {
 	if p.Metadata == nil {
-		p.Metadata = make(map[string]string)
+		p.Metadata = make(Metadata)
 	}
 
 	p.Metadata[key] = value
@@ -375,7 +375,7 @@ type Card struct {
 	// The last four digits of the card.
 	Last4 string `json:"last4"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format.
-	Metadata map[string]string `json:"metadata"`
+	Metadata Metadata `json:"metadata"`
 	// Cardholder name.
 	Name     string        `json:"name"`
 	Networks *CardNetworks `json:"networks"`
@@ -60,7 +60,7 @@ type ClimateOrderParams struct {
 	// Specifies which fields in the response should be expanded.
 	Expand []*string `form:"expand"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format. Individual keys can be unset by posting an empty value to them. All keys can be unset by posting an empty value to `metadata`.
-	Metadata map[string]string `form:"metadata"`
+	Metadata Metadata `form:"metadata"`
 	// Requested number of tons for the order. Either this or `amount` must be specified.
 	MetricTons *float64 `form:"metric_tons,high_precision"`
 	// Unique identifier of the Climate product.
@@ -75,7 +75,7 @@ func (p *ClimateOrderParams) AddExpand(f string) {
 // AddMetadata adds a new key-value pair to the Metadata.
 func (p *ClimateOrderParams) AddMetadata(key string, value string) {
 	if p.Metadata == nil {
-		p.Metadata = make(map[string]string)
+		p.Metadata = make(Metadata)
 	}
 
 	p.Metadata[key] = value
@@ -165,7 +165,7 @@ type ClimateOrder struct {
 	// Has the value `true` if the object exists in live mode or the value `false` if the object exists in test mode.
 	Livemode bool `json:"livemode"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format.
-	Metadata map[string]string `json:"metadata"`
+	Metadata Metadata `json:"metadata"`
 	// Quantity of carbon removal that is included in this order.
 	MetricTons float64 `json:"metric_tons,string"`
 	// String representing the object's type. Objects of the same type share the same value.
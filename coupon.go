@@ -40,7 +40,7 @@ type CouponParams struct {
 	// A positive integer specifying the number of times the coupon can be redeemed before it's no longer valid. For example, you might have a 50% off coupon that the first 20 readers of your blog can use.
 	MaxRedemptions *int64 `form:"max_redemptions"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format. Individual keys can be unset by posting an empty value to them. All keys can be unset by posting an empty value to `metadata`.
-	Metadata map[string]string `form:"metadata"`
+	Metadata Metadata `form:"metadata"`
 	// Name of the coupon displayed to customers on, for instance invoices, or receipts. By default the `id` is shown if `name` is not set.
 	Name *string `form:"name"`
 	// A positive float larger than 0, and smaller or equal to 100, that represents the discount the coupon will apply (required if `amount_off` is not passed).
@@ -57,7 +57,7 @@ func (p *CouponParams) AddExpand(f string) {
 // AddMetadata adds a new key-value pair to the Metadata.
 func (p *CouponParams) AddMetadata(key string, value string) {
 	if p.Metadata == nil {
-		p.Metadata = make(map[string]string)
+		p.Metadata = make(Metadata)
 	}
 
 	p.Metadata[key] = value
@@ -127,7 +127,7 @@ type Coupon struct {
 	// Maximum number of times this coupon can be redeemed, in total, across all customers, before it is no longer valid.
 	MaxRedemptions int64 `json:"max_redemptions"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format.
-	Metadata map[string]string `json:"metadata"`
+	Metadata Metadata `json:"metadata"`
 	// Name of the coupon displayed to customers on for instance invoices or receipts.
 	Name string `json:"name"`
 	// String representing the object's type. Objects of the same type share the same value.
@@ -139,7 +139,7 @@ type CreditNoteLineParams struct {
 	// The integer unit amount in cents (or local equivalent) of the credit note line item. This `unit_amount` will be multiplied by the quantity to get the full amount to credit for this line item. Only valid when `type` is `custom_line_item`.
 	UnitAmount *int64 `form:"unit_amount"`
 	// Same as `unit_amount`, but accepts a decimal value in cents (or local equivalent) with at most 12 decimal places. Only one of `unit_amount` and `unit_amount_decimal` can be set.
-	UnitAmountDecimal *float64 `form:"unit_amount_decimal,high_precision"`
+	UnitAmountDecimal *Decimal `form:"unit_amount_decimal"`
 }
 
 // When shipping_cost contains the shipping_rate from the invoice, the shipping_cost is included in the credit note.
@@ -179,7 +179,7 @@ type CreditNoteParams struct {
 	// The credit note's memo appears on the credit note PDF.
 	Memo *string `form:"memo"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format. Individual keys can be unset by posting an empty value to them. All keys can be unset by posting an empty value to `metadata`.
-	Metadata map[string]string `form:"metadata"`
+	Metadata Metadata `form:"metadata"`
 	// The integer amount in cents (or local equivalent) representing the amount that is credited outside of Stripe.
 	OutOfBandAmount *int64 `form:"out_of_band_amount"`
 	// Reason for issuing this credit note, one of `duplicate`, `fraudulent`, `order_change`, or `product_unsatisfactory`
@@ -200,7 +200,7 @@ func (p *CreditNoteParams) AddExpand(f string) {
 // AddMetadata adds a new key-value pair to the Metadata.
 func (p *CreditNoteParams) AddMetadata(key string, value string) {
 	if p.Metadata == nil {
-		p.Metadata = make(map[string]string)
+		p.Metadata = make(Metadata)
 	}
 
 	p.Metadata[key] = value
@@ -235,7 +235,7 @@ type CreditNotePreviewLineParams struct {
 	// The integer unit amount in cents (or local equivalent) of the credit note line item. This `unit_amount` will be multiplied by the quantity to get the full amount to credit for this line item. Only valid when `type` is `custom_line_item`.
 	UnitAmount *int64 `form:"unit_amount"`
 	// Same as `unit_amount`, but accepts a decimal value in cents (or local equivalent) with at most 12 decimal places. Only one of `unit_amount` and `unit_amount_decimal` can be set.
-	UnitAmountDecimal *float64 `form:"unit_amount_decimal,high_precision"`
+	UnitAmountDecimal *Decimal `form:"unit_amount_decimal"`
 }
 
 // When shipping_cost contains the shipping_rate from the invoice, the shipping_cost is included in the credit note.
@@ -264,7 +264,7 @@ type CreditNotePreviewParams struct {
 	// The credit note's memo appears on the credit note PDF.
 	Memo *string `form:"memo"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format. Individual keys can be unset by posting an empty value to them. All keys can be unset by posting an empty value to `metadata`.
-	Metadata map[string]string `form:"metadata"`
+	Metadata Metadata `form:"metadata"`
 	// The integer amount in cents (or local equivalent) representing the amount that is credited outside of Stripe.
 	OutOfBandAmount *int64 `form:"out_of_band_amount"`
 	// Reason for issuing this credit note, one of `duplicate`, `fraudulent`, `order_change`, or `product_unsatisfactory`
@@ -285,7 +285,7 @@ func (p *CreditNotePreviewParams) AddExpand(f string) {
 // AddMetadata adds a new key-value pair to the Metadata.
 func (p *CreditNotePreviewParams) AddMetadata(key string, value string) {
 	if p.Metadata == nil {
-		p.Metadata = make(map[string]string)
+		p.Metadata = make(Metadata)
 	}
 
 	p.Metadata[key] = value
@@ -320,7 +320,7 @@ type CreditNotePreviewLinesLineParams struct {
 	// The integer unit amount in cents (or local equivalent) of the credit note line item. This `unit_amount` will be multiplied by the quantity to get the full amount to credit for this line item. Only valid when `type` is `custom_line_item`.
 	UnitAmount *int64 `form:"unit_amount"`
 	// Same as `unit_amount`, but accepts a decimal value in cents (or local equivalent) with at most 12 decimal places. Only one of `unit_amount` and `unit_amount_decimal` can be set.
-	UnitAmountDecimal *float64 `form:"unit_amount_decimal,high_precision"`
+	UnitAmountDecimal *Decimal `form:"unit_amount_decimal"`
 }
 
 // When shipping_cost contains the shipping_rate from the invoice, the shipping_cost is included in the credit note.
@@ -349,7 +349,7 @@ type CreditNotePreviewLinesParams struct {
 	// The credit note's memo appears on the credit note PDF.
 	Memo *string `form:"memo"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format. Individual keys can be unset by posting an empty value to them. All keys can be unset by posting an empty value to `metadata`.
-	Metadata map[string]string `form:"metadata"`
+	Metadata Metadata `form:"metadata"`
 	// The integer amount in cents (or local equivalent) representing the amount that is credited outside of Stripe.
 	OutOfBandAmount *int64 `form:"out_of_band_amount"`
 	// Reason for issuing this credit note, one of `duplicate`, `fraudulent`, `order_change`, or `product_unsatisfactory`
@@ -370,7 +370,7 @@ func (p *CreditNotePreviewLinesParams) AddExpand(f string) {
 // AddMetadata adds a new key-value pair to the Metadata.
 func (p *CreditNotePreviewLinesParams) AddMetadata(key string, value string) {
 	if p.Metadata == nil {
-		p.Metadata = make(map[string]string)
+		p.Metadata = make(Metadata)
 	}
 
 	p.Metadata[key] = value
@@ -497,7 +497,7 @@ type CreditNote struct {
 	// Customer-facing text that appears on the credit note PDF.
 	Memo string `json:"memo"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format.
-	Metadata map[string]string `json:"metadata"`
+	Metadata Metadata `json:"metadata"`
 	// A unique number that identifies this particular credit note and appears on the PDF of the credit note and its associated invoice.
 	Number string `json:"number"`
 	// String representing the object's type. Objects of the same type share the same value.
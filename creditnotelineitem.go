@@ -79,7 +79,7 @@ type CreditNoteLineItem struct {
 	// The cost of each unit of product being credited.
 	UnitAmount int64 `json:"unit_amount"`
 	// Same as `unit_amount`, but contains a decimal value with at most 12 decimal places.
-	UnitAmountDecimal float64 `json:"unit_amount_decimal,string"`
+	UnitAmountDecimal Decimal `json:"unit_amount_decimal"`
 	// The amount in cents (or local equivalent) representing the unit amount being credited for this line item, excluding all tax and discounts.
 	UnitAmountExcludingTax float64 `json:"unit_amount_excluding_tax,string"`
 }
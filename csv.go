@@ -0,0 +1,293 @@
+package stripe
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+//
+// Public types
+//
+
+// CSVDecoder decodes the rows of CSV files generated by Stripe, like the
+// results of report runs and Sigma queries, into structs or maps. The first
+// row of the files is the header naming their columns.
+//
+// Struct fields are matched to columns by their `csv` tag, like
+// `csv:"balance_transaction_id"`, including those of embedded structs.
+// Columns without fields are ignored, and fields without columns are left
+// untouched. Fields can be strings, booleans, integers, floats, Decimals and
+// time.Times (see Location), or pointers to those, which are set to nil for
+// empty values. Other fields are left as their zero value for empty values.
+type CSVDecoder struct {
+	// Location is the time zone of the timestamps without one, like those of
+	// report runs with a Timezone parameter. Unix timestamps are supported
+	// too.
+	//
+	// Defaults to UTC.
+	Location *time.Location
+
+	fields map[reflect.Type][]csvField
+	header []string
+	r      *csv.Reader
+	row    int
+}
+
+// Decode decodes the next row into v, which must be a pointer to a struct or
+// to a map[string]string. It returns io.EOF when there are no more rows.
+func (d *CSVDecoder) Decode(v interface{}) error {
+	header, err := d.Header()
+	if err != nil {
+		return err
+	}
+
+	record, err := d.r.Read()
+	if err != nil {
+		return err
+	}
+	d.row++
+
+	switch v := v.(type) {
+	case *map[string]string:
+		if *v == nil {
+			*v = make(map[string]string, len(header))
+		}
+		for i, column := range header {
+			(*v)[column] = record[i]
+		}
+		return nil
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("stripe: cannot decode CSV row into %T", v)
+	}
+	return d.decodeStruct(rv.Elem(), record)
+}
+
+// DecodeAll decodes the remaining rows into v, which must be a pointer to a
+// slice of structs, of pointers to structs, or of map[string]string.
+func (d *CSVDecoder) DecodeAll(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("stripe: cannot decode CSV rows into %T", v)
+	}
+
+	slice := rv.Elem()
+	elemType := slice.Type().Elem()
+	for {
+		var elem reflect.Value
+		if elemType.Kind() == reflect.Ptr {
+			elem = reflect.New(elemType.Elem())
+		} else {
+			elem = reflect.New(elemType)
+		}
+
+		err := d.Decode(elem.Interface())
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if elemType.Kind() != reflect.Ptr {
+			elem = elem.Elem()
+		}
+		slice.Set(reflect.Append(slice, elem))
+	}
+}
+
+// Header returns the names of the columns, read from the first row.
+func (d *CSVDecoder) Header() ([]string, error) {
+	if d.header != nil {
+		return d.header, nil
+	}
+
+	header, err := d.r.Read()
+	if err == io.EOF {
+		return nil, errors.New("stripe: CSV file doesn't have a header")
+	}
+	if err != nil {
+		return nil, err
+	}
+	d.header = header
+	return header, nil
+}
+
+//
+// Public functions
+//
+
+// NewCSVDecoder returns a decoder reading a CSV file from r.
+func NewCSVDecoder(r io.Reader) *CSVDecoder {
+	return &CSVDecoder{
+		fields: make(map[reflect.Type][]csvField),
+		r:      csv.NewReader(r),
+	}
+}
+
+//
+// Private types
+//
+
+// csvField is a struct field decoded from a column.
+type csvField struct {
+	column int
+	index  []int
+}
+
+//
+// Private variables
+//
+
+var decimalType = reflect.TypeOf(Decimal{})
+var timeType = reflect.TypeOf(time.Time{})
+
+// csvTimeLayouts are the layouts of the timestamps of CSV files, tried in
+// order. Fractional seconds are accepted after the seconds of each.
+var csvTimeLayouts = []string{
+	"2006-01-02 15:04:05",
+	time.RFC3339,
+	"2006-01-02",
+}
+
+//
+// Private functions
+//
+
+// decodeStruct decodes a record into the fields of a struct.
+func (d *CSVDecoder) decodeStruct(v reflect.Value, record []string) error {
+	fields, ok := d.fields[v.Type()]
+	if !ok {
+		fields = csvFields(v.Type(), nil, d.header)
+		d.fields[v.Type()] = fields
+	}
+
+	for _, field := range fields {
+		value := record[field.column]
+		if err := d.decodeValue(v.FieldByIndex(field.index), value); err != nil {
+			return fmt.Errorf("stripe: cannot decode column %q of CSV row %d: %v",
+				d.header[field.column], d.row, err)
+		}
+	}
+	return nil
+}
+
+// decodeValue decodes the value of a column into a field.
+func (d *CSVDecoder) decodeValue(v reflect.Value, value string) error {
+	if v.Kind() == reflect.Ptr {
+		if value == "" {
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+		v.Set(reflect.New(v.Type().Elem()))
+		v = v.Elem()
+	}
+	if value == "" {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+
+	switch v.Type() {
+	case decimalType:
+		decimal, err := ParseDecimal(value)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(decimal))
+		return nil
+
+	case timeType:
+		t, err := d.parseTime(value)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(value, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(value, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported field type %v", v.Type())
+	}
+	return nil
+}
+
+// parseTime parses the timestamp of a column.
+func (d *CSVDecoder) parseTime(value string) (time.Time, error) {
+	if unix, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(unix, 0).UTC(), nil
+	}
+
+	location := d.Location
+	if location == nil {
+		location = time.UTC
+	}
+	for _, layout := range csvTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, location); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid timestamp %q", value)
+}
+
+// csvFields returns the fields of a struct type matching columns of a
+// header, looking into embedded structs.
+func csvFields(t reflect.Type, index []int, header []string) []csvField {
+	var fields []csvField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fieldIndex := append(append([]int(nil), index...), i)
+
+		tag, ok := field.Tag.Lookup("csv")
+		if !ok {
+			if field.Anonymous && field.Type.Kind() == reflect.Struct {
+				fields = append(fields, csvFields(field.Type, fieldIndex, header)...)
+			}
+			continue
+		}
+		if tag == "-" || field.PkgPath != "" {
+			continue
+		}
+
+		for column, name := range header {
+			if name == tag {
+				fields = append(fields, csvField{column: column, index: fieldIndex})
+				break
+			}
+		}
+	}
+	return fields
+}
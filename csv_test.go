@@ -0,0 +1,84 @@
+package stripe
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+type testCSVBase struct {
+	ID string `csv:"id"`
+}
+
+type testCSVRow struct {
+	testCSVBase
+
+	Amount   Decimal    `csv:"amount"`
+	Count    int64      `csv:"count"`
+	Created  time.Time  `csv:"created"`
+	Currency Currency   `csv:"currency"`
+	Ignored  string     `csv:"-"`
+	Paid     bool       `csv:"paid"`
+	Refunded *time.Time `csv:"refunded"`
+	Untagged string
+}
+
+func TestCSVDecoder_Decode(t *testing.T) {
+	d := NewCSVDecoder(strings.NewReader("id,amount,count,created,currency,paid,refunded,other\n" +
+		"ch_1,12.34,2,2024-01-02 03:04:05,usd,true,,x\n" +
+		"ch_2,-0.5,0,1704164645,eur,false,2024-01-03,y\n"))
+
+	header, err := d.Header()
+	assert.NoError(t, err)
+	assert.Equal(t, 8, len(header))
+
+	var row testCSVRow
+	assert.NoError(t, d.Decode(&row))
+	assert.Equal(t, "ch_1", row.ID)
+	assert.True(t, MustParseDecimal("12.34").Equal(row.Amount))
+	assert.Equal(t, int64(2), row.Count)
+	assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), row.Created)
+	assert.Equal(t, CurrencyUSD, row.Currency)
+	assert.True(t, row.Paid)
+	assert.Nil(t, row.Refunded)
+
+	var values map[string]string
+	assert.NoError(t, d.Decode(&values))
+	assert.Equal(t, "ch_2", values["id"])
+	assert.Equal(t, "y", values["other"])
+
+	assert.Equal(t, io.EOF, d.Decode(&row))
+}
+
+func TestCSVDecoder_DecodeAll(t *testing.T) {
+	d := NewCSVDecoder(strings.NewReader("id,created,refunded\n" +
+		"ch_1,2024-01-02 03:04:05.123,\n" +
+		"ch_2,2024-01-02T03:04:05Z,2024-01-03 00:00:00\n"))
+	d.Location = time.FixedZone("UTC+1", 3600)
+
+	var rows []*testCSVRow
+	assert.NoError(t, d.DecodeAll(&rows))
+	assert.Equal(t, 2, len(rows))
+	assert.Equal(t, "ch_1", rows[0].ID)
+	assert.Equal(t, time.Date(2024, 1, 2, 2, 4, 5, 123000000, time.UTC), rows[0].Created.UTC())
+	assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), rows[1].Created.UTC())
+	assert.Equal(t, time.Date(2024, 1, 2, 23, 0, 0, 0, time.UTC), rows[1].Refunded.UTC())
+}
+
+func TestCSVDecoder_Errors(t *testing.T) {
+	d := NewCSVDecoder(strings.NewReader(""))
+	_, err := d.Header()
+	assert.Error(t, err)
+
+	d = NewCSVDecoder(strings.NewReader("id,count\nch_1,many\n"))
+	var row testCSVRow
+	err = d.Decode(&row)
+	assert.EqualError(t, err, `stripe: cannot decode column "count" of CSV row 1: strconv.ParseInt: parsing "many": invalid syntax`)
+
+	d = NewCSVDecoder(strings.NewReader("id\nch_1\n"))
+	assert.Error(t, d.Decode(row))
+	assert.Error(t, d.DecodeAll(&row))
+}
@@ -0,0 +1,323 @@
+package stripe
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/stripe/stripe-go/v81/form"
+)
+
+//
+// Public constants
+//
+
+// MaxDecimalExponent is the largest exponent accepted in the scientific
+// notation of decimals, like the 7 of `1e-7`, so that parsing untrusted input
+// can't allocate huge numbers.
+const MaxDecimalExponent = 1000
+
+//
+// Public types
+//
+
+// Decimal is an exact decimal number, used for the `*_decimal` fields of the
+// API, like the unit amount of a price in fractions of a cent. Unlike
+// float64, it represents values like 0.1 exactly, and is encoded as is in
+// requests and decoded as is from responses.
+//
+// The zero value is 0. Decimals are immutable, and arithmetic on them is
+// exact, apart from Round.
+type Decimal struct {
+	// unscaled is the decimal without its decimal point, or nil for 0.
+	unscaled *big.Int
+
+	// scale is the number of digits of unscaled after the decimal point.
+	// Decimals are kept normalized, without trailing zeros after the decimal
+	// point, so that equal decimals have the same representation.
+	scale int32
+}
+
+// Add returns the sum of d and o.
+func (d Decimal) Add(o Decimal) Decimal {
+	a, b, scale := alignDecimals(d, o)
+	return newDecimal(a.Add(a, b), scale)
+}
+
+// AppendTo implements form.Appender so that decimals are encoded exactly in
+// requests.
+func (d Decimal) AppendTo(values *form.Values, keyParts []string) {
+	values.Add(form.FormatKey(keyParts), d.String())
+}
+
+// Cmp compares d and o, and returns -1 if d < o, 0 if d == o, and 1 if
+// d > o.
+func (d Decimal) Cmp(o Decimal) int {
+	a, b, _ := alignDecimals(d, o)
+	return a.Cmp(b)
+}
+
+// Equal returns whether d and o are equal.
+func (d Decimal) Equal(o Decimal) bool {
+	return d.Cmp(o) == 0
+}
+
+// Float64 returns the float64 nearest to the decimal, which may not be exact.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// Int64 returns the decimal as an int64. It returns an error if the decimal
+// isn't an integer, which can be taken care of with Round first, or if it
+// doesn't fit in an int64.
+func (d Decimal) Int64() (int64, error) {
+	if d.scale != 0 {
+		return 0, fmt.Errorf("stripe: decimal %s isn't an integer", d)
+	}
+	v := d.bigInt()
+	if !v.IsInt64() {
+		return 0, fmt.Errorf("stripe: decimal %s overflows int64", d)
+	}
+	return v.Int64(), nil
+}
+
+// IsZero returns whether the decimal is 0.
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// MarshalJSON encodes the decimal as a string, like the API does.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// Mul returns the product of d and o.
+func (d Decimal) Mul(o Decimal) Decimal {
+	v := new(big.Int).Mul(d.bigInt(), o.bigInt())
+	return newDecimal(v, d.scale+o.scale)
+}
+
+// Neg returns the opposite of d.
+func (d Decimal) Neg() Decimal {
+	return newDecimal(new(big.Int).Neg(d.bigInt()), d.scale)
+}
+
+// Param returns a pointer to the decimal, to be used in parameters like
+// PriceParams.UnitAmountDecimal.
+func (d Decimal) Param() *Decimal {
+	return &d
+}
+
+// Round returns the decimal rounded to the given number of decimal places,
+// with halves rounded away from zero. For example, 1.005 is rounded to 1.01
+// with 2 places, and a unit amount in fractions of a cent multiplied by a
+// quantity can be rounded to a whole amount with 0 places.
+func (d Decimal) Round(places int32) Decimal {
+	if places < 0 {
+		places = 0
+	}
+	if d.scale <= places {
+		return d
+	}
+
+	divisor := pow10(d.scale - places)
+	quotient, remainder := new(big.Int).QuoRem(d.bigInt(), divisor, new(big.Int))
+
+	// Round away from zero when the remainder is at least half of the
+	// divisor.
+	remainder.Abs(remainder)
+	if remainder.Lsh(remainder, 1).Cmp(divisor) >= 0 {
+		if d.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+	return newDecimal(quotient, places)
+}
+
+// Sign returns -1 if the decimal is negative, 0 if it's 0, and 1 if it's
+// positive.
+func (d Decimal) Sign() int {
+	if d.unscaled == nil {
+		return 0
+	}
+	return d.unscaled.Sign()
+}
+
+// String formats the decimal in plain notation, like "0.0125", without
+// trailing zeros.
+func (d Decimal) String() string {
+	v := d.bigInt()
+	digits := new(big.Int).Abs(v).String()
+	if d.scale > 0 {
+		scale := int(d.scale)
+		if len(digits) <= scale {
+			digits = strings.Repeat("0", scale-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+	}
+	if v.Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+// Sub returns the difference of d and o.
+func (d Decimal) Sub(o Decimal) Decimal {
+	a, b, scale := alignDecimals(d, o)
+	return newDecimal(a.Sub(a, b), scale)
+}
+
+// UnmarshalJSON decodes the decimal from either a string, like the API
+// returns, or a number. Null leaves the decimal unchanged.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	s := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	}
+	v, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+//
+// Public functions
+//
+
+// DecimalFromFloat64 returns the decimal with the shortest representation
+// which rounds to f, like 0.1 for 0.1, easing the move from float64 fields.
+// It panics if f is infinite or NaN.
+func DecimalFromFloat64(f float64) Decimal {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		panic(fmt.Sprintf("stripe: %v can't be represented as a decimal", f))
+	}
+	return MustParseDecimal(strconv.FormatFloat(f, 'f', -1, 64))
+}
+
+// MustParseDecimal is like ParseDecimal but panics if the decimal can't be
+// parsed. It's meant for decimals known in advance.
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// NewDecimal returns the decimal unscaled / 10^scale, like 125 with a scale of
+// 4 for 0.0125.
+func NewDecimal(unscaled int64, scale int32) Decimal {
+	if scale < 0 {
+		return newDecimal(new(big.Int).Mul(big.NewInt(unscaled), pow10(-scale)), 0)
+	}
+	return newDecimal(big.NewInt(unscaled), scale)
+}
+
+// ParseDecimal parses a decimal in plain notation, like "-0.0125", or in
+// scientific notation, like "1.25e-2".
+func ParseDecimal(s string) (Decimal, error) {
+	invalid := fmt.Errorf("stripe: invalid decimal %q", s)
+
+	mantissa, exponent := s, int64(0)
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		var err error
+		mantissa = s[:i]
+		exponent, err = strconv.ParseInt(s[i+1:], 10, 32)
+		if err != nil || exponent > MaxDecimalExponent || exponent < -MaxDecimalExponent {
+			return Decimal{}, invalid
+		}
+	}
+
+	sign := ""
+	if strings.HasPrefix(mantissa, "-") || strings.HasPrefix(mantissa, "+") {
+		sign, mantissa = mantissa[:1], mantissa[1:]
+	}
+	whole, fraction := mantissa, ""
+	if i := strings.IndexByte(mantissa, '.'); i >= 0 {
+		whole, fraction = mantissa[:i], mantissa[i+1:]
+	}
+	if whole+fraction == "" || !isDigits(whole) || !isDigits(fraction) {
+		return Decimal{}, invalid
+	}
+
+	v, ok := new(big.Int).SetString(sign+whole+fraction, 10)
+	if !ok {
+		return Decimal{}, invalid
+	}
+	scale := int64(len(fraction)) - exponent
+	if scale < 0 {
+		return newDecimal(v.Mul(v, pow10(int32(-scale))), 0), nil
+	}
+	if scale > math.MaxInt32 {
+		return Decimal{}, invalid
+	}
+	return newDecimal(v, int32(scale)), nil
+}
+
+//
+// Private functions
+//
+
+// alignDecimals returns the unscaled values of two decimals at the same scale,
+// as new integers which can be modified.
+func alignDecimals(d, o Decimal) (*big.Int, *big.Int, int32) {
+	a, b := new(big.Int).Set(d.bigInt()), new(big.Int).Set(o.bigInt())
+	switch {
+	case d.scale < o.scale:
+		a.Mul(a, pow10(o.scale-d.scale))
+		return a, b, o.scale
+	case d.scale > o.scale:
+		b.Mul(b, pow10(d.scale-o.scale))
+	}
+	return a, b, d.scale
+}
+
+// bigInt returns the unscaled value of the decimal, which mustn't be
+// modified.
+func (d Decimal) bigInt() *big.Int {
+	if d.unscaled == nil {
+		return new(big.Int)
+	}
+	return d.unscaled
+}
+
+// newDecimal returns the normalized decimal unscaled / 10^scale, taking
+// ownership of unscaled.
+func newDecimal(unscaled *big.Int, scale int32) Decimal {
+	if unscaled.Sign() == 0 {
+		return Decimal{}
+	}
+
+	ten := big.NewInt(10)
+	quotient, remainder := new(big.Int), new(big.Int)
+	for scale > 0 {
+		quotient.QuoRem(unscaled, ten, remainder)
+		if remainder.Sign() != 0 {
+			break
+		}
+		unscaled, quotient = quotient, unscaled
+		scale--
+	}
+	return Decimal{unscaled: unscaled, scale: scale}
+}
+
+// pow10 returns 10^n.
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
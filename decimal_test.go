@@ -0,0 +1,128 @@
+package stripe
+
+import (
+	"encoding/json"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+	"github.com/stripe/stripe-go/v81/form"
+)
+
+func TestDecimal_Arithmetic(t *testing.T) {
+	a := MustParseDecimal("0.1")
+	b := MustParseDecimal("0.2")
+
+	assert.Equal(t, "0.3", a.Add(b).String())
+	assert.True(t, a.Add(b).Equal(MustParseDecimal("0.30")))
+	assert.Equal(t, "-0.1", a.Sub(b).String())
+	assert.Equal(t, "0.02", a.Mul(b).String())
+	assert.Equal(t, "-0.1", a.Neg().String())
+	assert.Equal(t, -1, a.Cmp(b))
+	assert.Equal(t, 1, b.Cmp(a))
+	assert.True(t, a.Sub(a).IsZero())
+	assert.Equal(t, Decimal{}, a.Sub(a))
+
+	// A sub-cent unit amount times a quantity, rounded to a whole amount.
+	total := MustParseDecimal("0.0125").Mul(NewDecimal(1234, 0))
+	assert.Equal(t, "15.425", total.String())
+	amount, err := total.Round(0).Int64()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(15), amount)
+}
+
+func TestDecimal_Int64(t *testing.T) {
+	v, err := NewDecimal(-42, 0).Int64()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-42), v)
+
+	_, err = MustParseDecimal("1.5").Int64()
+	assert.Error(t, err)
+
+	_, err = MustParseDecimal("1e30").Int64()
+	assert.Error(t, err)
+}
+
+func TestDecimal_Round(t *testing.T) {
+	testCases := []struct {
+		in     string
+		places int32
+		want   string
+	}{
+		{"1.005", 2, "1.01"},
+		{"1.004", 2, "1"},
+		{"-1.005", 2, "-1.01"},
+		{"2.5", 0, "3"},
+		{"-2.5", 0, "-3"},
+		{"0.4", 0, "0"},
+		{"1.25", 5, "1.25"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.in, func(t *testing.T) {
+			assert.Equal(t, tc.want, MustParseDecimal(tc.in).Round(tc.places).String())
+		})
+	}
+}
+
+func TestDecimal_JSON(t *testing.T) {
+	var price Price
+	err := json.Unmarshal([]byte(`{"unit_amount_decimal": "0.0000125"}`), &price)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.0000125", price.UnitAmountDecimal.String())
+
+	data, err := json.Marshal(price.UnitAmountDecimal)
+	assert.NoError(t, err)
+	assert.Equal(t, `"0.0000125"`, string(data))
+
+	var d Decimal
+	assert.NoError(t, json.Unmarshal([]byte(`12.50`), &d))
+	assert.Equal(t, "12.5", d.String())
+	assert.NoError(t, json.Unmarshal([]byte(`null`), &d))
+	assert.Equal(t, "12.5", d.String())
+	assert.Error(t, json.Unmarshal([]byte(`"abc"`), &d))
+}
+
+func TestDecimal_AppendTo(t *testing.T) {
+	params := &PriceParams{
+		UnitAmountDecimal: MustParseDecimal("0.0000125").Param(),
+	}
+	body := &form.Values{}
+	form.AppendTo(body, params)
+	assert.Equal(t, []string{"0.0000125"}, body.Get("unit_amount_decimal"))
+}
+
+func TestParseDecimal(t *testing.T) {
+	testCases := []struct {
+		in   string
+		want string
+	}{
+		{"0", "0"},
+		{"-0.00", "0"},
+		{"+1.50", "1.5"},
+		{"100", "100"},
+		{".5", "0.5"},
+		{"5.", "5"},
+		{"1.25e-2", "0.0125"},
+		{"1.25E3", "1250"},
+		{"0.0123456789012345678901234567890", "0.012345678901234567890123456789"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.in, func(t *testing.T) {
+			d, err := ParseDecimal(tc.in)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, d.String())
+		})
+	}
+
+	for _, in := range []string{"", ".", "-", "1.2.3", "abc", "1e", "1e1001", "0x10", "1 "} {
+		t.Run(in, func(t *testing.T) {
+			_, err := ParseDecimal(in)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestDecimalFromFloat64(t *testing.T) {
+	assert.Equal(t, "0.1", DecimalFromFloat64(0.1).String())
+	assert.Equal(t, "-3.5", DecimalFromFloat64(-3.5).String())
+	assert.Equal(t, 0.0125, MustParseDecimal("0.0125").Float64())
+}
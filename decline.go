@@ -0,0 +1,406 @@
+package stripe
+
+import (
+	"net/http"
+	"strings"
+)
+
+//
+// Public constants
+//
+
+// List of values that DeclineAction can take.
+const (
+	// DeclineActionAuthenticate is for payments which can be retried once
+	// the customer authenticates, with 3D Secure or by entering their PIN.
+	DeclineActionAuthenticate DeclineAction = "authenticate"
+
+	// DeclineActionCorrectDetails is for payments which can be retried once
+	// the customer corrects the details of their card.
+	DeclineActionCorrectDetails DeclineAction = "correct_details"
+
+	// DeclineActionDoNotRetry is for payments which mustn't be retried with
+	// the same payment method, because it's likely fraudulent or because
+	// the customer revoked it. Card networks may fine repeated attempts.
+	DeclineActionDoNotRetry DeclineAction = "do_not_retry"
+
+	// DeclineActionRetryLater is for payments which may succeed if retried
+	// later with the same payment method, like when the issuer was
+	// unavailable or funds were insufficient.
+	DeclineActionRetryLater DeclineAction = "retry_later"
+
+	// DeclineActionUpdatePaymentMethod is for payments which need a different
+	// payment method, or the customer to contact their issuer.
+	DeclineActionUpdatePaymentMethod DeclineAction = "update_payment_method"
+)
+
+// List of values that DeclineCategory can take.
+const (
+	// DeclineCategoryAuthenticationRequired is for declines which require
+	// the customer to authenticate.
+	DeclineCategoryAuthenticationRequired DeclineCategory = "authentication_required"
+
+	// DeclineCategoryCardUnusable is for cards which can't be used for the
+	// payment, like expired cards or cards which don't support the currency.
+	DeclineCategoryCardUnusable DeclineCategory = "card_unusable"
+
+	// DeclineCategoryFraud is for declines because of suspected fraud, like
+	// lost or stolen cards.
+	DeclineCategoryFraud DeclineCategory = "fraud"
+
+	// DeclineCategoryGeneric is for declines whose reason isn't given by the
+	// issuer.
+	DeclineCategoryGeneric DeclineCategory = "generic"
+
+	// DeclineCategoryIncorrectDetails is for card details entered
+	// incorrectly, like the number, the CVC or the postal code.
+	DeclineCategoryIncorrectDetails DeclineCategory = "incorrect_details"
+
+	// DeclineCategoryInsufficientFunds is for cards without enough funds or
+	// credit left.
+	DeclineCategoryInsufficientFunds DeclineCategory = "insufficient_funds"
+
+	// DeclineCategoryTemporary is for temporary failures, like the issuer
+	// being unavailable.
+	DeclineCategoryTemporary DeclineCategory = "temporary"
+
+	// DeclineCategoryUnknown is for errors which aren't declines, or whose
+	// decline code isn't known by this version of the library.
+	DeclineCategoryUnknown DeclineCategory = "unknown"
+)
+
+//
+// Public types
+//
+
+// DeclineAction is the recommended way to handle a decline.
+type DeclineAction string
+
+// DeclineCategory groups declines by what caused them.
+type DeclineCategory string
+
+// Category returns the category of the decline code.
+func (c DeclineCode) Category() DeclineCategory {
+	return declineClassificationOf(c).category
+}
+
+// CustomerFacingMessage returns a message explaining the decline to the
+// customer in the given locale, like "fr" or "pt-BR", falling back on English
+// for locales without messages. See Error.CustomerFacingMessage.
+func (c DeclineCode) CustomerFacingMessage(locale string) string {
+	return declineMessage(c.Category(), locale)
+}
+
+// IsFraudRelated returns whether the decline code is because of suspected
+// fraud. Payments declined for fraud mustn't be retried, and their reason
+// shouldn't be disclosed to the customer.
+func (c DeclineCode) IsFraudRelated() bool {
+	return c.Category() == DeclineCategoryFraud
+}
+
+// IsRetryable returns whether a payment declined with the code may succeed
+// when retried with the same payment method as is, either later or once the
+// customer has authenticated.
+func (c DeclineCode) IsRetryable() bool {
+	return declineClassificationOf(c).isRetryable()
+}
+
+// RecommendedAction returns the recommended way to handle a payment declined
+// with the code.
+func (c DeclineCode) RecommendedAction() DeclineAction {
+	return declineClassificationOf(c).action
+}
+
+// Category returns the category of the decline of a card error, from its
+// decline code, or from its code for errors without a decline code. Errors
+// which aren't declines are of category DeclineCategoryUnknown.
+func (e *Error) Category() DeclineCategory {
+	return e.declineClassification().category
+}
+
+// CustomerFacingMessage returns a message explaining the error to the
+// customer in the given locale, like "fr" or "pt-BR", falling back on
+// English. Unlike Msg, it's the same for all the declines of a category, and
+// doesn't disclose declines because of suspected fraud, which get the
+// message of generic declines.
+func (e *Error) CustomerFacingMessage(locale string) string {
+	return declineMessage(e.Category(), locale)
+}
+
+// IsFraudRelated returns whether the error is a decline because of suspected
+// fraud. See DeclineCode.IsFraudRelated.
+func (e *Error) IsFraudRelated() bool {
+	return e.Category() == DeclineCategoryFraud
+}
+
+// IsRetryable returns whether the payment which failed with the error may
+// succeed when retried with the same payment method. See
+// DeclineCode.IsRetryable. Errors which aren't declines are retryable when
+// they're temporary, like rate limits, lock timeouts and server errors.
+func (e *Error) IsRetryable() bool {
+	return e.declineClassification().isRetryable()
+}
+
+// RecommendedAction returns the recommended way to handle the decline of a
+// card error. Errors which aren't declines are to be retried later with
+// DeclineActionRetryLater when they're temporary, like rate limits, lock
+// timeouts and server errors, and otherwise don't have a recommended action.
+func (e *Error) RecommendedAction() DeclineAction {
+	return e.declineClassification().action
+}
+
+// Category returns the category of the decline. See Error.Category.
+func (e *CardError) Category() DeclineCategory {
+	return e.stripeErr.Category()
+}
+
+// CustomerFacingMessage returns a message explaining the decline to the
+// customer. See Error.CustomerFacingMessage.
+func (e *CardError) CustomerFacingMessage(locale string) string {
+	return e.stripeErr.CustomerFacingMessage(locale)
+}
+
+// IsFraudRelated returns whether the decline is because of suspected fraud.
+// See Error.IsFraudRelated.
+func (e *CardError) IsFraudRelated() bool {
+	return e.stripeErr.IsFraudRelated()
+}
+
+// IsRetryable returns whether the payment may succeed when retried with the
+// same payment method. See Error.IsRetryable.
+func (e *CardError) IsRetryable() bool {
+	return e.stripeErr.IsRetryable()
+}
+
+// RecommendedAction returns the recommended way to handle the decline. See
+// Error.RecommendedAction.
+func (e *CardError) RecommendedAction() DeclineAction {
+	return e.stripeErr.RecommendedAction()
+}
+
+// IsRetryable returns true, since requests which couldn't get a response
+// from Stripe may succeed when retried. See Error.IsRetryable.
+func (e *ConnectionError) IsRetryable() bool {
+	return true
+}
+
+// RecommendedAction returns DeclineActionRetryLater, since requests which
+// couldn't get a response from Stripe may succeed later. See
+// Error.RecommendedAction.
+func (e *ConnectionError) RecommendedAction() DeclineAction {
+	return DeclineActionRetryLater
+}
+
+//
+// Private types
+//
+
+type declineClassification struct {
+	action   DeclineAction
+	category DeclineCategory
+}
+
+func (c declineClassification) isRetryable() bool {
+	return c.action == DeclineActionAuthenticate || c.action == DeclineActionRetryLater
+}
+
+//
+// Private variables
+//
+
+// declineClassifications classifies every decline code, following the next
+// steps documented at https://stripe.com/docs/declines/codes. Some error
+// codes which are declines themselves, or which share their name with a
+// decline code, are classified too.
+var declineClassifications = map[DeclineCode]declineClassification{
+	DeclineCodeApproveWithID:                  {DeclineActionRetryLater, DeclineCategoryTemporary},
+	DeclineCodeAuthenticationRequired:         {DeclineActionAuthenticate, DeclineCategoryAuthenticationRequired},
+	DeclineCodeCallIssuer:                     {DeclineActionUpdatePaymentMethod, DeclineCategoryGeneric},
+	DeclineCodeCardNotSupported:               {DeclineActionUpdatePaymentMethod, DeclineCategoryCardUnusable},
+	DeclineCodeCardVelocityExceeded:           {DeclineActionRetryLater, DeclineCategoryInsufficientFunds},
+	DeclineCodeCurrencyNotSupported:           {DeclineActionUpdatePaymentMethod, DeclineCategoryCardUnusable},
+	DeclineCodeDoNotHonor:                     {DeclineActionUpdatePaymentMethod, DeclineCategoryGeneric},
+	DeclineCodeDoNotTryAgain:                  {DeclineActionDoNotRetry, DeclineCategoryGeneric},
+	DeclineCodeDuplicateTransaction:           {DeclineActionDoNotRetry, DeclineCategoryGeneric},
+	DeclineCodeExpiredCard:                    {DeclineActionUpdatePaymentMethod, DeclineCategoryCardUnusable},
+	DeclineCodeFraudulent:                     {DeclineActionDoNotRetry, DeclineCategoryFraud},
+	DeclineCodeGenericDecline:                 {DeclineActionUpdatePaymentMethod, DeclineCategoryGeneric},
+	DeclineCodeIncorrectCVC:                   {DeclineActionCorrectDetails, DeclineCategoryIncorrectDetails},
+	DeclineCodeIncorrectNumber:                {DeclineActionCorrectDetails, DeclineCategoryIncorrectDetails},
+	DeclineCodeIncorrectPIN:                   {DeclineActionCorrectDetails, DeclineCategoryIncorrectDetails},
+	DeclineCodeIncorrectZip:                   {DeclineActionCorrectDetails, DeclineCategoryIncorrectDetails},
+	DeclineCodeInsufficientFunds:              {DeclineActionRetryLater, DeclineCategoryInsufficientFunds},
+	DeclineCodeInvalidAccount:                 {DeclineActionUpdatePaymentMethod, DeclineCategoryCardUnusable},
+	DeclineCodeInvalidAmount:                  {DeclineActionUpdatePaymentMethod, DeclineCategoryGeneric},
+	DeclineCodeInvalidCVC:                     {DeclineActionCorrectDetails, DeclineCategoryIncorrectDetails},
+	DeclineCodeInvalidExpiryMonth:             {DeclineActionCorrectDetails, DeclineCategoryIncorrectDetails},
+	DeclineCodeInvalidExpiryYear:              {DeclineActionCorrectDetails, DeclineCategoryIncorrectDetails},
+	DeclineCodeInvalidNumber:                  {DeclineActionCorrectDetails, DeclineCategoryIncorrectDetails},
+	DeclineCodeInvalidPIN:                     {DeclineActionCorrectDetails, DeclineCategoryIncorrectDetails},
+	DeclineCodeIssuerNotAvailable:             {DeclineActionRetryLater, DeclineCategoryTemporary},
+	DeclineCodeLostCard:                       {DeclineActionDoNotRetry, DeclineCategoryFraud},
+	DeclineCodeMerchantBlacklist:              {DeclineActionDoNotRetry, DeclineCategoryFraud},
+	DeclineCodeNewAccountInformationAvailable: {DeclineActionUpdatePaymentMethod, DeclineCategoryCardUnusable},
+	DeclineCodeNoActionTaken:                  {DeclineActionUpdatePaymentMethod, DeclineCategoryGeneric},
+	DeclineCodeNotPermitted:                   {DeclineActionUpdatePaymentMethod, DeclineCategoryGeneric},
+	DeclineCodeOfflinePINRequired:             {DeclineActionAuthenticate, DeclineCategoryAuthenticationRequired},
+	DeclineCodeOnlineOrOfflinePINRequired:     {DeclineActionAuthenticate, DeclineCategoryAuthenticationRequired},
+	DeclineCodePickupCard:                     {DeclineActionDoNotRetry, DeclineCategoryFraud},
+	DeclineCodePINTryExceeded:                 {DeclineActionUpdatePaymentMethod, DeclineCategoryIncorrectDetails},
+	DeclineCodeProcessingError:                {DeclineActionRetryLater, DeclineCategoryTemporary},
+	DeclineCodeReenterTransaction:             {DeclineActionRetryLater, DeclineCategoryTemporary},
+	DeclineCodeRestrictedCard:                 {DeclineActionDoNotRetry, DeclineCategoryFraud},
+	DeclineCodeRevocationOfAllAuthorizations:  {DeclineActionDoNotRetry, DeclineCategoryGeneric},
+	DeclineCodeRevocationOfAuthorization:      {DeclineActionDoNotRetry, DeclineCategoryGeneric},
+	DeclineCodeSecurityViolation:              {DeclineActionDoNotRetry, DeclineCategoryFraud},
+	DeclineCodeServiceNotAllowed:              {DeclineActionUpdatePaymentMethod, DeclineCategoryCardUnusable},
+	DeclineCodeStolenCard:                     {DeclineActionDoNotRetry, DeclineCategoryFraud},
+	DeclineCodeStopPaymentOrder:               {DeclineActionDoNotRetry, DeclineCategoryGeneric},
+	DeclineCodeTestModeDecline:                {DeclineActionUpdatePaymentMethod, DeclineCategoryCardUnusable},
+	DeclineCodeTransactionNotAllowed:          {DeclineActionUpdatePaymentMethod, DeclineCategoryCardUnusable},
+	DeclineCodeTryAgainLater:                  {DeclineActionRetryLater, DeclineCategoryTemporary},
+	DeclineCodeWithdrawalCountLimitExceeded:   {DeclineActionRetryLater, DeclineCategoryInsufficientFunds},
+
+	// Error codes of declines without a decline code.
+	DeclineCode(ErrorCodeCardDeclined):                       {DeclineActionUpdatePaymentMethod, DeclineCategoryGeneric},
+	DeclineCode(ErrorCodeCardDeclineRateLimitExceeded):       {DeclineActionRetryLater, DeclineCategoryTemporary},
+	DeclineCode(ErrorCodeIncorrectAddress):                   {DeclineActionCorrectDetails, DeclineCategoryIncorrectDetails},
+	DeclineCode(ErrorCodeInvalidCardType):                    {DeclineActionUpdatePaymentMethod, DeclineCategoryCardUnusable},
+	DeclineCode(ErrorCodePaymentIntentAuthenticationFailure): {DeclineActionAuthenticate, DeclineCategoryAuthenticationRequired},
+	DeclineCode(ErrorCodePaymentMethodProviderDecline):       {DeclineActionUpdatePaymentMethod, DeclineCategoryGeneric},
+	DeclineCode(ErrorCodePaymentMethodProviderTimeout):       {DeclineActionRetryLater, DeclineCategoryTemporary},
+}
+
+// declineMessages are the messages for customers of each category of
+// declines, by language. Declines because of suspected fraud get the message
+// of generic declines.
+var declineMessages = map[string]map[DeclineCategory]string{
+	"de": {
+		DeclineCategoryAuthenticationRequired: "Ihre Bank verlangt eine Authentifizierung dieser Zahlung. Bitte versuchen Sie es erneut und bestätigen Sie die Zahlung.",
+		DeclineCategoryCardUnusable:           "Ihre Karte kann für diese Zahlung nicht verwendet werden. Bitte verwenden Sie eine andere Zahlungsmethode.",
+		DeclineCategoryGeneric:                "Ihre Karte wurde abgelehnt. Bitte verwenden Sie eine andere Zahlungsmethode oder wenden Sie sich an Ihre Bank.",
+		DeclineCategoryIncorrectDetails:       "Ihre Kartendaten sind nicht korrekt. Bitte überprüfen Sie sie und versuchen Sie es erneut.",
+		DeclineCategoryInsufficientFunds:      "Ihre Karte ist nicht ausreichend gedeckt. Bitte verwenden Sie eine andere Zahlungsmethode.",
+		DeclineCategoryTemporary:              "Ihre Zahlung konnte nicht verarbeitet werden. Bitte versuchen Sie es später erneut.",
+		DeclineCategoryUnknown:                "Ihre Zahlung konnte nicht verarbeitet werden. Bitte versuchen Sie es erneut.",
+	},
+	"en": {
+		DeclineCategoryAuthenticationRequired: "Your bank requires you to authenticate this payment. Please try again and confirm the payment.",
+		DeclineCategoryCardUnusable:           "Your card can't be used for this payment. Please use a different payment method.",
+		DeclineCategoryGeneric:                "Your card was declined. Please use a different payment method or contact your bank.",
+		DeclineCategoryIncorrectDetails:       "Your card details are incorrect. Please check them and try again.",
+		DeclineCategoryInsufficientFunds:      "Your card has insufficient funds. Please use a different payment method.",
+		DeclineCategoryTemporary:              "Your payment couldn't be processed. Please try again later.",
+		DeclineCategoryUnknown:                "Your payment couldn't be processed. Please try again.",
+	},
+	"es": {
+		DeclineCategoryAuthenticationRequired: "Tu banco requiere que autentiques este pago. Vuelve a intentarlo y confirma el pago.",
+		DeclineCategoryCardUnusable:           "Tu tarjeta no se puede usar para este pago. Usa otro método de pago.",
+		DeclineCategoryGeneric:                "Tu tarjeta ha sido rechazada. Usa otro método de pago o ponte en contacto con tu banco.",
+		DeclineCategoryIncorrectDetails:       "Los datos de tu tarjeta no son correctos. Revísalos y vuelve a intentarlo.",
+		DeclineCategoryInsufficientFunds:      "Tu tarjeta no tiene fondos suficientes. Usa otro método de pago.",
+		DeclineCategoryTemporary:              "No se ha podido procesar tu pago. Vuelve a intentarlo más tarde.",
+		DeclineCategoryUnknown:                "No se ha podido procesar tu pago. Vuelve a intentarlo.",
+	},
+	"fr": {
+		DeclineCategoryAuthenticationRequired: "Votre banque demande l'authentification de ce paiement. Veuillez réessayer et confirmer le paiement.",
+		DeclineCategoryCardUnusable:           "Votre carte ne peut pas être utilisée pour ce paiement. Veuillez utiliser un autre moyen de paiement.",
+		DeclineCategoryGeneric:                "Votre carte a été refusée. Veuillez utiliser un autre moyen de paiement ou contacter votre banque.",
+		DeclineCategoryIncorrectDetails:       "Les informations de votre carte sont incorrectes. Veuillez les vérifier et réessayer.",
+		DeclineCategoryInsufficientFunds:      "Le solde de votre carte est insuffisant. Veuillez utiliser un autre moyen de paiement.",
+		DeclineCategoryTemporary:              "Votre paiement n'a pas pu être traité. Veuillez réessayer plus tard.",
+		DeclineCategoryUnknown:                "Votre paiement n'a pas pu être traité. Veuillez réessayer.",
+	},
+	"it": {
+		DeclineCategoryAuthenticationRequired: "La tua banca richiede l'autenticazione di questo pagamento. Riprova e conferma il pagamento.",
+		DeclineCategoryCardUnusable:           "La tua carta non può essere usata per questo pagamento. Usa un altro metodo di pagamento.",
+		DeclineCategoryGeneric:                "La tua carta è stata rifiutata. Usa un altro metodo di pagamento o contatta la tua banca.",
+		DeclineCategoryIncorrectDetails:       "I dati della tua carta non sono corretti. Controllali e riprova.",
+		DeclineCategoryInsufficientFunds:      "La tua carta non ha fondi sufficienti. Usa un altro metodo di pagamento.",
+		DeclineCategoryTemporary:              "Non è stato possibile elaborare il pagamento. Riprova più tardi.",
+		DeclineCategoryUnknown:                "Non è stato possibile elaborare il pagamento. Riprova.",
+	},
+	"ja": {
+		DeclineCategoryAuthenticationRequired: "カード発行会社がこの支払いの認証を求めています。もう一度お試しいただき、支払いを承認してください。",
+		DeclineCategoryCardUnusable:           "このカードはこの支払いに使用できません。別の支払い方法をご利用ください。",
+		DeclineCategoryGeneric:                "カードが拒否されました。別の支払い方法をご利用いただくか、カード発行会社にお問い合わせください。",
+		DeclineCategoryIncorrectDetails:       "カード情報が正しくありません。ご確認のうえ、もう一度お試しください。",
+		DeclineCategoryInsufficientFunds:      "カードの残高が不足しています。別の支払い方法をご利用ください。",
+		DeclineCategoryTemporary:              "支払いを処理できませんでした。しばらくしてからもう一度お試しください。",
+		DeclineCategoryUnknown:                "支払いを処理できませんでした。もう一度お試しください。",
+	},
+	"pt": {
+		DeclineCategoryAuthenticationRequired: "O seu banco exige a autenticação deste pagamento. Tente novamente e confirme o pagamento.",
+		DeclineCategoryCardUnusable:           "O seu cartão não pode ser usado para este pagamento. Use outro método de pagamento.",
+		DeclineCategoryGeneric:                "O seu cartão foi recusado. Use outro método de pagamento ou entre em contato com o seu banco.",
+		DeclineCategoryIncorrectDetails:       "Os dados do seu cartão estão incorretos. Verifique-os e tente novamente.",
+		DeclineCategoryInsufficientFunds:      "O seu cartão não tem saldo suficiente. Use outro método de pagamento.",
+		DeclineCategoryTemporary:              "Não foi possível processar o seu pagamento. Tente novamente mais tarde.",
+		DeclineCategoryUnknown:                "Não foi possível processar o seu pagamento. Tente novamente.",
+	},
+}
+
+//
+// Private functions
+//
+
+// declineClassificationOf returns the classification of a decline code, or
+// an unknown decline to be retried with another payment method for codes
+// which aren't known.
+func declineClassificationOf(c DeclineCode) declineClassification {
+	if classification, ok := declineClassifications[c]; ok {
+		return classification
+	}
+	return declineClassification{DeclineActionUpdatePaymentMethod, DeclineCategoryUnknown}
+}
+
+// declineClassification returns the classification of the decline code of
+// the error, or of its code for errors without one, since some declines are
+// only given an error code. Card errors with codes which aren't known are
+// classified as generic declines.
+//
+// Errors which aren't declines are of category DeclineCategoryUnknown, and
+// are to be retried later when they're temporary, like rate limits, lock
+// timeouts and server errors. Others don't have a recommended action.
+func (e *Error) declineClassification() declineClassification {
+	if e.DeclineCode == "" && e.isTemporary() {
+		return declineClassification{DeclineActionRetryLater, DeclineCategoryUnknown}
+	}
+
+	code := e.DeclineCode
+	if code == "" {
+		code = DeclineCode(e.Code)
+	}
+	if classification, ok := declineClassifications[code]; ok {
+		return classification
+	}
+	if e.Type == ErrorTypeCard {
+		return declineClassification{DeclineActionUpdatePaymentMethod, DeclineCategoryGeneric}
+	}
+	return declineClassification{"", DeclineCategoryUnknown}
+}
+
+// declineMessage returns the message for customers of a category of declines
+// in the language of a locale.
+func declineMessage(category DeclineCategory, locale string) string {
+	if category == DeclineCategoryFraud {
+		category = DeclineCategoryGeneric
+	}
+
+	language := strings.ToLower(locale)
+	if i := strings.IndexAny(language, "-_"); i >= 0 {
+		language = language[:i]
+	}
+	messages, ok := declineMessages[language]
+	if !ok {
+		messages = declineMessages["en"]
+	}
+	return messages[category]
+}
+
+// isTemporary returns whether the error is a temporary failure of Stripe
+// rather than of the request, so that the request may succeed later.
+func (e *Error) isTemporary() bool {
+	return e.HTTPStatusCode == http.StatusTooManyRequests || e.HTTPStatusCode >= http.StatusInternalServerError ||
+		e.Code == ErrorCodeRateLimit || e.Code == ErrorCodeLockTimeout || e.Type == ErrorTypeAPI
+}
@@ -0,0 +1,132 @@
+package stripe
+
+import (
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"strconv"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestDeclineClassificationsCoverDeclineCodes(t *testing.T) {
+	file, err := parser.ParseFile(token.NewFileSet(), "error.go", nil, 0)
+	assert.NoError(t, err)
+
+	count := 0
+	ast.Inspect(file, func(n ast.Node) bool {
+		spec, ok := n.(*ast.ValueSpec)
+		if !ok || spec.Type == nil || spec.Type.(*ast.Ident).Name != "DeclineCode" {
+			return true
+		}
+		for _, value := range spec.Values {
+			code, err := strconv.Unquote(value.(*ast.BasicLit).Value)
+			assert.NoError(t, err)
+			_, ok := declineClassifications[DeclineCode(code)]
+			assert.True(t, ok, "decline code %q isn't classified", code)
+			count++
+		}
+		return true
+	})
+	assert.True(t, count > 40)
+}
+
+func TestDeclineCode_Classification(t *testing.T) {
+	assert.Equal(t, DeclineCategoryInsufficientFunds, DeclineCodeInsufficientFunds.Category())
+	assert.Equal(t, DeclineActionRetryLater, DeclineCodeInsufficientFunds.RecommendedAction())
+	assert.True(t, DeclineCodeInsufficientFunds.IsRetryable())
+	assert.False(t, DeclineCodeInsufficientFunds.IsFraudRelated())
+
+	assert.True(t, DeclineCodeStolenCard.IsFraudRelated())
+	assert.False(t, DeclineCodeStolenCard.IsRetryable())
+	assert.Equal(t, DeclineActionDoNotRetry, DeclineCodeStolenCard.RecommendedAction())
+
+	assert.True(t, DeclineCodeAuthenticationRequired.IsRetryable())
+	assert.False(t, DeclineCodeIncorrectCVC.IsRetryable())
+	assert.Equal(t, DeclineActionCorrectDetails, DeclineCodeIncorrectCVC.RecommendedAction())
+
+	assert.Equal(t, DeclineCategoryUnknown, DeclineCode("new_decline_code").Category())
+	assert.Equal(t, DeclineActionUpdatePaymentMethod, DeclineCode("new_decline_code").RecommendedAction())
+}
+
+func TestError_Classification(t *testing.T) {
+	// The decline code takes precedence over the code.
+	err := &Error{Code: ErrorCodeCardDeclined, DeclineCode: DeclineCodeExpiredCard, Type: ErrorTypeCard}
+	assert.Equal(t, DeclineCategoryCardUnusable, err.Category())
+
+	// Some declines only have a code.
+	err = &Error{Code: ErrorCodeIncorrectZip, Type: ErrorTypeCard}
+	assert.Equal(t, DeclineCategoryIncorrectDetails, err.Category())
+	err = &Error{Code: ErrorCodeCardDeclineRateLimitExceeded, Type: ErrorTypeCard}
+	assert.True(t, err.IsRetryable())
+
+	// Card errors with unknown codes are generic declines.
+	err = &Error{Code: "new_code", Type: ErrorTypeCard}
+	assert.Equal(t, DeclineCategoryGeneric, err.Category())
+
+	err = &Error{Code: ErrorCodeParameterMissing, HTTPStatusCode: http.StatusBadRequest, Type: ErrorTypeInvalidRequest}
+	assert.Equal(t, DeclineCategoryUnknown, err.Category())
+	assert.Equal(t, DeclineAction(""), err.RecommendedAction())
+	assert.False(t, err.IsRetryable())
+
+	err = &Error{HTTPStatusCode: http.StatusBadRequest, Type: ErrorTypeIdempotency}
+	assert.Equal(t, DeclineAction(""), err.RecommendedAction())
+	assert.False(t, err.IsRetryable())
+}
+
+func TestError_ClassificationTemporary(t *testing.T) {
+	errs := []*Error{
+		{Code: ErrorCodeRateLimit, HTTPStatusCode: http.StatusTooManyRequests, Type: ErrorTypeInvalidRequest},
+		{Code: ErrorCodeLockTimeout, HTTPStatusCode: http.StatusTooManyRequests, Type: ErrorTypeInvalidRequest},
+		{HTTPStatusCode: http.StatusInternalServerError, Type: ErrorTypeAPI},
+		{HTTPStatusCode: http.StatusServiceUnavailable},
+	}
+	for _, err := range errs {
+		assert.Equal(t, DeclineCategoryUnknown, err.Category())
+		assert.Equal(t, DeclineActionRetryLater, err.RecommendedAction())
+		assert.True(t, err.IsRetryable())
+	}
+
+	// Declines of too many attempts on a card aren't rate limits of Stripe.
+	err := &Error{Code: ErrorCodeCardDeclineRateLimitExceeded, HTTPStatusCode: http.StatusPaymentRequired, Type: ErrorTypeCard}
+	assert.Equal(t, DeclineCategoryTemporary, err.Category())
+
+	connectionErr := &ConnectionError{Err: errors.New("connection reset")}
+	assert.Equal(t, DeclineActionRetryLater, connectionErr.RecommendedAction())
+	assert.True(t, connectionErr.IsRetryable())
+}
+
+func TestError_CustomerFacingMessage(t *testing.T) {
+	err := &Error{Code: ErrorCodeCardDeclined, DeclineCode: DeclineCodeInsufficientFunds, Type: ErrorTypeCard}
+	assert.Equal(t, "Your card has insufficient funds. Please use a different payment method.", err.CustomerFacingMessage("en-US"))
+	assert.Equal(t, "Le solde de votre carte est insuffisant. Veuillez utiliser un autre moyen de paiement.", err.CustomerFacingMessage("fr_CA"))
+	assert.Equal(t, err.CustomerFacingMessage("en"), err.CustomerFacingMessage("xx"))
+
+	// Fraud isn't disclosed to the customer.
+	fraud := &Error{Code: ErrorCodeCardDeclined, DeclineCode: DeclineCodeFraudulent, Type: ErrorTypeCard}
+	generic := &Error{Code: ErrorCodeCardDeclined, DeclineCode: DeclineCodeGenericDecline, Type: ErrorTypeCard}
+	assert.Equal(t, generic.CustomerFacingMessage("de"), fraud.CustomerFacingMessage("de"))
+
+	for language, messages := range declineMessages {
+		assert.Equal(t, len(declineMessages["en"]), len(messages), language)
+	}
+}
+
+func TestCardError_Classification(t *testing.T) {
+	c := GetBackend(APIBackend).(*BackendImplementation)
+	err := c.ResponseToError(&http.Response{
+		Header:     http.Header{},
+		StatusCode: http.StatusPaymentRequired,
+	}, []byte(`{"error":{"type":"card_error","code":"card_declined","decline_code":"lost_card"}}`))
+
+	var cardErr *CardError
+	assert.True(t, errors.As(err, &cardErr))
+	assert.True(t, cardErr.IsFraudRelated())
+	assert.Equal(t, DeclineActionDoNotRetry, cardErr.RecommendedAction())
+	assert.Equal(t, DeclineCategoryFraud, cardErr.Category())
+	assert.False(t, cardErr.IsRetryable())
+	assert.NotEmpty(t, cardErr.CustomerFacingMessage("ja"))
+}
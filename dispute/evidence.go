@@ -0,0 +1,537 @@
+package dispute
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+
+	stripe "github.com/stripe/stripe-go/v81"
+	"github.com/stripe/stripe-go/v81/file"
+)
+
+//
+// Public constants
+//
+
+// Limits of dispute evidence.
+const (
+	// MaxEvidenceFilesSize is the maximum combined size of the files attached
+	// to the evidence of a dispute, in bytes (4.5 MB).
+	MaxEvidenceFilesSize = 4718592
+
+	// MaxEvidenceFieldLength is the maximum number of characters of a text
+	// field of evidence.
+	MaxEvidenceFieldLength = 20000
+
+	// MaxEvidenceLength is the maximum combined number of characters of all
+	// the fields of evidence.
+	MaxEvidenceLength = 150000
+)
+
+// List of values that EvidenceField can take. The fields documented as file
+// fields take the ID of a file uploaded with purpose `dispute_evidence`, like
+// with EvidenceBuilder.Attach.
+const (
+	EvidenceFieldAccessActivityLog            EvidenceField = "access_activity_log"
+	EvidenceFieldBillingAddress               EvidenceField = "billing_address"
+	EvidenceFieldCancellationPolicy           EvidenceField = "cancellation_policy" // File field.
+	EvidenceFieldCancellationPolicyDisclosure EvidenceField = "cancellation_policy_disclosure"
+	EvidenceFieldCancellationRebuttal         EvidenceField = "cancellation_rebuttal"
+	EvidenceFieldCustomerCommunication        EvidenceField = "customer_communication" // File field.
+	EvidenceFieldCustomerEmailAddress         EvidenceField = "customer_email_address"
+	EvidenceFieldCustomerName                 EvidenceField = "customer_name"
+	EvidenceFieldCustomerPurchaseIP           EvidenceField = "customer_purchase_ip"
+	EvidenceFieldCustomerSignature            EvidenceField = "customer_signature"             // File field.
+	EvidenceFieldDuplicateChargeDocumentation EvidenceField = "duplicate_charge_documentation" // File field.
+	EvidenceFieldDuplicateChargeExplanation   EvidenceField = "duplicate_charge_explanation"
+	EvidenceFieldDuplicateChargeID            EvidenceField = "duplicate_charge_id"
+	EvidenceFieldProductDescription           EvidenceField = "product_description"
+	EvidenceFieldReceipt                      EvidenceField = "receipt"       // File field.
+	EvidenceFieldRefundPolicy                 EvidenceField = "refund_policy" // File field.
+	EvidenceFieldRefundPolicyDisclosure       EvidenceField = "refund_policy_disclosure"
+	EvidenceFieldRefundRefusalExplanation     EvidenceField = "refund_refusal_explanation"
+	EvidenceFieldServiceDate                  EvidenceField = "service_date"
+	EvidenceFieldServiceDocumentation         EvidenceField = "service_documentation" // File field.
+	EvidenceFieldShippingAddress              EvidenceField = "shipping_address"
+	EvidenceFieldShippingCarrier              EvidenceField = "shipping_carrier"
+	EvidenceFieldShippingDate                 EvidenceField = "shipping_date"
+	EvidenceFieldShippingDocumentation        EvidenceField = "shipping_documentation" // File field.
+	EvidenceFieldShippingTrackingNumber       EvidenceField = "shipping_tracking_number"
+	EvidenceFieldUncategorizedFile            EvidenceField = "uncategorized_file" // File field.
+	EvidenceFieldUncategorizedText            EvidenceField = "uncategorized_text"
+)
+
+//
+// Public types
+//
+
+// EvidenceBuilder builds the evidence of a dispute field by field, checking
+// the limits of evidence as they're set, and submits it once the fields
+// required for the reason of the dispute are set.
+type EvidenceBuilder struct {
+	// Files is the client used to upload attached files.
+	//
+	// Defaults to a client with the uploads backend from stripe.GetBackend,
+	// and the backend and key of the dispute client.
+	Files *file.Client
+
+	// Params are used for file uploads and for updating the dispute, so that
+	// their Context can cancel them and their StripeAccount can respond to
+	// the disputes of connected accounts. Their IdempotencyKey is ignored,
+	// since it can't be shared by several requests.
+	Params stripe.Params
+
+	c        Client
+	dispute  *stripe.Dispute
+	evidence *stripe.DisputeEvidenceParams
+
+	// filesSizes are the sizes of the attached files per file field.
+	filesSizes map[EvidenceField]int64
+}
+
+// EvidenceField is the name of a field of dispute evidence, as in the API.
+type EvidenceField string
+
+// IncompleteEvidenceError is the error returned when submitting evidence
+// without the fields required for the reason of the dispute.
+type IncompleteEvidenceError struct {
+	// Missing are the required fields which aren't set.
+	Missing []EvidenceField
+
+	// Reason is the reason of the dispute.
+	Reason stripe.DisputeReason
+}
+
+// Error lists the missing fields.
+func (e *IncompleteEvidenceError) Error() string {
+	missing := make([]string, len(e.Missing))
+	for i, field := range e.Missing {
+		missing[i] = string(field)
+	}
+	return fmt.Sprintf("dispute: evidence of %s dispute is missing %s", e.Reason, strings.Join(missing, ", "))
+}
+
+// Attach uploads a file with purpose `dispute_evidence` and sets it as a file
+// field of the evidence. The file is read into memory first, and isn't
+// uploaded if it brings the combined size of the attached files over
+// MaxEvidenceFilesSize. The size of the file it replaces, if any, isn't
+// counted anymore.
+func (b *EvidenceBuilder) Attach(field EvidenceField, filename string, r io.Reader) error {
+	if !evidenceFileFields[field] {
+		return fmt.Errorf("dispute: evidence field %s isn't a file field", field)
+	}
+
+	// Reading one byte more than what's left is enough to know that the file
+	// is too large.
+	left := MaxEvidenceFilesSize - b.filesSize() + b.filesSizes[field]
+	contents, err := ioutil.ReadAll(io.LimitReader(r, left+1))
+	if err != nil {
+		return err
+	}
+	if size := int64(len(contents)); size > left {
+		return fmt.Errorf("dispute: attaching %s brings evidence files over %d bytes", filename, MaxEvidenceFilesSize)
+	}
+
+	files := b.Files
+	if files == nil {
+		files = &file.Client{B: b.c.B, BUploads: stripe.GetBackend(stripe.UploadsBackend), Key: b.c.Key}
+	}
+	f, err := files.New(&stripe.FileParams{
+		FileReader: bytes.NewReader(contents),
+		Filename:   stripe.String(filename),
+		Params:     b.params(),
+		Purpose:    stripe.String(string(stripe.FilePurposeDisputeEvidence)),
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := b.set(field, f.ID); err != nil {
+		return err
+	}
+	b.filesSizes[field] = int64(len(contents))
+	return nil
+}
+
+// Evidence returns a copy of the evidence built so far.
+func (b *EvidenceBuilder) Evidence() *stripe.DisputeEvidenceParams {
+	evidence := *b.evidence
+	return &evidence
+}
+
+// IsComplete returns whether the fields required for the reason of the
+// dispute are set.
+func (b *EvidenceBuilder) IsComplete() bool {
+	return len(b.Missing()) == 0
+}
+
+// Missing returns the fields required for the reason of the dispute which
+// aren't set.
+func (b *EvidenceBuilder) Missing() []EvidenceField {
+	var missing []EvidenceField
+	for _, field := range requirementsOf(b.dispute.Reason).required {
+		if b.get(field) == "" {
+			missing = append(missing, field)
+		}
+	}
+	return missing
+}
+
+// Recommended returns the fields relevant to the reason of the dispute,
+// starting with the required ones. Setting as many of them as possible
+// improves the chances of winning the dispute.
+func (b *EvidenceBuilder) Recommended() []EvidenceField {
+	requirements := requirementsOf(b.dispute.Reason)
+	return append(append([]EvidenceField(nil), requirements.required...), requirements.recommended...)
+}
+
+// Save stages the evidence on the dispute without submitting it, so that it
+// can be completed later, and returns the updated dispute.
+func (b *EvidenceBuilder) Save() (*stripe.Dispute, error) {
+	return b.update(false)
+}
+
+// Set sets a text field of the evidence, checking MaxEvidenceFieldLength and
+// MaxEvidenceLength.
+func (b *EvidenceBuilder) Set(field EvidenceField, value string) error {
+	if evidenceFileFields[field] {
+		return fmt.Errorf("dispute: evidence field %s is a file field", field)
+	}
+	if length := utf8.RuneCountInString(value); length > MaxEvidenceFieldLength {
+		return fmt.Errorf("dispute: evidence field %s is %d characters long, more than %d",
+			field, length, MaxEvidenceFieldLength)
+	}
+	return b.set(field, value)
+}
+
+// SetFile sets a file field of the evidence to a file already uploaded with
+// purpose `dispute_evidence`. Its size isn't counted towards
+// MaxEvidenceFilesSize, and neither is that of the file it replaces anymore.
+func (b *EvidenceBuilder) SetFile(field EvidenceField, fileID string) error {
+	if !evidenceFileFields[field] {
+		return fmt.Errorf("dispute: evidence field %s isn't a file field", field)
+	}
+	if err := b.set(field, fileID); err != nil {
+		return err
+	}
+	delete(b.filesSizes, field)
+	return nil
+}
+
+// Submit submits the evidence to the bank and returns the updated dispute.
+// Evidence can't be changed once submitted, so an *IncompleteEvidenceError
+// is returned without submitting it if fields required for the reason of the
+// dispute are missing.
+func (b *EvidenceBuilder) Submit() (*stripe.Dispute, error) {
+	if missing := b.Missing(); len(missing) > 0 {
+		return nil, &IncompleteEvidenceError{Missing: missing, Reason: b.dispute.Reason}
+	}
+	return b.update(true)
+}
+
+//
+// Public functions
+//
+
+// NewEvidenceBuilder returns a builder of the evidence of a dispute. See
+// Client.NewEvidenceBuilder.
+func NewEvidenceBuilder(dispute *stripe.Dispute) *EvidenceBuilder {
+	return getC().NewEvidenceBuilder(dispute)
+}
+
+// NewEvidenceBuilder returns a builder of the evidence of a dispute, whose
+// Reason determines the required and recommended fields. The builder starts
+// from the evidence already staged on the dispute, and the sizes of its files
+// count towards MaxEvidenceFilesSize. The dispute is updated with the client
+// when saving or submitting the evidence.
+func (c Client) NewEvidenceBuilder(dispute *stripe.Dispute) *EvidenceBuilder {
+	b := &EvidenceBuilder{
+		c:          c,
+		dispute:    dispute,
+		evidence:   &stripe.DisputeEvidenceParams{},
+		filesSizes: make(map[EvidenceField]int64),
+	}
+	if dispute.Evidence != nil {
+		b.stage(dispute.Evidence)
+	}
+	return b
+}
+
+//
+// Private types
+//
+
+// evidenceRequirements are the fields of evidence relevant to a reason of
+// dispute.
+type evidenceRequirements struct {
+	recommended []EvidenceField
+	required    []EvidenceField
+}
+
+//
+// Private variables
+//
+
+// evidenceFieldIndexes are the indexes of the fields of
+// stripe.DisputeEvidenceParams per evidence field.
+var evidenceFieldIndexes = func() map[EvidenceField]int {
+	indexes := make(map[EvidenceField]int)
+	t := reflect.TypeOf(stripe.DisputeEvidenceParams{})
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Type == reflect.TypeOf((*string)(nil)) {
+			indexes[EvidenceField(field.Tag.Get("form"))] = i
+		}
+	}
+	return indexes
+}()
+
+// evidenceStagedIndexes are the indexes of the fields of
+// stripe.DisputeEvidence per evidence field.
+var evidenceStagedIndexes = func() map[EvidenceField]int {
+	indexes := make(map[EvidenceField]int)
+	t := reflect.TypeOf(stripe.DisputeEvidence{})
+	for i := 0; i < t.NumField(); i++ {
+		field := EvidenceField(t.Field(i).Tag.Get("json"))
+		if _, ok := evidenceFieldIndexes[field]; ok {
+			indexes[field] = i
+		}
+	}
+	return indexes
+}()
+
+var evidenceFileFields = map[EvidenceField]bool{
+	EvidenceFieldCancellationPolicy:           true,
+	EvidenceFieldCustomerCommunication:        true,
+	EvidenceFieldCustomerSignature:            true,
+	EvidenceFieldDuplicateChargeDocumentation: true,
+	EvidenceFieldReceipt:                      true,
+	EvidenceFieldRefundPolicy:                 true,
+	EvidenceFieldServiceDocumentation:         true,
+	EvidenceFieldShippingDocumentation:        true,
+	EvidenceFieldUncategorizedFile:            true,
+}
+
+// evidenceRequirementsByReason are the fields of evidence relevant to each
+// reason of dispute, following Stripe's guidelines for responding to
+// disputes. Reasons which aren't listed use generalEvidenceRequirements.
+var evidenceRequirementsByReason = map[stripe.DisputeReason]evidenceRequirements{
+	stripe.DisputeReasonCreditNotProcessed: {
+		required: []EvidenceField{
+			EvidenceFieldRefundPolicy,
+			EvidenceFieldRefundRefusalExplanation,
+		},
+		recommended: []EvidenceField{
+			EvidenceFieldRefundPolicyDisclosure,
+			EvidenceFieldCustomerCommunication,
+			EvidenceFieldReceipt,
+			EvidenceFieldProductDescription,
+		},
+	},
+	stripe.DisputeReasonDuplicate: {
+		required: []EvidenceField{
+			EvidenceFieldDuplicateChargeExplanation,
+		},
+		recommended: []EvidenceField{
+			EvidenceFieldDuplicateChargeID,
+			EvidenceFieldDuplicateChargeDocumentation,
+			EvidenceFieldReceipt,
+			EvidenceFieldCustomerCommunication,
+		},
+	},
+	stripe.DisputeReasonFraudulent: {
+		required: []EvidenceField{
+			EvidenceFieldProductDescription,
+			EvidenceFieldCustomerName,
+			EvidenceFieldCustomerEmailAddress,
+		},
+		recommended: []EvidenceField{
+			EvidenceFieldBillingAddress,
+			EvidenceFieldCustomerPurchaseIP,
+			EvidenceFieldReceipt,
+			EvidenceFieldCustomerCommunication,
+			EvidenceFieldCustomerSignature,
+			EvidenceFieldAccessActivityLog,
+			EvidenceFieldShippingAddress,
+			EvidenceFieldShippingTrackingNumber,
+			EvidenceFieldShippingDocumentation,
+		},
+	},
+	stripe.DisputeReasonProductNotReceived: {
+		required: []EvidenceField{
+			EvidenceFieldProductDescription,
+		},
+		recommended: []EvidenceField{
+			EvidenceFieldShippingAddress,
+			EvidenceFieldShippingCarrier,
+			EvidenceFieldShippingDate,
+			EvidenceFieldShippingTrackingNumber,
+			EvidenceFieldShippingDocumentation,
+			EvidenceFieldServiceDate,
+			EvidenceFieldServiceDocumentation,
+			EvidenceFieldAccessActivityLog,
+			EvidenceFieldCustomerCommunication,
+			EvidenceFieldReceipt,
+		},
+	},
+	stripe.DisputeReasonProductUnacceptable: {
+		required: []EvidenceField{
+			EvidenceFieldProductDescription,
+			EvidenceFieldRefundRefusalExplanation,
+		},
+		recommended: []EvidenceField{
+			EvidenceFieldRefundPolicy,
+			EvidenceFieldRefundPolicyDisclosure,
+			EvidenceFieldCustomerCommunication,
+			EvidenceFieldReceipt,
+			EvidenceFieldServiceDocumentation,
+			EvidenceFieldShippingDocumentation,
+		},
+	},
+	stripe.DisputeReasonSubscriptionCanceled: {
+		required: []EvidenceField{
+			EvidenceFieldCancellationPolicy,
+			EvidenceFieldCancellationRebuttal,
+		},
+		recommended: []EvidenceField{
+			EvidenceFieldCancellationPolicyDisclosure,
+			EvidenceFieldCustomerCommunication,
+			EvidenceFieldReceipt,
+			EvidenceFieldProductDescription,
+			EvidenceFieldAccessActivityLog,
+		},
+	},
+	stripe.DisputeReasonUnrecognized: {
+		required: []EvidenceField{
+			EvidenceFieldProductDescription,
+			EvidenceFieldCustomerName,
+		},
+		recommended: []EvidenceField{
+			EvidenceFieldCustomerEmailAddress,
+			EvidenceFieldBillingAddress,
+			EvidenceFieldCustomerPurchaseIP,
+			EvidenceFieldReceipt,
+			EvidenceFieldCustomerCommunication,
+			EvidenceFieldShippingAddress,
+			EvidenceFieldShippingDocumentation,
+		},
+	},
+}
+
+var generalEvidenceRequirements = evidenceRequirements{
+	required: []EvidenceField{
+		EvidenceFieldProductDescription,
+	},
+	recommended: []EvidenceField{
+		EvidenceFieldReceipt,
+		EvidenceFieldCustomerCommunication,
+		EvidenceFieldCustomerName,
+		EvidenceFieldCustomerEmailAddress,
+		EvidenceFieldUncategorizedText,
+		EvidenceFieldUncategorizedFile,
+	},
+}
+
+//
+// Private functions
+//
+
+// filesSize returns the combined size of the attached files.
+func (b *EvidenceBuilder) filesSize() int64 {
+	var size int64
+	for _, fieldSize := range b.filesSizes {
+		size += fieldSize
+	}
+	return size
+}
+
+// get returns the value of a field of the evidence.
+func (b *EvidenceBuilder) get(field EvidenceField) string {
+	value := reflect.ValueOf(b.evidence).Elem().Field(evidenceFieldIndexes[field])
+	return stripe.StringValue(value.Interface().(*string))
+}
+
+// length returns the combined number of characters of the text fields of
+// the evidence.
+func (b *EvidenceBuilder) length() int {
+	length := 0
+	for field := range evidenceFieldIndexes {
+		if !evidenceFileFields[field] {
+			length += utf8.RuneCountInString(b.get(field))
+		}
+	}
+	return length
+}
+
+// params returns the params of a request, without the IdempotencyKey which
+// would otherwise be sent with every request.
+func (b *EvidenceBuilder) params() stripe.Params {
+	params := b.Params
+	params.IdempotencyKey = nil
+	return params
+}
+
+// set sets a field of the evidence, checking MaxEvidenceLength for text
+// fields.
+func (b *EvidenceBuilder) set(field EvidenceField, value string) error {
+	index, ok := evidenceFieldIndexes[field]
+	if !ok {
+		return fmt.Errorf("dispute: unknown evidence field %s", field)
+	}
+
+	length := b.length() - utf8.RuneCountInString(b.get(field)) + utf8.RuneCountInString(value)
+	if !evidenceFileFields[field] && length > MaxEvidenceLength {
+		return fmt.Errorf("dispute: setting evidence field %s brings evidence to %d characters, more than %d",
+			field, length, MaxEvidenceLength)
+	}
+
+	reflect.ValueOf(b.evidence).Elem().Field(index).Set(reflect.ValueOf(stripe.String(value)))
+	return nil
+}
+
+// stage starts the evidence from the evidence staged on the dispute, which
+// has expanded files in place of file IDs.
+func (b *EvidenceBuilder) stage(staged *stripe.DisputeEvidence) {
+	evidence := reflect.ValueOf(staged).Elem()
+	for field, index := range evidenceStagedIndexes {
+		value := ""
+		switch staged := evidence.Field(index).Interface().(type) {
+		case string:
+			value = staged
+		case *stripe.File:
+			if staged != nil {
+				value = staged.ID
+				b.filesSizes[field] = staged.Size
+			}
+		}
+		if value != "" {
+			reflect.ValueOf(b.evidence).Elem().Field(evidenceFieldIndexes[field]).Set(reflect.ValueOf(stripe.String(value)))
+		}
+	}
+}
+
+// update updates the dispute with the evidence.
+func (b *EvidenceBuilder) update(submit bool) (*stripe.Dispute, error) {
+	params := &stripe.DisputeParams{
+		Evidence: b.Evidence(),
+		Params:   b.params(),
+		Submit:   stripe.Bool(submit),
+	}
+	dispute, err := b.c.Update(b.dispute.ID, params)
+	if err != nil {
+		return nil, err
+	}
+	b.dispute = dispute
+	return dispute, nil
+}
+
+func requirementsOf(reason stripe.DisputeReason) evidenceRequirements {
+	if requirements, ok := evidenceRequirementsByReason[reason]; ok {
+		return requirements
+	}
+	return generalEvidenceRequirements
+}
@@ -0,0 +1,204 @@
+package dispute_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+	stripe "github.com/stripe/stripe-go/v81"
+	"github.com/stripe/stripe-go/v81/dispute"
+	"github.com/stripe/stripe-go/v81/file"
+	"github.com/stripe/stripe-go/v81/stripetest"
+)
+
+func TestEvidenceBuilder(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/files":
+			assert.NoError(t, r.ParseMultipartForm(1<<20))
+			assert.Equal(t, "dispute_evidence", r.FormValue("purpose"))
+			fmt.Fprint(w, `{"id":"file_123","object":"file","size":1024}`)
+		case "/v1/disputes/dp_123":
+			assert.NoError(t, r.ParseForm())
+			assert.Equal(t, "file_123", r.PostForm.Get("evidence[cancellation_policy]"))
+			assert.Equal(t, "Canceled after renewal.", r.PostForm.Get("evidence[cancellation_rebuttal]"))
+			fmt.Fprintf(w, `{"id":"dp_123","object":"dispute","reason":"subscription_canceled","status":"%s"}`,
+				map[string]string{"true": "under_review", "false": "needs_response"}[r.PostForm.Get("submit")])
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	c := dispute.Client{B: stripetest.NewBackend(stripe.APIBackend, ts.URL), Key: "sk_test_123"}
+	b := c.NewEvidenceBuilder(&stripe.Dispute{ID: "dp_123", Reason: stripe.DisputeReasonSubscriptionCanceled})
+	b.Files = &file.Client{BUploads: stripetest.NewBackend(stripe.APIBackend, ts.URL), Key: "sk_test_123"}
+	assert.Equal(t, []dispute.EvidenceField{
+		dispute.EvidenceFieldCancellationPolicy,
+		dispute.EvidenceFieldCancellationRebuttal,
+	}, b.Missing())
+	assert.Equal(t, dispute.EvidenceFieldCancellationPolicyDisclosure, b.Recommended()[2])
+
+	_, err := b.Submit()
+	assert.Equal(t, &dispute.IncompleteEvidenceError{
+		Missing: b.Missing(),
+		Reason:  stripe.DisputeReasonSubscriptionCanceled,
+	}, err)
+
+	assert.NoError(t, b.Attach(dispute.EvidenceFieldCancellationPolicy, "policy.pdf", strings.NewReader("%PDF")))
+	assert.NoError(t, b.Set(dispute.EvidenceFieldCancellationRebuttal, "Canceled after renewal."))
+	assert.True(t, b.IsComplete())
+
+	d, err := b.Save()
+	assert.NoError(t, err)
+	assert.Equal(t, stripe.DisputeStatusNeedsResponse, d.Status)
+
+	d, err = b.Submit()
+	assert.NoError(t, err)
+	assert.Equal(t, stripe.DisputeStatusUnderReview, d.Status)
+}
+
+func TestEvidenceBuilderLimits(t *testing.T) {
+	b := dispute.NewEvidenceBuilder(&stripe.Dispute{ID: "dp_123"})
+	assert.Equal(t, []dispute.EvidenceField{dispute.EvidenceFieldProductDescription}, b.Missing())
+
+	assert.EqualError(t, b.Set(dispute.EvidenceFieldReceipt, "Receipt"),
+		"dispute: evidence field receipt is a file field")
+	assert.EqualError(t, b.SetFile(dispute.EvidenceFieldCustomerName, "file_123"),
+		"dispute: evidence field customer_name isn't a file field")
+	assert.EqualError(t, b.Set("unknown", "value"), "dispute: unknown evidence field unknown")
+
+	long := strings.Repeat("é", dispute.MaxEvidenceFieldLength)
+	assert.Error(t, b.Set(dispute.EvidenceFieldProductDescription, long+"é"))
+	assert.Nil(t, b.Evidence().ProductDescription)
+
+	fields := []dispute.EvidenceField{
+		dispute.EvidenceFieldAccessActivityLog,
+		dispute.EvidenceFieldBillingAddress,
+		dispute.EvidenceFieldCancellationPolicyDisclosure,
+		dispute.EvidenceFieldCancellationRebuttal,
+		dispute.EvidenceFieldCustomerEmailAddress,
+		dispute.EvidenceFieldCustomerName,
+		dispute.EvidenceFieldCustomerPurchaseIP,
+	}
+	for _, field := range fields {
+		assert.NoError(t, b.Set(field, long))
+	}
+	assert.EqualError(t, b.Set(dispute.EvidenceFieldProductDescription, long),
+		"dispute: setting evidence field product_description brings evidence to 160000 characters, more than 150000")
+
+	// Replacing a field only counts its new value.
+	assert.NoError(t, b.Set(dispute.EvidenceFieldCustomerName, "Jenny Rosen"))
+	assert.NoError(t, b.Set(dispute.EvidenceFieldProductDescription, long))
+	assert.True(t, b.IsComplete())
+}
+
+func TestEvidenceBuilderFilesSize(t *testing.T) {
+	uploads := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		uploads++
+		fmt.Fprint(w, `{"id":"file_123","object":"file"}`)
+	}))
+	defer ts.Close()
+
+	b := dispute.NewEvidenceBuilder(&stripe.Dispute{ID: "dp_123"})
+	b.Files = &file.Client{BUploads: stripetest.NewBackend(stripe.APIBackend, ts.URL), Key: "sk_test_123"}
+	receipt := strings.Repeat("x", 3000000)
+	assert.NoError(t, b.Attach(dispute.EvidenceFieldReceipt, "receipt.pdf", strings.NewReader(receipt)))
+	assert.EqualError(t, b.Attach(dispute.EvidenceFieldShippingDocumentation, "shipping.pdf", strings.NewReader(receipt)),
+		"dispute: attaching shipping.pdf brings evidence files over 4718592 bytes")
+	assert.Nil(t, b.Evidence().ShippingDocumentation)
+	assert.Equal(t, 1, uploads)
+
+	// File IDs don't count towards the length of the text fields.
+	long := strings.Repeat("x", dispute.MaxEvidenceFieldLength)
+	for _, field := range []dispute.EvidenceField{
+		dispute.EvidenceFieldAccessActivityLog,
+		dispute.EvidenceFieldBillingAddress,
+		dispute.EvidenceFieldCancellationPolicyDisclosure,
+		dispute.EvidenceFieldCancellationRebuttal,
+		dispute.EvidenceFieldCustomerEmailAddress,
+		dispute.EvidenceFieldCustomerName,
+		dispute.EvidenceFieldCustomerPurchaseIP,
+	} {
+		assert.NoError(t, b.Set(field, long))
+	}
+	assert.NoError(t, b.Set(dispute.EvidenceFieldProductDescription, strings.Repeat("x", 10000)))
+}
+
+func TestEvidenceBuilderFilesSizeReplaced(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"file_456","object":"file"}`)
+	}))
+	defer ts.Close()
+
+	b := dispute.NewEvidenceBuilder(&stripe.Dispute{ID: "dp_123"})
+	b.Files = &file.Client{BUploads: stripetest.NewBackend(stripe.APIBackend, ts.URL), Key: "sk_test_123"}
+	receipt := strings.Repeat("x", 3000000)
+	assert.NoError(t, b.Attach(dispute.EvidenceFieldReceipt, "receipt.pdf", strings.NewReader(receipt)))
+	assert.NoError(t, b.Attach(dispute.EvidenceFieldReceipt, "receipt.pdf", strings.NewReader(receipt)))
+
+	// Files set by ID aren't counted, and neither are those they replace.
+	assert.NoError(t, b.SetFile(dispute.EvidenceFieldReceipt, "file_123"))
+	assert.NoError(t, b.Attach(dispute.EvidenceFieldShippingDocumentation, "shipping.pdf", strings.NewReader(receipt)))
+}
+
+func TestEvidenceBuilderIdempotencyKey(t *testing.T) {
+	var keys []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		switch r.URL.Path {
+		case "/v1/files":
+			fmt.Fprint(w, `{"id":"file_123","object":"file"}`)
+		default:
+			fmt.Fprint(w, `{"id":"dp_123","object":"dispute"}`)
+		}
+	}))
+	defer ts.Close()
+
+	c := dispute.Client{B: stripetest.NewBackend(stripe.APIBackend, ts.URL), Key: "sk_test_123"}
+	b := c.NewEvidenceBuilder(&stripe.Dispute{ID: "dp_123"})
+	b.Files = &file.Client{BUploads: stripetest.NewBackend(stripe.APIBackend, ts.URL), Key: "sk_test_123"}
+	b.Params.SetIdempotencyKey("key_123")
+	assert.NoError(t, b.Attach(dispute.EvidenceFieldReceipt, "receipt.pdf", strings.NewReader("%PDF")))
+	assert.NoError(t, b.Set(dispute.EvidenceFieldProductDescription, "T-shirt"))
+	_, err := b.Save()
+	assert.NoError(t, err)
+
+	assert.Len(t, keys, 2)
+	assert.NotContains(t, keys, "key_123")
+	assert.Equal(t, "key_123", stripe.StringValue(b.Params.IdempotencyKey))
+}
+
+func TestEvidenceBuilderStaged(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "T-shirt", r.PostForm.Get("evidence[product_description]"))
+		assert.Equal(t, "file_123", r.PostForm.Get("evidence[receipt]"))
+		fmt.Fprint(w, `{"id":"dp_123","object":"dispute","status":"needs_response"}`)
+	}))
+	defer ts.Close()
+
+	c := dispute.Client{B: stripetest.NewBackend(stripe.APIBackend, ts.URL), Key: "sk_test_123"}
+	b := c.NewEvidenceBuilder(&stripe.Dispute{
+		ID: "dp_123",
+		Evidence: &stripe.DisputeEvidence{
+			ProductDescription: "T-shirt",
+			Receipt:            &stripe.File{ID: "file_123", Size: 3000000},
+		},
+	})
+	b.Files = &file.Client{BUploads: stripetest.NewBackend(stripe.APIBackend, ts.URL), Key: "sk_test_123"}
+	assert.True(t, b.IsComplete())
+	assert.Equal(t, "file_123", stripe.StringValue(b.Evidence().Receipt))
+	assert.Nil(t, b.Evidence().CustomerName)
+
+	receipt := strings.Repeat("x", 3000000)
+	assert.EqualError(t, b.Attach(dispute.EvidenceFieldShippingDocumentation, "shipping.pdf", strings.NewReader(receipt)),
+		"dispute: attaching shipping.pdf brings evidence files over 4718592 bytes")
+
+	_, err := b.Submit()
+	assert.NoError(t, err)
+}
@@ -34,7 +34,7 @@ type EntitlementsFeatureParams struct {
 	// A unique key you provide as your own system identifier. This may be up to 80 characters.
 	LookupKey *string `form:"lookup_key"`
 	// Set of key-value pairs that you can attach to an object. This can be useful for storing additional information about the object in a structured format.
-	Metadata map[string]string `form:"metadata"`
+	Metadata Metadata `form:"metadata"`
 	// The feature's name, for your own purpose, not meant to be displayable to the customer.
 	Name *string `form:"name"`
 }
@@ -47,7 +47,7 @@ func (p *EntitlementsFeatureParams) AddExpand(f string) {
 // AddMetadata adds a new key-value pair to the Metadata.
 func (p *EntitlementsFeatureParams) AddMetadata(key string, value string) {
 	if p.Metadata == nil {
-		p.Metadata = make(map[string]string)
+		p.Metadata = make(Metadata)
 	}
 
 	p.Metadata[key] = value
@@ -66,7 +66,7 @@ type EntitlementsFeature struct {
 	// A unique key you provide as your own system identifier. This may be up to 80 characters.
 	LookupKey string `json:"lookup_key"`
 	// Set of key-value pairs that you can attach to an object. This can be useful for storing additional information about the object in a structured format.
-	Metadata map[string]string `json:"metadata"`
+	Metadata Metadata `json:"metadata"`
 	// The feature's name, for your own purpose, not meant to be displayable to the customer.
 	Name string `json:"name"`
 	// String representing the object's type. Objects of the same type share the same value.
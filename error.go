@@ -1,6 +1,9 @@
 package stripe
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+)
 
 // ErrorType is the list of allowed values for the error's type.
 type ErrorType string
@@ -251,8 +254,32 @@ const (
 	DeclineCodeWithdrawalCountLimitExceeded   DeclineCode = "withdrawal_count_limit_exceeded"
 )
 
+// Sentinel errors for common error codes, which errors returned by the API
+// match with errors.Is when they have the same code:
+//
+//	if errors.Is(err, stripe.ErrResourceMissing) {
+//		// ...
+//	}
+var (
+	ErrAPIKeyExpired         error = codeError(ErrorCodeAPIKeyExpired)
+	ErrCardDeclined          error = codeError(ErrorCodeCardDeclined)
+	ErrExpiredCard           error = codeError(ErrorCodeExpiredCard)
+	ErrIdempotencyKeyInUse   error = codeError(ErrorCodeIdempotencyKeyInUse)
+	ErrIncorrectCVC          error = codeError(ErrorCodeIncorrectCVC)
+	ErrInsufficientFunds     error = codeError(ErrorCodeInsufficientFunds)
+	ErrLockTimeout           error = codeError(ErrorCodeLockTimeout)
+	ErrParameterMissing      error = codeError(ErrorCodeParameterMissing)
+	ErrRateLimit             error = codeError(ErrorCodeRateLimit)
+	ErrResourceAlreadyExists error = codeError(ErrorCodeResourceAlreadyExists)
+	ErrResourceMissing       error = codeError(ErrorCodeResourceMissing)
+)
+
 // Error is the response returned when a call is unsuccessful.
 // For more details see https://stripe.com/docs/api#errors.
+//
+// Err holds a typed error depending on the type of the error and its HTTP
+// status, which can be retrieved with errors.As, like a *CardError or a
+// *RateLimitError.
 type Error struct {
 	APIResource
 
@@ -290,6 +317,13 @@ func (e *Error) Error() string {
 	return string(ret)
 }
 
+// Is returns whether the error matches one of the sentinel errors for
+// common error codes, like ErrResourceMissing.
+func (e *Error) Is(target error) bool {
+	code, ok := target.(codeError)
+	return ok && e.Code == ErrorCode(code)
+}
+
 // Unwrap returns the wrapped typed error.
 func (e *Error) Unwrap() error {
 	return e.Err
@@ -343,6 +377,90 @@ func (e *IdempotencyError) Error() string {
 	return e.stripeErr.Error()
 }
 
+// AuthenticationError occurs when a request isn't authenticated, usually
+// because its API key is invalid, expired or revoked. It wraps the error
+// for the type of the error, like an *InvalidRequestError.
+type AuthenticationError struct {
+	stripeErr *Error
+	err       error
+}
+
+// Error serializes the error object to JSON and returns it as a string.
+func (e *AuthenticationError) Error() string {
+	return e.stripeErr.Error()
+}
+
+// Unwrap returns the error for the type of the error.
+func (e *AuthenticationError) Unwrap() error {
+	return e.err
+}
+
+// ConnectionError occurs when a request couldn't get a response from Stripe
+// because of a network failure, after retries. It wraps the cause of the
+// failure, so that errors like context.DeadlineExceeded can be matched with
+// errors.Is.
+//
+// Network failures used to be returned as is. Code asserting their type, like
+// err.(*url.Error) or err.(net.Error), needs to use errors.As instead.
+type ConnectionError struct {
+	// Err is the network failure.
+	Err error
+}
+
+// Error returns a description of the network failure.
+func (e *ConnectionError) Error() string {
+	return fmt.Sprintf("stripe: connection error: %v", e.Err)
+}
+
+// Unwrap returns the network failure.
+func (e *ConnectionError) Unwrap() error {
+	return e.Err
+}
+
+// PermissionError occurs when the API key of a request doesn't have the
+// permission to make it, like a restricted key. It wraps the error for the
+// type of the error, like an *InvalidRequestError.
+type PermissionError struct {
+	stripeErr *Error
+	err       error
+}
+
+// Error serializes the error object to JSON and returns it as a string.
+func (e *PermissionError) Error() string {
+	return e.stripeErr.Error()
+}
+
+// Unwrap returns the error for the type of the error.
+func (e *PermissionError) Unwrap() error {
+	return e.err
+}
+
+// RateLimitError occurs when too many requests are made too quickly. Lock
+// timeouts, which are also responded with a 429 but are retried by the
+// backend, aren't rate limiting errors. It wraps the error for the type of
+// the error, like an *InvalidRequestError.
+type RateLimitError struct {
+	stripeErr *Error
+	err       error
+}
+
+// Error serializes the error object to JSON and returns it as a string.
+func (e *RateLimitError) Error() string {
+	return e.stripeErr.Error()
+}
+
+// Unwrap returns the error for the type of the error.
+func (e *RateLimitError) Unwrap() error {
+	return e.err
+}
+
+// codeError is the type of the sentinel errors for error codes.
+type codeError ErrorCode
+
+func (c codeError) Error() string {
+	return "stripe: " + string(c)
+}
+
 // redact returns a copy of the error object with sensitive fields replaced with
 // a placeholder value.
 func (e *Error) redact() *Error {
@@ -1,10 +1,12 @@
 package stripe
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 
 	assert "github.com/stretchr/testify/require"
@@ -40,6 +42,86 @@ func TestErrorResponse(t *testing.T) {
 	assert.Equal(t, 401, stripeErr.HTTPStatusCode)
 	var invalidRequestErr *InvalidRequestError
 	assert.True(t, errors.As(err, &invalidRequestErr))
+	var authenticationErr *AuthenticationError
+	assert.True(t, errors.As(err, &authenticationErr))
+}
+
+func TestErrorResponse_TypedErrors(t *testing.T) {
+	testCases := []struct {
+		name   string
+		status int
+		code   ErrorCode
+		check  func(t *testing.T, err error)
+	}{
+		{"permission", http.StatusForbidden, "", func(t *testing.T, err error) {
+			var permissionErr *PermissionError
+			assert.True(t, errors.As(err, &permissionErr))
+		}},
+		{"rate limit", http.StatusTooManyRequests, ErrorCodeRateLimit, func(t *testing.T, err error) {
+			var rateLimitErr *RateLimitError
+			assert.True(t, errors.As(err, &rateLimitErr))
+			assert.True(t, errors.Is(err, ErrRateLimit))
+		}},
+		{"lock timeout", http.StatusTooManyRequests, ErrorCodeLockTimeout, func(t *testing.T, err error) {
+			var rateLimitErr *RateLimitError
+			assert.False(t, errors.As(err, &rateLimitErr))
+			assert.True(t, errors.Is(err, ErrLockTimeout))
+		}},
+		{"resource missing", http.StatusNotFound, ErrorCodeResourceMissing, func(t *testing.T, err error) {
+			var rateLimitErr *RateLimitError
+			assert.False(t, errors.As(err, &rateLimitErr))
+			assert.True(t, errors.Is(err, ErrResourceMissing))
+			assert.False(t, errors.Is(err, ErrRateLimit))
+		}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				fmt.Fprintln(w, `{"error":{"code":"`+string(tc.code)+`","message":"bar","type":"`+string(ErrorTypeInvalidRequest)+`"}}`)
+			}))
+			defer ts.Close()
+
+			backend := GetBackendWithConfig(APIBackend, &BackendConfig{
+				LeveledLogger:     &LeveledLogger{Level: LevelNull},
+				MaxNetworkRetries: Int64(0),
+				URL:               String(ts.URL),
+			})
+
+			err := backend.Call(http.MethodGet, "/v1/account", "sk_test_123", nil, &Account{})
+			assert.Error(t, err)
+
+			var invalidRequestErr *InvalidRequestError
+			assert.True(t, errors.As(err, &invalidRequestErr))
+			tc.check(t, err)
+		})
+	}
+}
+
+func TestErrorResponse_ConnectionError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	ts.Close()
+
+	backend := GetBackendWithConfig(APIBackend, &BackendConfig{
+		LeveledLogger:     &LeveledLogger{Level: LevelNull},
+		MaxNetworkRetries: Int64(0),
+		URL:               String(ts.URL),
+	})
+
+	err := backend.Call(http.MethodGet, "/v1/account", "sk_test_123", nil, &Account{})
+	var connectionErr *ConnectionError
+	assert.True(t, errors.As(err, &connectionErr))
+	var urlErr *url.Error
+	assert.True(t, errors.As(err, &urlErr))
+
+	// Cancellations can still be told apart.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	params := &AccountParams{}
+	params.Context = ctx
+	err = backend.Call(http.MethodGet, "/v1/account", "sk_test_123", params, &Account{})
+	assert.True(t, errors.As(err, &connectionErr))
+	assert.True(t, errors.Is(err, context.Canceled))
 }
 
 func TestErrorRedact(t *testing.T) {
@@ -6390,8 +6390,8 @@ func TestTestHelpersIssuingAuthorizationsCapturePost(t *testing.T) {
 			Fuel: &stripe.TestHelpersIssuingAuthorizationCapturePurchaseDetailsFuelParams{
 				Type:            stripe.String("diesel"),
 				Unit:            stripe.String("liter"),
-				UnitCostDecimal: stripe.Float64(3.5),
-				QuantityDecimal: stripe.Float64(10),
+				UnitCostDecimal: stripe.MustParseDecimal("3.5").Param(),
+				QuantityDecimal: stripe.NewDecimal(10, 0).Param(),
 			},
 			Lodging: &stripe.TestHelpersIssuingAuthorizationCapturePurchaseDetailsLodgingParams{
 				CheckInAt: stripe.Int64(1633651200),
@@ -6438,8 +6438,8 @@ func TestTestHelpersIssuingAuthorizationsCapturePostService(t *testing.T) {
 			Fuel: &stripe.TestHelpersIssuingAuthorizationCapturePurchaseDetailsFuelParams{
 				Type:            stripe.String("diesel"),
 				Unit:            stripe.String("liter"),
-				UnitCostDecimal: stripe.Float64(3.5),
-				QuantityDecimal: stripe.Float64(10),
+				UnitCostDecimal: stripe.MustParseDecimal("3.5").Param(),
+				QuantityDecimal: stripe.NewDecimal(10, 0).Param(),
 			},
 			Lodging: &stripe.TestHelpersIssuingAuthorizationCapturePurchaseDetailsLodgingParams{
 				CheckInAt: stripe.Int64(1633651200),
@@ -6763,8 +6763,8 @@ func TestTestHelpersIssuingTransactionsCreateForceCapturePost(t *testing.T) {
 			Fuel: &stripe.TestHelpersIssuingTransactionCreateForceCapturePurchaseDetailsFuelParams{
 				Type:            stripe.String("diesel"),
 				Unit:            stripe.String("liter"),
-				UnitCostDecimal: stripe.Float64(3.5),
-				QuantityDecimal: stripe.Float64(10),
+				UnitCostDecimal: stripe.MustParseDecimal("3.5").Param(),
+				QuantityDecimal: stripe.NewDecimal(10, 0).Param(),
 			},
 			Lodging: &stripe.TestHelpersIssuingTransactionCreateForceCapturePurchaseDetailsLodgingParams{
 				CheckInAt: stripe.Int64(1533651200),
@@ -6823,8 +6823,8 @@ func TestTestHelpersIssuingTransactionsCreateForceCapturePostService(
 			Fuel: &stripe.TestHelpersIssuingTransactionCreateForceCapturePurchaseDetailsFuelParams{
 				Type:            stripe.String("diesel"),
 				Unit:            stripe.String("liter"),
-				UnitCostDecimal: stripe.Float64(3.5),
-				QuantityDecimal: stripe.Float64(10),
+				UnitCostDecimal: stripe.MustParseDecimal("3.5").Param(),
+				QuantityDecimal: stripe.NewDecimal(10, 0).Param(),
 			},
 			Lodging: &stripe.TestHelpersIssuingTransactionCreateForceCapturePurchaseDetailsLodgingParams{
 				CheckInAt: stripe.Int64(1533651200),
@@ -6882,8 +6882,8 @@ func TestTestHelpersIssuingTransactionsCreateUnlinkedRefundPost(t *testing.T) {
 			Fuel: &stripe.TestHelpersIssuingTransactionCreateUnlinkedRefundPurchaseDetailsFuelParams{
 				Type:            stripe.String("diesel"),
 				Unit:            stripe.String("liter"),
-				UnitCostDecimal: stripe.Float64(3.5),
-				QuantityDecimal: stripe.Float64(10),
+				UnitCostDecimal: stripe.MustParseDecimal("3.5").Param(),
+				QuantityDecimal: stripe.NewDecimal(10, 0).Param(),
 			},
 			Lodging: &stripe.TestHelpersIssuingTransactionCreateUnlinkedRefundPurchaseDetailsLodgingParams{
 				CheckInAt: stripe.Int64(1533651200),
@@ -6942,8 +6942,8 @@ func TestTestHelpersIssuingTransactionsCreateUnlinkedRefundPostService(
 			Fuel: &stripe.TestHelpersIssuingTransactionCreateUnlinkedRefundPurchaseDetailsFuelParams{
 				Type:            stripe.String("diesel"),
 				Unit:            stripe.String("liter"),
-				UnitCostDecimal: stripe.Float64(3.5),
-				QuantityDecimal: stripe.Float64(10),
+				UnitCostDecimal: stripe.MustParseDecimal("3.5").Param(),
+				QuantityDecimal: stripe.NewDecimal(10, 0).Param(),
 			},
 			Lodging: &stripe.TestHelpersIssuingTransactionCreateUnlinkedRefundPurchaseDetailsLodgingParams{
 				CheckInAt: stripe.Int64(1533651200),
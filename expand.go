@@ -0,0 +1,147 @@
+package stripe
+
+//go:generate go run scripts/generate_expand_paths/main.go
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+//
+// Public constants
+//
+
+// MaxExpandDepth is the maximum number of levels that the API can expand in
+// a single path, including the `data` prefix of list responses.
+const MaxExpandDepth = 4
+
+//
+// Public types
+//
+
+// Expandable is implemented by the objects which can be fields of other
+// objects either as full objects or as unexpanded references of which only
+// the ID is populated, like Invoice.Customer. Its implementations are
+// generated along with the expand paths.
+type Expandable interface {
+	// IsExpanded returns whether the object is a full object rather than an
+	// unexpanded reference.
+	IsExpanded() bool
+
+	// loadPath returns the path retrieving the object by its ID, or an empty
+	// string if it can't be retrieved on its own.
+	loadPath() string
+}
+
+// ExpandPath is a path to a field of a response that should be expanded
+// from an ID into a full object, like `customer` or `data.subscription`.
+//
+// The fields that can be expanded on every resource are listed in variables
+// named after the resource, like InvoiceExpand.Customer, and can be combined
+// with Join and ListExpand. Plain strings can still be passed to the AddExpand
+// method of parameters.
+type ExpandPath string
+
+// Expander is implemented by all parameters structs that support expanding
+// fields in their response.
+type Expander interface {
+	AddExpand(f string)
+}
+
+// Depth returns the number of levels expanded by the path.
+func (p ExpandPath) Depth() int {
+	if p == "" {
+		return 0
+	}
+	return strings.Count(string(p), ".") + 1
+}
+
+// Join returns the path expanding next within the object expanded by p, for
+// example InvoiceExpand.Subscription.Join(SubscriptionExpand.DefaultPaymentMethod)
+// is `subscription.default_payment_method`.
+func (p ExpandPath) Join(next ExpandPath) ExpandPath {
+	return p + "." + next
+}
+
+// String returns the path as a string.
+func (p ExpandPath) String() string {
+	return string(p)
+}
+
+// Validate returns an error if the path is malformed or deeper than the API
+// allows.
+func (p ExpandPath) Validate() error {
+	if p == "" {
+		return fmt.Errorf("expand path cannot be empty")
+	}
+	for _, segment := range strings.Split(string(p), ".") {
+		if segment == "" {
+			return fmt.Errorf("expand path %q has an empty segment", p)
+		}
+	}
+	if p.Depth() > MaxExpandDepth {
+		return fmt.Errorf("expand path %q is %d levels deep, but at most %d levels can be expanded",
+			p, p.Depth(), MaxExpandDepth)
+	}
+	return nil
+}
+
+//
+// Public functions
+//
+
+// AddExpandPaths validates the given paths and adds them to the fields to
+// expand of params. Nothing is added if any of the paths is invalid.
+func AddExpandPaths(params Expander, paths ...ExpandPath) error {
+	for _, p := range paths {
+		if err := p.Validate(); err != nil {
+			return err
+		}
+	}
+	for _, p := range paths {
+		params.AddExpand(string(p))
+	}
+	return nil
+}
+
+// Load retrieves the full object when obj is an unexpanded reference, like a
+// field of an object retrieved without expanding it, and replaces obj with
+// the result. It does nothing if obj is nil or already expanded.
+//
+// The request is made with ctx, which takes precedence over the Context of
+// params. params may be nil, or set other parameters like the Expand of the
+// object or the StripeAccount it belongs to.
+//
+// Objects which can't be retrieved on their own, like the sources of balance
+// transactions, can only be expanded.
+func Load(ctx context.Context, b Backend, key string, obj Expandable, params *Params) error {
+	if v := reflect.ValueOf(obj); !v.IsValid() || v.IsNil() || obj.IsExpanded() {
+		return nil
+	}
+
+	path := obj.loadPath()
+	v, ok := obj.(LastResponseSetter)
+	if path == "" || !ok {
+		return fmt.Errorf("stripe: %T can't be retrieved on its own, expand it instead", obj)
+	}
+
+	p := &Params{}
+	if params != nil {
+		copied := *params
+		p = &copied
+	}
+	if ctx != nil {
+		p.Context = ctx
+	}
+	return b.Call(http.MethodGet, path, key, p, v)
+}
+
+// ListExpand returns the path expanding p on every object of a list
+// response, for example ListExpand(InvoiceExpand.Customer) is
+// `data.customer`.
+func ListExpand(p ExpandPath) ExpandPath {
+	return "data." + p
+}
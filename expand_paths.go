@@ -0,0 +1,781 @@
+// Code generated by scripts/generate_expand_paths; DO NOT EDIT.
+
+package stripe
+
+// ApplicationFeeExpand lists the fields of ApplicationFee that can be expanded.
+var ApplicationFeeExpand = struct {
+	Account                ExpandPath
+	Application            ExpandPath
+	BalanceTransaction     ExpandPath
+	Charge                 ExpandPath
+	OriginatingTransaction ExpandPath
+}{
+	Account:                "account",
+	Application:            "application",
+	BalanceTransaction:     "balance_transaction",
+	Charge:                 "charge",
+	OriginatingTransaction: "originating_transaction",
+}
+
+// BalanceTransactionExpand lists the fields of BalanceTransaction that can be expanded.
+var BalanceTransactionExpand = struct {
+	Source ExpandPath
+}{
+	Source: "source",
+}
+
+// BankAccountExpand lists the fields of BankAccount that can be expanded.
+var BankAccountExpand = struct {
+	Account  ExpandPath
+	Customer ExpandPath
+}{
+	Account:  "account",
+	Customer: "customer",
+}
+
+// BillingCreditBalanceSummaryExpand lists the fields of BillingCreditBalanceSummary that can be expanded.
+var BillingCreditBalanceSummaryExpand = struct {
+	Customer ExpandPath
+}{
+	Customer: "customer",
+}
+
+// BillingCreditBalanceTransactionExpand lists the fields of BillingCreditBalanceTransaction that can be expanded.
+var BillingCreditBalanceTransactionExpand = struct {
+	CreditGrant ExpandPath
+	TestClock   ExpandPath
+}{
+	CreditGrant: "credit_grant",
+	TestClock:   "test_clock",
+}
+
+// BillingCreditGrantExpand lists the fields of BillingCreditGrant that can be expanded.
+var BillingCreditGrantExpand = struct {
+	Customer  ExpandPath
+	TestClock ExpandPath
+}{
+	Customer:  "customer",
+	TestClock: "test_clock",
+}
+
+// BillingPortalConfigurationExpand lists the fields of BillingPortalConfiguration that can be expanded.
+var BillingPortalConfigurationExpand = struct {
+	Application ExpandPath
+}{
+	Application: "application",
+}
+
+// BillingPortalSessionExpand lists the fields of BillingPortalSession that can be expanded.
+var BillingPortalSessionExpand = struct {
+	Configuration ExpandPath
+}{
+	Configuration: "configuration",
+}
+
+// CapabilityExpand lists the fields of Capability that can be expanded.
+var CapabilityExpand = struct {
+	Account ExpandPath
+}{
+	Account: "account",
+}
+
+// CardExpand lists the fields of Card that can be expanded.
+var CardExpand = struct {
+	Account  ExpandPath
+	Customer ExpandPath
+}{
+	Account:  "account",
+	Customer: "customer",
+}
+
+// ChargeExpand lists the fields of Charge that can be expanded.
+var ChargeExpand = struct {
+	Application               ExpandPath
+	ApplicationFee            ExpandPath
+	BalanceTransaction        ExpandPath
+	Customer                  ExpandPath
+	FailureBalanceTransaction ExpandPath
+	Invoice                   ExpandPath
+	OnBehalfOf                ExpandPath
+	PaymentIntent             ExpandPath
+	Review                    ExpandPath
+	Source                    ExpandPath
+	SourceTransfer            ExpandPath
+	Transfer                  ExpandPath
+}{
+	Application:               "application",
+	ApplicationFee:            "application_fee",
+	BalanceTransaction:        "balance_transaction",
+	Customer:                  "customer",
+	FailureBalanceTransaction: "failure_balance_transaction",
+	Invoice:                   "invoice",
+	OnBehalfOf:                "on_behalf_of",
+	PaymentIntent:             "payment_intent",
+	Review:                    "review",
+	Source:                    "source",
+	SourceTransfer:            "source_transfer",
+	Transfer:                  "transfer",
+}
+
+// CheckoutSessionExpand lists the fields of CheckoutSession that can be expanded.
+var CheckoutSessionExpand = struct {
+	Customer      ExpandPath
+	Invoice       ExpandPath
+	PaymentIntent ExpandPath
+	PaymentLink   ExpandPath
+	SetupIntent   ExpandPath
+	Subscription  ExpandPath
+}{
+	Customer:      "customer",
+	Invoice:       "invoice",
+	PaymentIntent: "payment_intent",
+	PaymentLink:   "payment_link",
+	SetupIntent:   "setup_intent",
+	Subscription:  "subscription",
+}
+
+// ClimateOrderExpand lists the fields of ClimateOrder that can be expanded.
+var ClimateOrderExpand = struct {
+	Product ExpandPath
+}{
+	Product: "product",
+}
+
+// CreditNoteExpand lists the fields of CreditNote that can be expanded.
+var CreditNoteExpand = struct {
+	Customer                   ExpandPath
+	CustomerBalanceTransaction ExpandPath
+	Invoice                    ExpandPath
+	Refund                     ExpandPath
+}{
+	Customer:                   "customer",
+	CustomerBalanceTransaction: "customer_balance_transaction",
+	Invoice:                    "invoice",
+	Refund:                     "refund",
+}
+
+// CustomerExpand lists the fields of Customer that can be expanded.
+var CustomerExpand = struct {
+	DefaultSource ExpandPath
+	Discount      ExpandPath
+	TestClock     ExpandPath
+}{
+	DefaultSource: "default_source",
+	Discount:      "discount",
+	TestClock:     "test_clock",
+}
+
+// CustomerBalanceTransactionExpand lists the fields of CustomerBalanceTransaction that can be expanded.
+var CustomerBalanceTransactionExpand = struct {
+	CreditNote ExpandPath
+	Customer   ExpandPath
+	Invoice    ExpandPath
+}{
+	CreditNote: "credit_note",
+	Customer:   "customer",
+	Invoice:    "invoice",
+}
+
+// CustomerCashBalanceTransactionExpand lists the fields of CustomerCashBalanceTransaction that can be expanded.
+var CustomerCashBalanceTransactionExpand = struct {
+	Customer ExpandPath
+}{
+	Customer: "customer",
+}
+
+// CustomerSessionExpand lists the fields of CustomerSession that can be expanded.
+var CustomerSessionExpand = struct {
+	Customer ExpandPath
+}{
+	Customer: "customer",
+}
+
+// DisputeExpand lists the fields of Dispute that can be expanded.
+var DisputeExpand = struct {
+	BalanceTransactions ExpandPath
+	Charge              ExpandPath
+	PaymentIntent       ExpandPath
+}{
+	BalanceTransactions: "balance_transactions",
+	Charge:              "charge",
+	PaymentIntent:       "payment_intent",
+}
+
+// EntitlementsActiveEntitlementExpand lists the fields of EntitlementsActiveEntitlement that can be expanded.
+var EntitlementsActiveEntitlementExpand = struct {
+	Feature ExpandPath
+}{
+	Feature: "feature",
+}
+
+// ErrorExpand lists the fields of Error that can be expanded.
+var ErrorExpand = struct {
+	PaymentIntent ExpandPath
+	PaymentMethod ExpandPath
+	SetupIntent   ExpandPath
+	Source        ExpandPath
+}{
+	PaymentIntent: "payment_intent",
+	PaymentMethod: "payment_method",
+	SetupIntent:   "setup_intent",
+	Source:        "source",
+}
+
+// FeeRefundExpand lists the fields of FeeRefund that can be expanded.
+var FeeRefundExpand = struct {
+	BalanceTransaction ExpandPath
+	Fee                ExpandPath
+}{
+	BalanceTransaction: "balance_transaction",
+	Fee:                "fee",
+}
+
+// FileLinkExpand lists the fields of FileLink that can be expanded.
+var FileLinkExpand = struct {
+	File ExpandPath
+}{
+	File: "file",
+}
+
+// FinancialConnectionsAccountExpand lists the fields of FinancialConnectionsAccount that can be expanded.
+var FinancialConnectionsAccountExpand = struct {
+	Ownership ExpandPath
+}{
+	Ownership: "ownership",
+}
+
+// IdentityVerificationSessionExpand lists the fields of IdentityVerificationSession that can be expanded.
+var IdentityVerificationSessionExpand = struct {
+	LastVerificationReport ExpandPath
+}{
+	LastVerificationReport: "last_verification_report",
+}
+
+// InvoiceExpand lists the fields of Invoice that can be expanded.
+var InvoiceExpand = struct {
+	AccountTaxIDs        ExpandPath
+	Application          ExpandPath
+	Charge               ExpandPath
+	Customer             ExpandPath
+	DefaultPaymentMethod ExpandPath
+	DefaultSource        ExpandPath
+	DefaultTaxRates      ExpandPath
+	Discount             ExpandPath
+	Discounts            ExpandPath
+	LatestRevision       ExpandPath
+	OnBehalfOf           ExpandPath
+	PaymentIntent        ExpandPath
+	Quote                ExpandPath
+	Subscription         ExpandPath
+	TestClock            ExpandPath
+}{
+	AccountTaxIDs:        "account_tax_ids",
+	Application:          "application",
+	Charge:               "charge",
+	Customer:             "customer",
+	DefaultPaymentMethod: "default_payment_method",
+	DefaultSource:        "default_source",
+	DefaultTaxRates:      "default_tax_rates",
+	Discount:             "discount",
+	Discounts:            "discounts",
+	LatestRevision:       "latest_revision",
+	OnBehalfOf:           "on_behalf_of",
+	PaymentIntent:        "payment_intent",
+	Quote:                "quote",
+	Subscription:         "subscription",
+	TestClock:            "test_clock",
+}
+
+// InvoiceItemExpand lists the fields of InvoiceItem that can be expanded.
+var InvoiceItemExpand = struct {
+	Customer     ExpandPath
+	Discounts    ExpandPath
+	Invoice      ExpandPath
+	Plan         ExpandPath
+	Price        ExpandPath
+	Subscription ExpandPath
+	TaxRates     ExpandPath
+	TestClock    ExpandPath
+}{
+	Customer:     "customer",
+	Discounts:    "discounts",
+	Invoice:      "invoice",
+	Plan:         "plan",
+	Price:        "price",
+	Subscription: "subscription",
+	TaxRates:     "tax_rates",
+	TestClock:    "test_clock",
+}
+
+// InvoiceLineItemExpand lists the fields of InvoiceLineItem that can be expanded.
+var InvoiceLineItemExpand = struct {
+	Discounts        ExpandPath
+	InvoiceItem      ExpandPath
+	Plan             ExpandPath
+	Price            ExpandPath
+	Subscription     ExpandPath
+	SubscriptionItem ExpandPath
+	TaxRates         ExpandPath
+}{
+	Discounts:        "discounts",
+	InvoiceItem:      "invoice_item",
+	Plan:             "plan",
+	Price:            "price",
+	Subscription:     "subscription",
+	SubscriptionItem: "subscription_item",
+	TaxRates:         "tax_rates",
+}
+
+// IssuingAuthorizationExpand lists the fields of IssuingAuthorization that can be expanded.
+var IssuingAuthorizationExpand = struct {
+	BalanceTransactions ExpandPath
+	Card                ExpandPath
+	Cardholder          ExpandPath
+	Token               ExpandPath
+	Transactions        ExpandPath
+}{
+	BalanceTransactions: "balance_transactions",
+	Card:                "card",
+	Cardholder:          "cardholder",
+	Token:               "token",
+	Transactions:        "transactions",
+}
+
+// IssuingCardExpand lists the fields of IssuingCard that can be expanded.
+var IssuingCardExpand = struct {
+	Cardholder            ExpandPath
+	PersonalizationDesign ExpandPath
+	ReplacedBy            ExpandPath
+	ReplacementFor        ExpandPath
+}{
+	Cardholder:            "cardholder",
+	PersonalizationDesign: "personalization_design",
+	ReplacedBy:            "replaced_by",
+	ReplacementFor:        "replacement_for",
+}
+
+// IssuingDisputeExpand lists the fields of IssuingDispute that can be expanded.
+var IssuingDisputeExpand = struct {
+	BalanceTransactions ExpandPath
+	Transaction         ExpandPath
+}{
+	BalanceTransactions: "balance_transactions",
+	Transaction:         "transaction",
+}
+
+// IssuingPersonalizationDesignExpand lists the fields of IssuingPersonalizationDesign that can be expanded.
+var IssuingPersonalizationDesignExpand = struct {
+	CardLogo       ExpandPath
+	PhysicalBundle ExpandPath
+}{
+	CardLogo:       "card_logo",
+	PhysicalBundle: "physical_bundle",
+}
+
+// IssuingTokenExpand lists the fields of IssuingToken that can be expanded.
+var IssuingTokenExpand = struct {
+	Card ExpandPath
+}{
+	Card: "card",
+}
+
+// IssuingTransactionExpand lists the fields of IssuingTransaction that can be expanded.
+var IssuingTransactionExpand = struct {
+	Authorization      ExpandPath
+	BalanceTransaction ExpandPath
+	Card               ExpandPath
+	Cardholder         ExpandPath
+	Dispute            ExpandPath
+	Token              ExpandPath
+}{
+	Authorization:      "authorization",
+	BalanceTransaction: "balance_transaction",
+	Card:               "card",
+	Cardholder:         "cardholder",
+	Dispute:            "dispute",
+	Token:              "token",
+}
+
+// MandateExpand lists the fields of Mandate that can be expanded.
+var MandateExpand = struct {
+	PaymentMethod ExpandPath
+}{
+	PaymentMethod: "payment_method",
+}
+
+// PaymentIntentExpand lists the fields of PaymentIntent that can be expanded.
+var PaymentIntentExpand = struct {
+	Application   ExpandPath
+	Customer      ExpandPath
+	Invoice       ExpandPath
+	LatestCharge  ExpandPath
+	OnBehalfOf    ExpandPath
+	PaymentMethod ExpandPath
+	Review        ExpandPath
+	Source        ExpandPath
+}{
+	Application:   "application",
+	Customer:      "customer",
+	Invoice:       "invoice",
+	LatestCharge:  "latest_charge",
+	OnBehalfOf:    "on_behalf_of",
+	PaymentMethod: "payment_method",
+	Review:        "review",
+	Source:        "source",
+}
+
+// PaymentLinkExpand lists the fields of PaymentLink that can be expanded.
+var PaymentLinkExpand = struct {
+	Application ExpandPath
+	OnBehalfOf  ExpandPath
+}{
+	Application: "application",
+	OnBehalfOf:  "on_behalf_of",
+}
+
+// PaymentMethodExpand lists the fields of PaymentMethod that can be expanded.
+var PaymentMethodExpand = struct {
+	Customer ExpandPath
+}{
+	Customer: "customer",
+}
+
+// PayoutExpand lists the fields of Payout that can be expanded.
+var PayoutExpand = struct {
+	ApplicationFee            ExpandPath
+	BalanceTransaction        ExpandPath
+	Destination               ExpandPath
+	FailureBalanceTransaction ExpandPath
+	OriginalPayout            ExpandPath
+	ReversedBy                ExpandPath
+}{
+	ApplicationFee:            "application_fee",
+	BalanceTransaction:        "balance_transaction",
+	Destination:               "destination",
+	FailureBalanceTransaction: "failure_balance_transaction",
+	OriginalPayout:            "original_payout",
+	ReversedBy:                "reversed_by",
+}
+
+// PlanExpand lists the fields of Plan that can be expanded.
+var PlanExpand = struct {
+	Product ExpandPath
+}{
+	Product: "product",
+}
+
+// PriceExpand lists the fields of Price that can be expanded.
+var PriceExpand = struct {
+	Product ExpandPath
+}{
+	Product: "product",
+}
+
+// ProductExpand lists the fields of Product that can be expanded.
+var ProductExpand = struct {
+	DefaultPrice ExpandPath
+	TaxCode      ExpandPath
+}{
+	DefaultPrice: "default_price",
+	TaxCode:      "tax_code",
+}
+
+// ProductFeatureExpand lists the fields of ProductFeature that can be expanded.
+var ProductFeatureExpand = struct {
+	EntitlementFeature ExpandPath
+}{
+	EntitlementFeature: "entitlement_feature",
+}
+
+// PromotionCodeExpand lists the fields of PromotionCode that can be expanded.
+var PromotionCodeExpand = struct {
+	Coupon   ExpandPath
+	Customer ExpandPath
+}{
+	Coupon:   "coupon",
+	Customer: "customer",
+}
+
+// QuoteExpand lists the fields of Quote that can be expanded.
+var QuoteExpand = struct {
+	Application          ExpandPath
+	Customer             ExpandPath
+	DefaultTaxRates      ExpandPath
+	Discounts            ExpandPath
+	Invoice              ExpandPath
+	OnBehalfOf           ExpandPath
+	Subscription         ExpandPath
+	SubscriptionSchedule ExpandPath
+	TestClock            ExpandPath
+}{
+	Application:          "application",
+	Customer:             "customer",
+	DefaultTaxRates:      "default_tax_rates",
+	Discounts:            "discounts",
+	Invoice:              "invoice",
+	OnBehalfOf:           "on_behalf_of",
+	Subscription:         "subscription",
+	SubscriptionSchedule: "subscription_schedule",
+	TestClock:            "test_clock",
+}
+
+// RadarEarlyFraudWarningExpand lists the fields of RadarEarlyFraudWarning that can be expanded.
+var RadarEarlyFraudWarningExpand = struct {
+	Charge        ExpandPath
+	PaymentIntent ExpandPath
+}{
+	Charge:        "charge",
+	PaymentIntent: "payment_intent",
+}
+
+// RefundExpand lists the fields of Refund that can be expanded.
+var RefundExpand = struct {
+	BalanceTransaction        ExpandPath
+	Charge                    ExpandPath
+	FailureBalanceTransaction ExpandPath
+	PaymentIntent             ExpandPath
+	SourceTransferReversal    ExpandPath
+	TransferReversal          ExpandPath
+}{
+	BalanceTransaction:        "balance_transaction",
+	Charge:                    "charge",
+	FailureBalanceTransaction: "failure_balance_transaction",
+	PaymentIntent:             "payment_intent",
+	SourceTransferReversal:    "source_transfer_reversal",
+	TransferReversal:          "transfer_reversal",
+}
+
+// ReportingReportRunExpand lists the fields of ReportingReportRun that can be expanded.
+var ReportingReportRunExpand = struct {
+	Result ExpandPath
+}{
+	Result: "result",
+}
+
+// ReviewExpand lists the fields of Review that can be expanded.
+var ReviewExpand = struct {
+	Charge        ExpandPath
+	PaymentIntent ExpandPath
+}{
+	Charge:        "charge",
+	PaymentIntent: "payment_intent",
+}
+
+// SetupAttemptExpand lists the fields of SetupAttempt that can be expanded.
+var SetupAttemptExpand = struct {
+	Application   ExpandPath
+	Customer      ExpandPath
+	OnBehalfOf    ExpandPath
+	PaymentMethod ExpandPath
+	SetupIntent   ExpandPath
+}{
+	Application:   "application",
+	Customer:      "customer",
+	OnBehalfOf:    "on_behalf_of",
+	PaymentMethod: "payment_method",
+	SetupIntent:   "setup_intent",
+}
+
+// SetupIntentExpand lists the fields of SetupIntent that can be expanded.
+var SetupIntentExpand = struct {
+	Application      ExpandPath
+	Customer         ExpandPath
+	LatestAttempt    ExpandPath
+	Mandate          ExpandPath
+	OnBehalfOf       ExpandPath
+	PaymentMethod    ExpandPath
+	SingleUseMandate ExpandPath
+}{
+	Application:      "application",
+	Customer:         "customer",
+	LatestAttempt:    "latest_attempt",
+	Mandate:          "mandate",
+	OnBehalfOf:       "on_behalf_of",
+	PaymentMethod:    "payment_method",
+	SingleUseMandate: "single_use_mandate",
+}
+
+// ShippingRateExpand lists the fields of ShippingRate that can be expanded.
+var ShippingRateExpand = struct {
+	TaxCode ExpandPath
+}{
+	TaxCode: "tax_code",
+}
+
+// SigmaScheduledQueryRunExpand lists the fields of SigmaScheduledQueryRun that can be expanded.
+var SigmaScheduledQueryRunExpand = struct {
+	File ExpandPath
+}{
+	File: "file",
+}
+
+// SubscriptionExpand lists the fields of Subscription that can be expanded.
+var SubscriptionExpand = struct {
+	Application          ExpandPath
+	Customer             ExpandPath
+	DefaultPaymentMethod ExpandPath
+	DefaultSource        ExpandPath
+	DefaultTaxRates      ExpandPath
+	Discount             ExpandPath
+	Discounts            ExpandPath
+	LatestInvoice        ExpandPath
+	OnBehalfOf           ExpandPath
+	PendingSetupIntent   ExpandPath
+	Schedule             ExpandPath
+	TestClock            ExpandPath
+}{
+	Application:          "application",
+	Customer:             "customer",
+	DefaultPaymentMethod: "default_payment_method",
+	DefaultSource:        "default_source",
+	DefaultTaxRates:      "default_tax_rates",
+	Discount:             "discount",
+	Discounts:            "discounts",
+	LatestInvoice:        "latest_invoice",
+	OnBehalfOf:           "on_behalf_of",
+	PendingSetupIntent:   "pending_setup_intent",
+	Schedule:             "schedule",
+	TestClock:            "test_clock",
+}
+
+// SubscriptionItemExpand lists the fields of SubscriptionItem that can be expanded.
+var SubscriptionItemExpand = struct {
+	Discounts ExpandPath
+	Plan      ExpandPath
+	Price     ExpandPath
+	TaxRates  ExpandPath
+}{
+	Discounts: "discounts",
+	Plan:      "plan",
+	Price:     "price",
+	TaxRates:  "tax_rates",
+}
+
+// SubscriptionScheduleExpand lists the fields of SubscriptionSchedule that can be expanded.
+var SubscriptionScheduleExpand = struct {
+	Application          ExpandPath
+	Customer             ExpandPath
+	ReleasedSubscription ExpandPath
+	Subscription         ExpandPath
+	TestClock            ExpandPath
+}{
+	Application:          "application",
+	Customer:             "customer",
+	ReleasedSubscription: "released_subscription",
+	Subscription:         "subscription",
+	TestClock:            "test_clock",
+}
+
+// TaxIDExpand lists the fields of TaxID that can be expanded.
+var TaxIDExpand = struct {
+	Customer ExpandPath
+}{
+	Customer: "customer",
+}
+
+// TerminalReaderExpand lists the fields of TerminalReader that can be expanded.
+var TerminalReaderExpand = struct {
+	Location ExpandPath
+}{
+	Location: "location",
+}
+
+// TokenExpand lists the fields of Token that can be expanded.
+var TokenExpand = struct {
+	BankAccount ExpandPath
+	Card        ExpandPath
+}{
+	BankAccount: "bank_account",
+	Card:        "card",
+}
+
+// TopupExpand lists the fields of Topup that can be expanded.
+var TopupExpand = struct {
+	BalanceTransaction ExpandPath
+	Source             ExpandPath
+}{
+	BalanceTransaction: "balance_transaction",
+	Source:             "source",
+}
+
+// TransferExpand lists the fields of Transfer that can be expanded.
+var TransferExpand = struct {
+	BalanceTransaction ExpandPath
+	Destination        ExpandPath
+	DestinationPayment ExpandPath
+	SourceTransaction  ExpandPath
+}{
+	BalanceTransaction: "balance_transaction",
+	Destination:        "destination",
+	DestinationPayment: "destination_payment",
+	SourceTransaction:  "source_transaction",
+}
+
+// TransferReversalExpand lists the fields of TransferReversal that can be expanded.
+var TransferReversalExpand = struct {
+	BalanceTransaction       ExpandPath
+	DestinationPaymentRefund ExpandPath
+	SourceRefund             ExpandPath
+	Transfer                 ExpandPath
+}{
+	BalanceTransaction:       "balance_transaction",
+	DestinationPaymentRefund: "destination_payment_refund",
+	SourceRefund:             "source_refund",
+	Transfer:                 "transfer",
+}
+
+// TreasuryCreditReversalExpand lists the fields of TreasuryCreditReversal that can be expanded.
+var TreasuryCreditReversalExpand = struct {
+	Transaction ExpandPath
+}{
+	Transaction: "transaction",
+}
+
+// TreasuryDebitReversalExpand lists the fields of TreasuryDebitReversal that can be expanded.
+var TreasuryDebitReversalExpand = struct {
+	Transaction ExpandPath
+}{
+	Transaction: "transaction",
+}
+
+// TreasuryInboundTransferExpand lists the fields of TreasuryInboundTransfer that can be expanded.
+var TreasuryInboundTransferExpand = struct {
+	Transaction ExpandPath
+}{
+	Transaction: "transaction",
+}
+
+// TreasuryOutboundPaymentExpand lists the fields of TreasuryOutboundPayment that can be expanded.
+var TreasuryOutboundPaymentExpand = struct {
+	Transaction ExpandPath
+}{
+	Transaction: "transaction",
+}
+
+// TreasuryOutboundTransferExpand lists the fields of TreasuryOutboundTransfer that can be expanded.
+var TreasuryOutboundTransferExpand = struct {
+	Transaction ExpandPath
+}{
+	Transaction: "transaction",
+}
+
+// TreasuryReceivedCreditExpand lists the fields of TreasuryReceivedCredit that can be expanded.
+var TreasuryReceivedCreditExpand = struct {
+	Transaction ExpandPath
+}{
+	Transaction: "transaction",
+}
+
+// TreasuryReceivedDebitExpand lists the fields of TreasuryReceivedDebit that can be expanded.
+var TreasuryReceivedDebitExpand = struct {
+	Transaction ExpandPath
+}{
+	Transaction: "transaction",
+}
+
+// TreasuryTransactionEntryExpand lists the fields of TreasuryTransactionEntry that can be expanded.
+var TreasuryTransactionEntryExpand = struct {
+	Transaction ExpandPath
+}{
+	Transaction: "transaction",
+}
@@ -0,0 +1,70 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+	"github.com/stripe/stripe-go/v81/form"
+)
+
+func TestExpandPathJoin(t *testing.T) {
+	p := InvoiceExpand.Subscription.Join(SubscriptionExpand.DefaultPaymentMethod)
+	assert.Equal(t, ExpandPath("subscription.default_payment_method"), p)
+	assert.Equal(t, 2, p.Depth())
+
+	assert.Equal(t, ExpandPath("data.customer"), ListExpand(InvoiceExpand.Customer))
+}
+
+func TestExpandPathValidate(t *testing.T) {
+	assert.NoError(t, ExpandPath("data.source.customer.default_source").Validate())
+	assert.Error(t, ExpandPath("").Validate())
+	assert.Error(t, ExpandPath("customer..default_source").Validate())
+	assert.Error(t, ExpandPath("data.source.customer.default_source.customer").Validate())
+}
+
+func TestAddExpandPaths(t *testing.T) {
+	params := &InvoiceListParams{}
+	err := AddExpandPaths(params, ListExpand(InvoiceExpand.Customer), ListExpand(InvoiceExpand.Subscription))
+	assert.NoError(t, err)
+
+	body := &form.Values{}
+	form.AppendTo(body, params)
+	assert.Equal(t, []string{"data.customer"}, body.Get("expand[0]"))
+	assert.Equal(t, []string{"data.subscription"}, body.Get("expand[1]"))
+
+	params = &InvoiceListParams{}
+	err = AddExpandPaths(params, InvoiceExpand.Customer, "a.b.c.d.e")
+	assert.Error(t, err)
+	assert.Nil(t, params.Expand)
+}
+
+func TestLoad(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/v1/customers/cus_123", r.URL.Path)
+		assert.Equal(t, "acct_123", r.Header.Get("Stripe-Account"))
+		fmt.Fprint(w, `{"id":"cus_123","object":"customer","email":"jenny@example.com"}`)
+	}))
+	defer ts.Close()
+	b := newTestBackend(ts.URL)
+
+	customer := &Customer{ID: "cus_123"}
+	assert.False(t, customer.IsExpanded())
+	err := Load(context.Background(), b, "sk_test_123", customer, &Params{StripeAccount: String("acct_123")})
+	assert.NoError(t, err)
+	assert.True(t, customer.IsExpanded())
+	assert.Equal(t, "jenny@example.com", customer.Email)
+	assert.NotNil(t, customer.LastResponse)
+
+	// Expanded and nil objects aren't loaded again.
+	assert.NoError(t, Load(context.Background(), b, "sk_test_123", customer, nil))
+	var nilCustomer *Customer
+	assert.NoError(t, Load(context.Background(), b, "sk_test_123", nilCustomer, nil))
+
+	err = Load(context.Background(), b, "sk_test_123", &BalanceTransactionSource{ID: "ch_123"}, nil)
+	assert.EqualError(t, err, "stripe: *stripe.BalanceTransactionSource can't be retrieved on its own, expand it instead")
+}
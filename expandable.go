@@ -0,0 +1,683 @@
+// Code generated by scripts/generate_expand_paths; DO NOT EDIT.
+
+package stripe
+
+// IsExpanded returns whether the Account holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (a *Account) IsExpanded() bool {
+	return a != nil && a.Object != ""
+}
+
+func (a *Account) loadPath() string {
+	return FormatURLPath("/v1/accounts/%s", a.ID)
+}
+
+// IsExpanded returns whether the AccountExternalAccount holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (a *AccountExternalAccount) IsExpanded() bool {
+	return a != nil && a.Type != ""
+}
+
+func (a *AccountExternalAccount) loadPath() string {
+	return ""
+}
+
+// IsExpanded returns whether the Application holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (a *Application) IsExpanded() bool {
+	return a != nil && a.Object != ""
+}
+
+func (a *Application) loadPath() string {
+	return ""
+}
+
+// IsExpanded returns whether the ApplicationFee holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (a *ApplicationFee) IsExpanded() bool {
+	return a != nil && a.Object != ""
+}
+
+func (a *ApplicationFee) loadPath() string {
+	return FormatURLPath("/v1/application_fees/%s", a.ID)
+}
+
+// IsExpanded returns whether the BalanceTransaction holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (b *BalanceTransaction) IsExpanded() bool {
+	return b != nil && b.Object != ""
+}
+
+func (b *BalanceTransaction) loadPath() string {
+	return FormatURLPath("/v1/balance_transactions/%s", b.ID)
+}
+
+// IsExpanded returns whether the BalanceTransactionSource holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (b *BalanceTransactionSource) IsExpanded() bool {
+	return b != nil && b.Type != ""
+}
+
+func (b *BalanceTransactionSource) loadPath() string {
+	return ""
+}
+
+// IsExpanded returns whether the BankAccount holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (b *BankAccount) IsExpanded() bool {
+	return b != nil && b.Object != ""
+}
+
+func (b *BankAccount) loadPath() string {
+	return ""
+}
+
+// IsExpanded returns whether the BillingCreditBalanceTransaction holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (b *BillingCreditBalanceTransaction) IsExpanded() bool {
+	return b != nil && b.Object != ""
+}
+
+func (b *BillingCreditBalanceTransaction) loadPath() string {
+	return FormatURLPath("/v1/billing/credit_balance_transactions/%s", b.ID)
+}
+
+// IsExpanded returns whether the BillingCreditGrant holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (b *BillingCreditGrant) IsExpanded() bool {
+	return b != nil && b.Object != ""
+}
+
+func (b *BillingCreditGrant) loadPath() string {
+	return FormatURLPath("/v1/billing/credit_grants/%s", b.ID)
+}
+
+// IsExpanded returns whether the BillingMeter holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (b *BillingMeter) IsExpanded() bool {
+	return b != nil && b.Object != ""
+}
+
+func (b *BillingMeter) loadPath() string {
+	return FormatURLPath("/v1/billing/meters/%s", b.ID)
+}
+
+// IsExpanded returns whether the BillingPortalConfiguration holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (b *BillingPortalConfiguration) IsExpanded() bool {
+	return b != nil && b.Object != ""
+}
+
+func (b *BillingPortalConfiguration) loadPath() string {
+	return FormatURLPath("/v1/billing_portal/configurations/%s", b.ID)
+}
+
+// IsExpanded returns whether the Card holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (c *Card) IsExpanded() bool {
+	return c != nil && c.Object != ""
+}
+
+func (c *Card) loadPath() string {
+	return ""
+}
+
+// IsExpanded returns whether the Charge holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (c *Charge) IsExpanded() bool {
+	return c != nil && c.Object != ""
+}
+
+func (c *Charge) loadPath() string {
+	return FormatURLPath("/v1/charges/%s", c.ID)
+}
+
+// IsExpanded returns whether the ClimateProduct holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (c *ClimateProduct) IsExpanded() bool {
+	return c != nil && c.Object != ""
+}
+
+func (c *ClimateProduct) loadPath() string {
+	return FormatURLPath("/v1/climate/products/%s", c.ID)
+}
+
+// IsExpanded returns whether the ConnectCollectionTransfer holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (c *ConnectCollectionTransfer) IsExpanded() bool {
+	return c != nil && c.Object != ""
+}
+
+func (c *ConnectCollectionTransfer) loadPath() string {
+	return ""
+}
+
+// IsExpanded returns whether the Coupon holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (c *Coupon) IsExpanded() bool {
+	return c != nil && c.Object != ""
+}
+
+func (c *Coupon) loadPath() string {
+	return FormatURLPath("/v1/coupons/%s", c.ID)
+}
+
+// IsExpanded returns whether the CreditNote holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (c *CreditNote) IsExpanded() bool {
+	return c != nil && c.Object != ""
+}
+
+func (c *CreditNote) loadPath() string {
+	return FormatURLPath("/v1/credit_notes/%s", c.ID)
+}
+
+// IsExpanded returns whether the Customer holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (c *Customer) IsExpanded() bool {
+	return c != nil && c.Object != ""
+}
+
+func (c *Customer) loadPath() string {
+	return FormatURLPath("/v1/customers/%s", c.ID)
+}
+
+// IsExpanded returns whether the CustomerBalanceTransaction holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (c *CustomerBalanceTransaction) IsExpanded() bool {
+	return c != nil && c.Object != ""
+}
+
+func (c *CustomerBalanceTransaction) loadPath() string {
+	return ""
+}
+
+// IsExpanded returns whether the CustomerCashBalanceTransaction holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (c *CustomerCashBalanceTransaction) IsExpanded() bool {
+	return c != nil && c.Object != ""
+}
+
+func (c *CustomerCashBalanceTransaction) loadPath() string {
+	return ""
+}
+
+// IsExpanded returns whether the Discount holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (d *Discount) IsExpanded() bool {
+	return d != nil && d.Object != ""
+}
+
+func (d *Discount) loadPath() string {
+	return ""
+}
+
+// IsExpanded returns whether the Dispute holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (d *Dispute) IsExpanded() bool {
+	return d != nil && d.Object != ""
+}
+
+func (d *Dispute) loadPath() string {
+	return FormatURLPath("/v1/disputes/%s", d.ID)
+}
+
+// IsExpanded returns whether the EntitlementsFeature holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (e *EntitlementsFeature) IsExpanded() bool {
+	return e != nil && e.Object != ""
+}
+
+func (e *EntitlementsFeature) loadPath() string {
+	return FormatURLPath("/v1/entitlements/features/%s", e.ID)
+}
+
+// IsExpanded returns whether the FeeRefund holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (f *FeeRefund) IsExpanded() bool {
+	return f != nil && f.Object != ""
+}
+
+func (f *FeeRefund) loadPath() string {
+	return ""
+}
+
+// IsExpanded returns whether the File holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (f *File) IsExpanded() bool {
+	return f != nil && f.Object != ""
+}
+
+func (f *File) loadPath() string {
+	return FormatURLPath("/v1/files/%s", f.ID)
+}
+
+// IsExpanded returns whether the FinancialConnectionsAccountOwnership holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (f *FinancialConnectionsAccountOwnership) IsExpanded() bool {
+	return f != nil && f.Object != ""
+}
+
+func (f *FinancialConnectionsAccountOwnership) loadPath() string {
+	return ""
+}
+
+// IsExpanded returns whether the IdentityVerificationReport holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (i *IdentityVerificationReport) IsExpanded() bool {
+	return i != nil && i.Object != ""
+}
+
+func (i *IdentityVerificationReport) loadPath() string {
+	return FormatURLPath("/v1/identity/verification_reports/%s", i.ID)
+}
+
+// IsExpanded returns whether the Invoice holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (i *Invoice) IsExpanded() bool {
+	return i != nil && i.Object != ""
+}
+
+func (i *Invoice) loadPath() string {
+	return FormatURLPath("/v1/invoices/%s", i.ID)
+}
+
+// IsExpanded returns whether the InvoiceItem holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (i *InvoiceItem) IsExpanded() bool {
+	return i != nil && i.Object != ""
+}
+
+func (i *InvoiceItem) loadPath() string {
+	return FormatURLPath("/v1/invoiceitems/%s", i.ID)
+}
+
+// IsExpanded returns whether the IssuingAuthorization holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (i *IssuingAuthorization) IsExpanded() bool {
+	return i != nil && i.Object != ""
+}
+
+func (i *IssuingAuthorization) loadPath() string {
+	return FormatURLPath("/v1/issuing/authorizations/%s", i.ID)
+}
+
+// IsExpanded returns whether the IssuingCard holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (i *IssuingCard) IsExpanded() bool {
+	return i != nil && i.Object != ""
+}
+
+func (i *IssuingCard) loadPath() string {
+	return FormatURLPath("/v1/issuing/cards/%s", i.ID)
+}
+
+// IsExpanded returns whether the IssuingCardholder holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (i *IssuingCardholder) IsExpanded() bool {
+	return i != nil && i.Object != ""
+}
+
+func (i *IssuingCardholder) loadPath() string {
+	return FormatURLPath("/v1/issuing/cardholders/%s", i.ID)
+}
+
+// IsExpanded returns whether the IssuingDispute holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (i *IssuingDispute) IsExpanded() bool {
+	return i != nil && i.Object != ""
+}
+
+func (i *IssuingDispute) loadPath() string {
+	return FormatURLPath("/v1/issuing/disputes/%s", i.ID)
+}
+
+// IsExpanded returns whether the IssuingPersonalizationDesign holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (i *IssuingPersonalizationDesign) IsExpanded() bool {
+	return i != nil && i.Object != ""
+}
+
+func (i *IssuingPersonalizationDesign) loadPath() string {
+	return FormatURLPath("/v1/issuing/personalization_designs/%s", i.ID)
+}
+
+// IsExpanded returns whether the IssuingPhysicalBundle holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (i *IssuingPhysicalBundle) IsExpanded() bool {
+	return i != nil && i.Object != ""
+}
+
+func (i *IssuingPhysicalBundle) loadPath() string {
+	return FormatURLPath("/v1/issuing/physical_bundles/%s", i.ID)
+}
+
+// IsExpanded returns whether the IssuingToken holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (i *IssuingToken) IsExpanded() bool {
+	return i != nil && i.Object != ""
+}
+
+func (i *IssuingToken) loadPath() string {
+	return FormatURLPath("/v1/issuing/tokens/%s", i.ID)
+}
+
+// IsExpanded returns whether the IssuingTransaction holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (i *IssuingTransaction) IsExpanded() bool {
+	return i != nil && i.Object != ""
+}
+
+func (i *IssuingTransaction) loadPath() string {
+	return FormatURLPath("/v1/issuing/transactions/%s", i.ID)
+}
+
+// IsExpanded returns whether the Mandate holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (m *Mandate) IsExpanded() bool {
+	return m != nil && m.Object != ""
+}
+
+func (m *Mandate) loadPath() string {
+	return FormatURLPath("/v1/mandates/%s", m.ID)
+}
+
+// IsExpanded returns whether the PaymentIntent holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (p *PaymentIntent) IsExpanded() bool {
+	return p != nil && p.Object != ""
+}
+
+func (p *PaymentIntent) loadPath() string {
+	return FormatURLPath("/v1/payment_intents/%s", p.ID)
+}
+
+// IsExpanded returns whether the PaymentLink holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (p *PaymentLink) IsExpanded() bool {
+	return p != nil && p.Object != ""
+}
+
+func (p *PaymentLink) loadPath() string {
+	return FormatURLPath("/v1/payment_links/%s", p.ID)
+}
+
+// IsExpanded returns whether the PaymentMethod holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (p *PaymentMethod) IsExpanded() bool {
+	return p != nil && p.Object != ""
+}
+
+func (p *PaymentMethod) loadPath() string {
+	return FormatURLPath("/v1/payment_methods/%s", p.ID)
+}
+
+// IsExpanded returns whether the PaymentSource holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (p *PaymentSource) IsExpanded() bool {
+	return p != nil && p.Type != ""
+}
+
+func (p *PaymentSource) loadPath() string {
+	return ""
+}
+
+// IsExpanded returns whether the Payout holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (p *Payout) IsExpanded() bool {
+	return p != nil && p.Object != ""
+}
+
+func (p *Payout) loadPath() string {
+	return FormatURLPath("/v1/payouts/%s", p.ID)
+}
+
+// IsExpanded returns whether the PayoutDestination holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (p *PayoutDestination) IsExpanded() bool {
+	return p != nil && p.Type != ""
+}
+
+func (p *PayoutDestination) loadPath() string {
+	return ""
+}
+
+// IsExpanded returns whether the Plan holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (p *Plan) IsExpanded() bool {
+	return p != nil && p.Object != ""
+}
+
+func (p *Plan) loadPath() string {
+	return FormatURLPath("/v1/plans/%s", p.ID)
+}
+
+// IsExpanded returns whether the Price holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (p *Price) IsExpanded() bool {
+	return p != nil && p.Object != ""
+}
+
+func (p *Price) loadPath() string {
+	return FormatURLPath("/v1/prices/%s", p.ID)
+}
+
+// IsExpanded returns whether the Product holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (p *Product) IsExpanded() bool {
+	return p != nil && p.Object != ""
+}
+
+func (p *Product) loadPath() string {
+	return FormatURLPath("/v1/products/%s", p.ID)
+}
+
+// IsExpanded returns whether the PromotionCode holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (p *PromotionCode) IsExpanded() bool {
+	return p != nil && p.Object != ""
+}
+
+func (p *PromotionCode) loadPath() string {
+	return FormatURLPath("/v1/promotion_codes/%s", p.ID)
+}
+
+// IsExpanded returns whether the Quote holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (q *Quote) IsExpanded() bool {
+	return q != nil && q.Object != ""
+}
+
+func (q *Quote) loadPath() string {
+	return FormatURLPath("/v1/quotes/%s", q.ID)
+}
+
+// IsExpanded returns whether the Refund holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (r *Refund) IsExpanded() bool {
+	return r != nil && r.Object != ""
+}
+
+func (r *Refund) loadPath() string {
+	return FormatURLPath("/v1/refunds/%s", r.ID)
+}
+
+// IsExpanded returns whether the ReserveTransaction holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (r *ReserveTransaction) IsExpanded() bool {
+	return r != nil && r.Object != ""
+}
+
+func (r *ReserveTransaction) loadPath() string {
+	return ""
+}
+
+// IsExpanded returns whether the Review holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (r *Review) IsExpanded() bool {
+	return r != nil && r.Object != ""
+}
+
+func (r *Review) loadPath() string {
+	return FormatURLPath("/v1/reviews/%s", r.ID)
+}
+
+// IsExpanded returns whether the SetupAttempt holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (s *SetupAttempt) IsExpanded() bool {
+	return s != nil && s.Object != ""
+}
+
+func (s *SetupAttempt) loadPath() string {
+	return ""
+}
+
+// IsExpanded returns whether the SetupIntent holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (s *SetupIntent) IsExpanded() bool {
+	return s != nil && s.Object != ""
+}
+
+func (s *SetupIntent) loadPath() string {
+	return FormatURLPath("/v1/setup_intents/%s", s.ID)
+}
+
+// IsExpanded returns whether the ShippingRate holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (s *ShippingRate) IsExpanded() bool {
+	return s != nil && s.Object != ""
+}
+
+func (s *ShippingRate) loadPath() string {
+	return FormatURLPath("/v1/shipping_rates/%s", s.ID)
+}
+
+// IsExpanded returns whether the Subscription holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (s *Subscription) IsExpanded() bool {
+	return s != nil && s.Object != ""
+}
+
+func (s *Subscription) loadPath() string {
+	return FormatURLPath("/v1/subscriptions/%s", s.ID)
+}
+
+// IsExpanded returns whether the SubscriptionItem holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (s *SubscriptionItem) IsExpanded() bool {
+	return s != nil && s.Object != ""
+}
+
+func (s *SubscriptionItem) loadPath() string {
+	return FormatURLPath("/v1/subscription_items/%s", s.ID)
+}
+
+// IsExpanded returns whether the SubscriptionSchedule holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (s *SubscriptionSchedule) IsExpanded() bool {
+	return s != nil && s.Object != ""
+}
+
+func (s *SubscriptionSchedule) loadPath() string {
+	return FormatURLPath("/v1/subscription_schedules/%s", s.ID)
+}
+
+// IsExpanded returns whether the TaxCode holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (t *TaxCode) IsExpanded() bool {
+	return t != nil && t.Object != ""
+}
+
+func (t *TaxCode) loadPath() string {
+	return FormatURLPath("/v1/tax_codes/%s", t.ID)
+}
+
+// IsExpanded returns whether the TaxDeductedAtSource holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (t *TaxDeductedAtSource) IsExpanded() bool {
+	return t != nil && t.Object != ""
+}
+
+func (t *TaxDeductedAtSource) loadPath() string {
+	return ""
+}
+
+// IsExpanded returns whether the TaxID holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (t *TaxID) IsExpanded() bool {
+	return t != nil && t.Object != ""
+}
+
+func (t *TaxID) loadPath() string {
+	return FormatURLPath("/v1/tax_ids/%s", t.ID)
+}
+
+// IsExpanded returns whether the TaxRate holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (t *TaxRate) IsExpanded() bool {
+	return t != nil && t.Object != ""
+}
+
+func (t *TaxRate) loadPath() string {
+	return FormatURLPath("/v1/tax_rates/%s", t.ID)
+}
+
+// IsExpanded returns whether the TerminalLocation holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (t *TerminalLocation) IsExpanded() bool {
+	return t != nil && t.Object != ""
+}
+
+func (t *TerminalLocation) loadPath() string {
+	return FormatURLPath("/v1/terminal/locations/%s", t.ID)
+}
+
+// IsExpanded returns whether the TestHelpersTestClock holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (t *TestHelpersTestClock) IsExpanded() bool {
+	return t != nil && t.Object != ""
+}
+
+func (t *TestHelpersTestClock) loadPath() string {
+	return FormatURLPath("/v1/test_helpers/test_clocks/%s", t.ID)
+}
+
+// IsExpanded returns whether the Topup holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (t *Topup) IsExpanded() bool {
+	return t != nil && t.Object != ""
+}
+
+func (t *Topup) loadPath() string {
+	return FormatURLPath("/v1/topups/%s", t.ID)
+}
+
+// IsExpanded returns whether the Transfer holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (t *Transfer) IsExpanded() bool {
+	return t != nil && t.Object != ""
+}
+
+func (t *Transfer) loadPath() string {
+	return FormatURLPath("/v1/transfers/%s", t.ID)
+}
+
+// IsExpanded returns whether the TransferReversal holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (t *TransferReversal) IsExpanded() bool {
+	return t != nil && t.Object != ""
+}
+
+func (t *TransferReversal) loadPath() string {
+	return ""
+}
+
+// IsExpanded returns whether the TreasuryTransaction holds a full object, as opposed to an
+// unexpanded reference of which only the ID is populated.
+func (t *TreasuryTransaction) IsExpanded() bool {
+	return t != nil && t.Object != ""
+}
+
+func (t *TreasuryTransaction) loadPath() string {
+	return FormatURLPath("/v1/treasury/transactions/%s", t.ID)
+}
@@ -66,13 +66,13 @@ type FileFileLinkDataParams struct {
 	// The link isn't available after this future timestamp.
 	ExpiresAt *int64 `form:"expires_at"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format. Individual keys can be unset by posting an empty value to them. All keys can be unset by posting an empty value to `metadata`.
-	Metadata map[string]string `form:"metadata"`
+	Metadata Metadata `form:"metadata"`
 }
 
 // AddMetadata adds a new key-value pair to the Metadata.
 func (p *FileFileLinkDataParams) AddMetadata(key string, value string) {
 	if p.Metadata == nil {
-		p.Metadata = make(map[string]string)
+		p.Metadata = make(Metadata)
 	}
 
 	p.Metadata[key] = value
@@ -86,7 +86,14 @@ type FileParams struct {
 	// Specifies which fields in the response should be expanded.
 	Expand []*string `form:"expand"`
 	// FileReader is a reader with the contents of the file that should be uploaded.
+	// Uploads are retried on network failures by seeking back if it implements
+	// io.Seeker, and otherwise by keeping up to 32MB of it in memory.
 	FileReader io.Reader
+	// Progress is called as the contents of the file are sent, with the number
+	// of bytes sent so far and the size of the file, or -1 if it's unknown. It
+	// starts over from zero if the upload is retried, and may be called from
+	// another goroutine.
+	Progress func(sent, total int64)
 
 	// Filename is just the name of the file without path information.
 	Filename *string
@@ -142,54 +149,85 @@ type FileList struct {
 }
 
 // GetBody gets an appropriate multipart form payload to use in a request body
-// to create a new file.
+// to create a new file. It reads the whole file into memory, unlike
+// GetMultipartBody.
 func (p *FileParams) GetBody() (*bytes.Buffer, string, error) {
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
+	body, err := p.GetMultipartBody()
+	if err != nil {
+		return nil, "", err
+	}
+
+	buffer, err := body.buffer()
+	if err != nil {
+		return nil, "", err
+	}
+
+	return buffer, body.Boundary, nil
+}
+
+// GetMultipartBody gets a multipart form payload to use in a request body to
+// create a new file, which reads the file from FileReader as it's sent.
+func (p *FileParams) GetMultipartBody() (*MultipartBody, error) {
+	// Everything but the contents of the file is small, so it's encoded right
+	// away, as what comes before and after them.
+	buffer := &bytes.Buffer{}
+	writer := multipart.NewWriter(buffer)
+	body := &MultipartBody{Boundary: writer.Boundary()}
 
 	if p.Purpose != nil {
 		err := writer.WriteField("purpose", StringValue(p.Purpose))
 		if err != nil {
-			return nil, "", err
+			return nil, err
 		}
 	}
 
 	if p.FileReader != nil && p.Filename != nil {
-		part, err := writer.CreateFormFile(
+		_, err := writer.CreateFormFile(
 			"file", filepath.Base(StringValue(p.Filename)))
 
 		if err != nil {
-			return nil, "", err
+			return nil, err
 		}
 
-		_, err = io.Copy(part, p.FileReader)
-		if err != nil {
-			return nil, "", err
-		}
+		body.source = p.FileReader
+		body.progress = p.Progress
+		body.start, body.rewindable = readerStart(p.FileReader)
+		body.total = readerSize(p.FileReader)
 	}
 
+	body.prefix = append([]byte(nil), buffer.Bytes()...)
+	buffer.Reset()
+
 	if p.FileLinkData != nil {
 		values := &form.Values{}
 		form.AppendToPrefixed(values, p.FileLinkData, []string{"file_link_data"})
 
 		params, err := url.ParseQuery(values.Encode())
 		if err != nil {
-			return nil, "", err
+			return nil, err
 		}
 		for key, values := range params {
 			err := writer.WriteField(key, values[0])
 			if err != nil {
-				return nil, "", err
+				return nil, err
 			}
 		}
 	}
 
 	err := writer.Close()
 	if err != nil {
-		return nil, "", err
+		return nil, err
+	}
+	body.suffix = buffer.Bytes()
+
+	body.Size = -1
+	if body.source == nil {
+		body.Size = int64(len(body.prefix) + len(body.suffix))
+	} else if body.total >= 0 {
+		body.Size = int64(len(body.prefix)+len(body.suffix)) + body.total
 	}
 
-	return body, writer.Boundary(), nil
+	return body, nil
 }
 
 // UnmarshalJSON handles deserialization of a File.
@@ -38,13 +38,13 @@ func (c Client) New(params *stripe.FileParams) (*stripe.File, error) {
 			"params cannot be nil, and params.Purpose and params.File must be set")
 	}
 
-	bodyBuffer, boundary, err := params.GetBody()
+	body, err := params.GetMultipartBody()
 	if err != nil {
 		return nil, err
 	}
 
 	file := &stripe.File{}
-	err = c.BUploads.CallMultipart(http.MethodPost, "/v1/files", c.Key, boundary, bodyBuffer, &params.Params, file)
+	err = stripe.CallMultipartStreaming(c.BUploads, http.MethodPost, "/v1/files", c.Key, body, &params.Params, file)
 
 	return file, err
 }
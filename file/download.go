@@ -0,0 +1,242 @@
+package file
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	stripe "github.com/stripe/stripe-go/v81"
+)
+
+//
+// Public constants
+//
+
+// DefaultDownloadMaxResumes is the number of times a download is resumed
+// after its connection was interrupted when DownloadParams.MaxResumes isn't
+// set.
+const DefaultDownloadMaxResumes = 3
+
+//
+// Public variables
+//
+
+// ErrChecksumMismatch is returned by DownloadReader.Read at the end of the
+// contents of a file when their SHA-256 checksum isn't the expected one.
+var ErrChecksumMismatch = errors.New("file: checksum of the contents doesn't match")
+
+//
+// Public types
+//
+
+// DownloadParams are the parameters of a file download.
+type DownloadParams struct {
+	// Params are used for every request of the download, so that its
+	// Context can cancel it and its StripeAccount can download the files of
+	// connected accounts.
+	stripe.Params `form:"-"`
+
+	// MaxResumes is the maximum number of times the download is resumed
+	// where it stopped after its connection was interrupted. Set to a
+	// negative value to disable.
+	//
+	// Defaults to DefaultDownloadMaxResumes.
+	MaxResumes int `form:"-"`
+
+	// Offset is the position in the contents at which the download starts,
+	// to resume a download made earlier.
+	Offset int64 `form:"-"`
+
+	// SHA256 is the expected hex-encoded SHA-256 checksum of the contents,
+	// verified once they've been read entirely. When Offset is set, it's the
+	// checksum of the contents from Offset on.
+	SHA256 string `form:"-"`
+}
+
+// DownloadReader reads the contents of a file as they're downloaded, from
+// the `/v1/files/:id/contents` endpoint of the files backend. Downloads are
+// resumed with ranged requests when their connection is interrupted.
+//
+// It must be closed once done with.
+type DownloadReader struct {
+	// LastResponse is the response of the last request of the download.
+	LastResponse *stripe.StreamingAPIResponse
+
+	// Size is the size of the contents from the offset of the download, or
+	// -1 when it isn't known.
+	Size int64
+
+	body    io.ReadCloser
+	c       Client
+	hash    hash.Hash
+	id      string
+	offset  int64
+	params  DownloadParams
+	read    int64
+	resumes int
+}
+
+// Close closes the connection of the download.
+func (r *DownloadReader) Close() error {
+	return r.body.Close()
+}
+
+// Offset returns the position in the contents of the next byte to read, which
+// can be used as DownloadParams.Offset to resume the download later.
+func (r *DownloadReader) Offset() int64 {
+	return r.offset + r.read
+}
+
+// Read implements io.Reader. It returns ErrChecksumMismatch instead of io.EOF
+// when DownloadParams.SHA256 is set and the contents don't match it.
+func (r *DownloadReader) Read(p []byte) (int, error) {
+	for {
+		n, err := r.body.Read(p)
+		r.hash.Write(p[:n])
+		r.read += int64(n)
+
+		switch {
+		case err == io.EOF && r.Size >= 0 && r.read < r.Size:
+			// The connection was closed before the end of the contents.
+			err = io.ErrUnexpectedEOF
+		case err == io.EOF:
+			if r.params.SHA256 != "" && !strings.EqualFold(r.SHA256(), r.params.SHA256) {
+				return n, ErrChecksumMismatch
+			}
+			return n, io.EOF
+		}
+
+		if err == nil || !r.canResume() {
+			return n, err
+		}
+		if resumeErr := r.resume(); resumeErr != nil {
+			return n, err
+		}
+		if n > 0 {
+			return n, nil
+		}
+	}
+}
+
+// SHA256 returns the hex-encoded SHA-256 checksum of the contents read so
+// far.
+func (r *DownloadReader) SHA256() string {
+	return hex.EncodeToString(r.hash.Sum(nil))
+}
+
+//
+// Public functions
+//
+
+// Download downloads the contents of a file. See Client.Download.
+func Download(id string, params *DownloadParams) (*DownloadReader, error) {
+	return getC().Download(id, params)
+}
+
+// Download downloads the contents of a file with the API key of the client,
+// and returns a reader streaming them without buffering them into memory.
+// Errors responded by the API are returned right away, while network
+// failures once the download started are returned by Read if resuming the
+// download fails.
+func (c Client) Download(id string, params *DownloadParams) (*DownloadReader, error) {
+	if params == nil {
+		params = &DownloadParams{}
+	}
+
+	r := &DownloadReader{
+		c:      c,
+		hash:   sha256.New(),
+		id:     id,
+		offset: params.Offset,
+		params: *params,
+	}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+//
+// Private functions
+//
+
+// canResume returns whether the download can be resumed after an error.
+func (r *DownloadReader) canResume() bool {
+	maxResumes := r.params.MaxResumes
+	if maxResumes == 0 {
+		maxResumes = DefaultDownloadMaxResumes
+	}
+	if r.resumes >= maxResumes {
+		return false
+	}
+	ctx := r.params.Context
+	return ctx == nil || ctx.Err() == nil
+}
+
+// open requests the contents of the file from the current offset.
+func (r *DownloadReader) open() error {
+	offset := r.Offset()
+
+	params := r.params.Params
+	params.Headers = http.Header{}
+	for k, v := range r.params.Headers {
+		params.Headers[k] = v
+	}
+	if offset > 0 {
+		params.Headers.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	path := stripe.FormatURLPath("/v1/files/%s/contents", r.id)
+	stream := &stripe.APIStream{}
+	if err := r.c.BUploads.CallStreaming(http.MethodGet, path, r.c.Key, &params, stream); err != nil {
+		return err
+	}
+	res := stream.LastResponse
+	r.body = res.Body
+	r.LastResponse = res
+
+	// Skip the beginning of the contents when the range wasn't honored.
+	if offset > 0 && res.StatusCode != http.StatusPartialContent {
+		if _, err := io.CopyN(ioutil.Discard, r.body, offset); err != nil {
+			r.body.Close()
+			return err
+		}
+	}
+
+	if r.Size == 0 {
+		r.Size = -1
+		if length := contentLength(res, offset); length >= 0 {
+			r.Size = length + offset - r.params.Offset
+		}
+	}
+	return nil
+}
+
+// resume closes the connection of the download and requests the rest of the
+// contents.
+func (r *DownloadReader) resume() error {
+	r.body.Close()
+	r.resumes++
+	return r.open()
+}
+
+// contentLength returns the length of the contents returned by a response
+// from offset on, or -1 when it isn't known.
+func contentLength(res *stripe.StreamingAPIResponse, offset int64) int64 {
+	var length int64 = -1
+	if s := res.Header.Get("Content-Length"); s != "" {
+		if _, err := fmt.Sscanf(s, "%d", &length); err != nil {
+			return -1
+		}
+	}
+	if length >= 0 && res.StatusCode != http.StatusPartialContent {
+		length -= offset
+	}
+	return length
+}
@@ -0,0 +1,161 @@
+package file
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+	stripe "github.com/stripe/stripe-go/v81"
+)
+
+const testContents = "The quick brown fox jumps over the lazy dog"
+
+func TestDownload(t *testing.T) {
+	server := newDownloadServer(t, 0)
+	defer server.Close()
+
+	r, err := newDownloadClient(server.URL).Download("file_123", &DownloadParams{
+		SHA256: testContentsSHA256(0),
+	})
+	assert.NoError(t, err)
+	defer r.Close()
+
+	contents, err := ioutil.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, testContents, string(contents))
+	assert.Equal(t, int64(len(testContents)), r.Size)
+	assert.Equal(t, int64(len(testContents)), r.Offset())
+}
+
+func TestDownload_Offset(t *testing.T) {
+	server := newDownloadServer(t, 0)
+	defer server.Close()
+
+	r, err := newDownloadClient(server.URL).Download("file_123", &DownloadParams{
+		Offset: 10,
+		SHA256: testContentsSHA256(10),
+	})
+	assert.NoError(t, err)
+	defer r.Close()
+
+	contents, err := ioutil.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, testContents[10:], string(contents))
+	assert.Equal(t, http.StatusPartialContent, r.LastResponse.StatusCode)
+}
+
+func TestDownload_Resume(t *testing.T) {
+	// The first response is interrupted after 16 bytes.
+	server := newDownloadServer(t, 16)
+	defer server.Close()
+
+	r, err := newDownloadClient(server.URL).Download("file_123", &DownloadParams{
+		SHA256: testContentsSHA256(0),
+	})
+	assert.NoError(t, err)
+	defer r.Close()
+
+	contents, err := ioutil.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, testContents, string(contents))
+	assert.Equal(t, 1, r.resumes)
+	assert.Equal(t, "bytes=16-", r.LastResponse.Header.Get("X-Test-Range"))
+}
+
+func TestDownload_ResumeDisabled(t *testing.T) {
+	server := newDownloadServer(t, 16)
+	defer server.Close()
+
+	r, err := newDownloadClient(server.URL).Download("file_123", &DownloadParams{
+		MaxResumes: -1,
+	})
+	assert.NoError(t, err)
+	defer r.Close()
+
+	_, err = ioutil.ReadAll(r)
+	assert.Error(t, err)
+	assert.Equal(t, int64(16), r.Offset())
+}
+
+func TestDownload_ChecksumMismatch(t *testing.T) {
+	server := newDownloadServer(t, 0)
+	defer server.Close()
+
+	r, err := newDownloadClient(server.URL).Download("file_123", &DownloadParams{
+		SHA256: testContentsSHA256(1),
+	})
+	assert.NoError(t, err)
+	defer r.Close()
+
+	_, err = ioutil.ReadAll(r)
+	assert.Equal(t, ErrChecksumMismatch, err)
+}
+
+func TestDownload_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error":{"type":"invalid_request_error","code":"resource_missing"}}`)
+	}))
+	defer server.Close()
+
+	_, err := newDownloadClient(server.URL).Download("file_123", nil)
+	assert.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "resource_missing"))
+}
+
+// newDownloadServer returns a server of the contents of a file, which honors
+// ranges and interrupts its first response after cutAt bytes when set.
+func newDownloadServer(t *testing.T, cutAt int) *httptest.Server {
+	requests := 0
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/files/file_123/contents", r.URL.Path)
+		requests++
+
+		start := 0
+		if rng := r.Header.Get("Range"); rng != "" {
+			var err error
+			start, err = strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(rng, "bytes="), "-"))
+			assert.NoError(t, err)
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, len(testContents)-1, len(testContents)))
+			w.Header().Set("X-Test-Range", rng)
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(testContents)-start))
+		if start > 0 {
+			w.WriteHeader(http.StatusPartialContent)
+		}
+
+		if requests == 1 && cutAt > 0 {
+			// Hijack the connection to close it before the end of the body.
+			w.Write([]byte(testContents[start:cutAt]))
+			w.(http.Flusher).Flush()
+			conn, _, err := w.(http.Hijacker).Hijack()
+			assert.NoError(t, err)
+			conn.Close()
+			return
+		}
+		w.Write([]byte(testContents[start:]))
+	}))
+}
+
+func newDownloadClient(url string) Client {
+	return Client{
+		BUploads: stripe.GetBackendWithConfig(stripe.UploadsBackend, &stripe.BackendConfig{
+			LeveledLogger:     &stripe.LeveledLogger{Level: stripe.LevelNull},
+			MaxNetworkRetries: stripe.Int64(0),
+			URL:               stripe.String(url),
+		}),
+		Key: "sk_test_123",
+	}
+}
+
+func testContentsSHA256(offset int) string {
+	sum := sha256.Sum256([]byte(testContents[offset:]))
+	return hex.EncodeToString(sum[:])
+}
@@ -401,6 +401,12 @@ func makeStructEncoder(t reflect.Type) *structEncoder {
 
 	for i := 0; i < t.NumField(); i++ {
 		reflectField := t.Field(i)
+
+		// Unexported fields can't be encoded, so they don't need a tag.
+		if reflectField.PkgPath != "" {
+			continue
+		}
+
 		tag := reflectField.Tag.Get(tagName)
 		if Strict && tag == "" {
 			panic(fmt.Sprintf(
@@ -365,6 +365,21 @@ func TestAppendTo_IgnoredFields(t *testing.T) {
 	assert.Equal(t, &Values{}, form)
 }
 
+func TestAppendTo_UnexportedFields(t *testing.T) {
+	type unexportedStruct struct {
+		String     string `form:"string"`
+		unexported string
+	}
+
+	form := &Values{}
+	data := &unexportedStruct{String: "foo", unexported: "bar"}
+	assert.NotPanics(t, func() {
+		AppendTo(form, data)
+	})
+	assert.Equal(t, []string{"foo"}, form.Get("string"))
+	assert.Len(t, form.values, 1)
+}
+
 func TestAppendTo_ZeroValues(t *testing.T) {
 	form := &Values{}
 	data := &testStruct{}
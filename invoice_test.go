@@ -186,3 +186,15 @@ func TestInvoice_UnmarshalJSON(t *testing.T) {
 		assert.Equal(t, "in_123", v.ID)
 	}
 }
+
+func TestInvoice_IsExpanded(t *testing.T) {
+	var invoice Invoice
+	err := json.Unmarshal([]byte(`{"id":"in_123","object":"invoice","customer":"cus_123","subscription":{"id":"sub_123","object":"subscription"}}`), &invoice)
+	assert.NoError(t, err)
+
+	assert.True(t, invoice.IsExpanded())
+	assert.Equal(t, "cus_123", invoice.Customer.ID)
+	assert.False(t, invoice.Customer.IsExpanded())
+	assert.True(t, invoice.Subscription.IsExpanded())
+	assert.False(t, invoice.Discount.IsExpanded())
+}
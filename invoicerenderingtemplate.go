@@ -76,7 +76,7 @@ type InvoiceRenderingTemplate struct {
 	// Has the value `true` if the object exists in live mode or the value `false` if the object exists in test mode.
 	Livemode bool `json:"livemode"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format.
-	Metadata map[string]string `json:"metadata"`
+	Metadata Metadata `json:"metadata"`
 	// A brief description of the template, hidden from customers
 	Nickname string `json:"nickname"`
 	// String representing the object's type. Objects of the same type share the same value.
@@ -210,7 +210,7 @@ type IssuingAuthorizationParams struct {
 	// Specifies which fields in the response should be expanded.
 	Expand []*string `form:"expand"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format. Individual keys can be unset by posting an empty value to them. All keys can be unset by posting an empty value to `metadata`.
-	Metadata map[string]string `form:"metadata"`
+	Metadata Metadata `form:"metadata"`
 }
 
 // AddExpand appends a new field to expand.
@@ -221,7 +221,7 @@ func (p *IssuingAuthorizationParams) AddExpand(f string) {
 // AddMetadata adds a new key-value pair to the Metadata.
 func (p *IssuingAuthorizationParams) AddMetadata(key string, value string) {
 	if p.Metadata == nil {
-		p.Metadata = make(map[string]string)
+		p.Metadata = make(Metadata)
 	}
 
 	p.Metadata[key] = value
@@ -236,7 +236,7 @@ type IssuingAuthorizationApproveParams struct {
 	// Specifies which fields in the response should be expanded.
 	Expand []*string `form:"expand"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format. Individual keys can be unset by posting an empty value to them. All keys can be unset by posting an empty value to `metadata`.
-	Metadata map[string]string `form:"metadata"`
+	Metadata Metadata `form:"metadata"`
 }
 
 // AddExpand appends a new field to expand.
@@ -247,7 +247,7 @@ func (p *IssuingAuthorizationApproveParams) AddExpand(f string) {
 // AddMetadata adds a new key-value pair to the Metadata.
 func (p *IssuingAuthorizationApproveParams) AddMetadata(key string, value string) {
 	if p.Metadata == nil {
-		p.Metadata = make(map[string]string)
+		p.Metadata = make(Metadata)
 	}
 
 	p.Metadata[key] = value
@@ -260,7 +260,7 @@ type IssuingAuthorizationDeclineParams struct {
 	// Specifies which fields in the response should be expanded.
 	Expand []*string `form:"expand"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format. Individual keys can be unset by posting an empty value to them. All keys can be unset by posting an empty value to `metadata`.
-	Metadata map[string]string `form:"metadata"`
+	Metadata Metadata `form:"metadata"`
 }
 
 // AddExpand appends a new field to expand.
@@ -271,7 +271,7 @@ func (p *IssuingAuthorizationDeclineParams) AddExpand(f string) {
 // AddMetadata adds a new key-value pair to the Metadata.
 func (p *IssuingAuthorizationDeclineParams) AddMetadata(key string, value string) {
 	if p.Metadata == nil {
-		p.Metadata = make(map[string]string)
+		p.Metadata = make(Metadata)
 	}
 
 	p.Metadata[key] = value
@@ -305,21 +305,21 @@ type IssuingAuthorizationFleetCardholderPromptData struct {
 // Breakdown of fuel portion of the purchase.
 type IssuingAuthorizationFleetReportedBreakdownFuel struct {
 	// Gross fuel amount that should equal Fuel Quantity multiplied by Fuel Unit Cost, inclusive of taxes.
-	GrossAmountDecimal float64 `json:"gross_amount_decimal,string"`
+	GrossAmountDecimal Decimal `json:"gross_amount_decimal"`
 }
 
 // Breakdown of non-fuel portion of the purchase.
 type IssuingAuthorizationFleetReportedBreakdownNonFuel struct {
 	// Gross non-fuel amount that should equal the sum of the line items, inclusive of taxes.
-	GrossAmountDecimal float64 `json:"gross_amount_decimal,string"`
+	GrossAmountDecimal Decimal `json:"gross_amount_decimal"`
 }
 
 // Information about tax included in this transaction.
 type IssuingAuthorizationFleetReportedBreakdownTax struct {
 	// Amount of state or provincial Sales Tax included in the transaction amount. `null` if not reported by merchant or not subject to tax.
-	LocalAmountDecimal float64 `json:"local_amount_decimal,string"`
+	LocalAmountDecimal Decimal `json:"local_amount_decimal"`
 	// Amount of national Sales Tax or VAT included in the transaction amount. `null` if not reported by merchant or not subject to tax.
-	NationalAmountDecimal float64 `json:"national_amount_decimal,string"`
+	NationalAmountDecimal Decimal `json:"national_amount_decimal"`
 }
 
 // More information about the total amount. Typically this information is received from the merchant after the authorization has been approved and the fuel dispensed. This information is not guaranteed to be accurate as some merchants may provide unreliable data.
@@ -359,13 +359,13 @@ type IssuingAuthorizationFuel struct {
 	// [Conexxus Payment System Product Code](https://www.conexxus.org/conexxus-payment-system-product-codes) identifying the primary fuel product purchased.
 	IndustryProductCode string `json:"industry_product_code"`
 	// The quantity of `unit`s of fuel that was dispensed, represented as a decimal string with at most 12 decimal places.
-	QuantityDecimal float64 `json:"quantity_decimal,string"`
+	QuantityDecimal Decimal `json:"quantity_decimal"`
 	// The type of fuel that was purchased.
 	Type IssuingAuthorizationFuelType `json:"type"`
 	// The units for `quantity_decimal`.
 	Unit IssuingAuthorizationFuelUnit `json:"unit"`
 	// The cost in cents per each unit of fuel, represented as a decimal string with at most 12 decimal places.
-	UnitCostDecimal float64 `json:"unit_cost_decimal,string"`
+	UnitCostDecimal Decimal `json:"unit_cost_decimal"`
 }
 type IssuingAuthorizationMerchantData struct {
 	// A categorization of the seller's type of business. See our [merchant categories guide](https://stripe.com/docs/issuing/merchant-categories) for a list of possible values.
@@ -529,7 +529,7 @@ type IssuingAuthorization struct {
 	MerchantCurrency Currency                          `json:"merchant_currency"`
 	MerchantData     *IssuingAuthorizationMerchantData `json:"merchant_data"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format.
-	Metadata map[string]string `json:"metadata"`
+	Metadata Metadata `json:"metadata"`
 	// Details about the authorization, such as identifiers, set by the card network.
 	NetworkData *IssuingAuthorizationNetworkData `json:"network_data"`
 	// String representing the object's type. Objects of the same type share the same value.
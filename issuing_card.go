@@ -247,7 +247,7 @@ type IssuingCardParams struct {
 	Expand           []*string `form:"expand"`
 	FinancialAccount *string   `form:"financial_account"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format. Individual keys can be unset by posting an empty value to them. All keys can be unset by posting an empty value to `metadata`.
-	Metadata map[string]string `form:"metadata"`
+	Metadata Metadata `form:"metadata"`
 	// The personalization design object belonging to this card.
 	PersonalizationDesign *string `form:"personalization_design"`
 	// The desired new PIN for this card.
@@ -279,7 +279,7 @@ func (p *IssuingCardParams) AddExpand(f string) {
 // AddMetadata adds a new key-value pair to the Metadata.
 func (p *IssuingCardParams) AddMetadata(key string, value string) {
 	if p.Metadata == nil {
-		p.Metadata = make(map[string]string)
+		p.Metadata = make(Metadata)
 	}
 
 	p.Metadata[key] = value
@@ -404,7 +404,7 @@ type IssuingCard struct {
 	// Has the value `true` if the object exists in live mode or the value `false` if the object exists in test mode.
 	Livemode bool `json:"livemode"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format.
-	Metadata map[string]string `json:"metadata"`
+	Metadata Metadata `json:"metadata"`
 	// The full unredacted card number. For security reasons, this is only available for virtual cards, and will be omitted unless you explicitly request it with [the `expand` parameter](https://stripe.com/docs/api/expanding_objects). Additionally, it's only available via the ["Retrieve a card" endpoint](https://stripe.com/docs/api/issuing/cards/retrieve), not via "List all cards" or any other endpoint.
 	Number string `json:"number"`
 	// String representing the object's type. Objects of the same type share the same value.
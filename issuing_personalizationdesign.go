@@ -104,7 +104,7 @@ type IssuingPersonalizationDesignParams struct {
 	// A lookup key used to retrieve personalization designs dynamically from a static string. This may be up to 200 characters.
 	LookupKey *string `form:"lookup_key"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format. Individual keys can be unset by posting an empty value to them. All keys can be unset by posting an empty value to `metadata`.
-	Metadata map[string]string `form:"metadata"`
+	Metadata Metadata `form:"metadata"`
 	// Friendly display name. Providing an empty string will set the field to null.
 	Name *string `form:"name"`
 	// The physical bundle object belonging to this personalization design.
@@ -123,7 +123,7 @@ func (p *IssuingPersonalizationDesignParams) AddExpand(f string) {
 // AddMetadata adds a new key-value pair to the Metadata.
 func (p *IssuingPersonalizationDesignParams) AddMetadata(key string, value string) {
 	if p.Metadata == nil {
-		p.Metadata = make(map[string]string)
+		p.Metadata = make(Metadata)
 	}
 
 	p.Metadata[key] = value
@@ -169,7 +169,7 @@ type IssuingPersonalizationDesign struct {
 	// A lookup key used to retrieve personalization designs dynamically from a static string. This may be up to 200 characters.
 	LookupKey string `json:"lookup_key"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format.
-	Metadata map[string]string `json:"metadata"`
+	Metadata Metadata `json:"metadata"`
 	// Friendly display name.
 	Name string `json:"name"`
 	// String representing the object's type. Objects of the same type share the same value.
@@ -82,7 +82,7 @@ type IssuingTransactionParams struct {
 	// Specifies which fields in the response should be expanded.
 	Expand []*string `form:"expand"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format. Individual keys can be unset by posting an empty value to them. All keys can be unset by posting an empty value to `metadata`.
-	Metadata map[string]string `form:"metadata"`
+	Metadata Metadata `form:"metadata"`
 }
 
 // AddExpand appends a new field to expand.
@@ -93,7 +93,7 @@ func (p *IssuingTransactionParams) AddExpand(f string) {
 // AddMetadata adds a new key-value pair to the Metadata.
 func (p *IssuingTransactionParams) AddMetadata(key string, value string) {
 	if p.Metadata == nil {
-		p.Metadata = make(map[string]string)
+		p.Metadata = make(Metadata)
 	}
 
 	p.Metadata[key] = value
@@ -134,21 +134,21 @@ type IssuingTransactionPurchaseDetailsFleetCardholderPromptData struct {
 // Breakdown of fuel portion of the purchase.
 type IssuingTransactionPurchaseDetailsFleetReportedBreakdownFuel struct {
 	// Gross fuel amount that should equal Fuel Volume multipled by Fuel Unit Cost, inclusive of taxes.
-	GrossAmountDecimal float64 `json:"gross_amount_decimal,string"`
+	GrossAmountDecimal Decimal `json:"gross_amount_decimal"`
 }
 
 // Breakdown of non-fuel portion of the purchase.
 type IssuingTransactionPurchaseDetailsFleetReportedBreakdownNonFuel struct {
 	// Gross non-fuel amount that should equal the sum of the line items, inclusive of taxes.
-	GrossAmountDecimal float64 `json:"gross_amount_decimal,string"`
+	GrossAmountDecimal Decimal `json:"gross_amount_decimal"`
 }
 
 // Information about tax included in this transaction.
 type IssuingTransactionPurchaseDetailsFleetReportedBreakdownTax struct {
 	// Amount of state or provincial Sales Tax included in the transaction amount. Null if not reported by merchant or not subject to tax.
-	LocalAmountDecimal float64 `json:"local_amount_decimal,string"`
+	LocalAmountDecimal Decimal `json:"local_amount_decimal"`
 	// Amount of national Sales Tax or VAT included in the transaction amount. Null if not reported by merchant or not subject to tax.
-	NationalAmountDecimal float64 `json:"national_amount_decimal,string"`
+	NationalAmountDecimal Decimal `json:"national_amount_decimal"`
 }
 
 // More information about the total amount. This information is not guaranteed to be accurate as some merchants may provide unreliable data.
@@ -208,13 +208,13 @@ type IssuingTransactionPurchaseDetailsFuel struct {
 	// [Conexxus Payment System Product Code](https://www.conexxus.org/conexxus-payment-system-product-codes) identifying the primary fuel product purchased.
 	IndustryProductCode string `json:"industry_product_code"`
 	// The quantity of `unit`s of fuel that was dispensed, represented as a decimal string with at most 12 decimal places.
-	QuantityDecimal float64 `json:"quantity_decimal,string"`
+	QuantityDecimal Decimal `json:"quantity_decimal"`
 	// The type of fuel that was purchased. One of `diesel`, `unleaded_plus`, `unleaded_regular`, `unleaded_super`, or `other`.
 	Type IssuingTransactionPurchaseDetailsFuelType `json:"type"`
 	// The units for `quantity_decimal`. One of `charging_minute`, `imperial_gallon`, `kilogram`, `kilowatt_hour`, `liter`, `pound`, `us_gallon`, or `other`.
 	Unit IssuingTransactionPurchaseDetailsFuelUnit `json:"unit"`
 	// The cost in cents per each unit of fuel, represented as a decimal string with at most 12 decimal places.
-	UnitCostDecimal float64 `json:"unit_cost_decimal,string"`
+	UnitCostDecimal Decimal `json:"unit_cost_decimal"`
 }
 
 // Information about lodging that was purchased with this transaction.
@@ -296,7 +296,7 @@ type IssuingTransaction struct {
 	MerchantCurrency Currency                          `json:"merchant_currency"`
 	MerchantData     *IssuingAuthorizationMerchantData `json:"merchant_data"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format.
-	Metadata map[string]string `json:"metadata"`
+	Metadata Metadata `json:"metadata"`
 	// Details about the transaction, such as processing dates, set by the card network.
 	NetworkData *IssuingTransactionNetworkData `json:"network_data"`
 	// String representing the object's type. Objects of the same type share the same value.
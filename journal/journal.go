@@ -0,0 +1,290 @@
+// Package journal converts balance transactions into double-entry journal
+// entries for accounting systems, and exports them as CSV or JSON.
+//
+// Every balance transaction becomes a balanced entry: its net is debited to
+// the account of the Stripe balance, its fees to fee accounts, and its amount
+// is credited to the account of its reporting category, like revenue for
+// charges or a bank account for payouts. Negative amounts swap debits and
+// credits.
+//
+// Exports can be made incrementally: the ID returned by an export is the one
+// of the newest balance transaction written, and setting it as EndingBefore
+// of the next export's params exports only the balance transactions created
+// since, from the oldest to the newest.
+//
+//	params := &stripe.BalanceTransactionListParams{}
+//	params.EndingBefore = stripe.String(lastID)
+//	lastID, err := journal.Export(params, chart, journal.NewCSVWriter(w))
+package journal
+
+import (
+	"fmt"
+
+	stripe "github.com/stripe/stripe-go/v81"
+	"github.com/stripe/stripe-go/v81/balancetransaction"
+)
+
+//
+// Public constants
+//
+
+// Accounts used when they aren't set in a ChartOfAccounts.
+const (
+	DefaultBalanceAccount       = "stripe_balance"
+	DefaultFeeAccount           = "stripe_fees"
+	DefaultUncategorizedAccount = "uncategorized"
+)
+
+//
+// Public types
+//
+
+// ChartOfAccounts maps balance transactions to the accounts of an accounting
+// system.
+type ChartOfAccounts struct {
+	// Balance is the account of the Stripe balance.
+	//
+	// Defaults to DefaultBalanceAccount.
+	Balance string
+
+	// Categories are the accounts of the counterparts of the amounts of
+	// balance transactions per reporting category, like a revenue account for
+	// `charge`, or a bank account for `payout`.
+	Categories map[stripe.BalanceTransactionReportingCategory]string
+
+	// Fee is the account of fees without details, and of fees whose type
+	// isn't in Fees.
+	//
+	// Defaults to DefaultFeeAccount.
+	Fee string
+
+	// Fees are the accounts of fees per type of fee detail, like
+	// `stripe_fee`, `application_fee` or `tax`.
+	Fees map[string]string
+
+	// Uncategorized is the account of the counterparts of the amounts of
+	// balance transactions whose reporting category isn't in Categories.
+	//
+	// Defaults to DefaultUncategorizedAccount.
+	Uncategorized string
+}
+
+// Client is used to export journal entries.
+type Client struct {
+	B   stripe.Backend
+	Key string
+}
+
+// Entry is the journal entry of a balance transaction.
+type Entry struct {
+	// BalanceTransaction is the balance transaction of the entry.
+	BalanceTransaction *stripe.BalanceTransaction
+
+	// Lines are the lines of the entry, whose debits and credits are equal
+	// when it's balanced.
+	Lines []*Line
+}
+
+// Line is a line of a journal entry, debiting or crediting an account.
+// Amounts are positive, in the smallest unit of the currency.
+type Line struct {
+	Account            string                                     `json:"account"`
+	BalanceTransaction string                                     `json:"balance_transaction"`
+	Created            int64                                      `json:"created"`
+	Credit             int64                                      `json:"credit"`
+	Currency           stripe.Currency                            `json:"currency"`
+	Debit              int64                                      `json:"debit"`
+	Description        string                                     `json:"description"`
+	ExchangeRate       float64                                    `json:"exchange_rate,omitempty"`
+	ReportingCategory  stripe.BalanceTransactionReportingCategory `json:"reporting_category"`
+	Source             string                                     `json:"source"`
+}
+
+// UnbalancedEntryError is the error returned when the journal entry of a
+// balance transaction isn't balanced, because the amounts of the balance
+// transaction don't add up.
+type UnbalancedEntryError struct {
+	// Entry is the entry which isn't balanced.
+	Entry *Entry
+}
+
+// Writer writes journal entries in an output format.
+type Writer interface {
+	// Flush writes any buffered data.
+	Flush() error
+
+	// WriteEntry writes the lines of an entry.
+	WriteEntry(entry *Entry) error
+}
+
+// IsBalanced returns whether the debits of the entry equal its credits.
+func (e *Entry) IsBalanced() bool {
+	var debits, credits int64
+	for _, line := range e.Lines {
+		debits += line.Debit
+		credits += line.Credit
+	}
+	return debits == credits
+}
+
+// Error names the balance transaction of the entry.
+func (e *UnbalancedEntryError) Error() string {
+	return fmt.Sprintf("journal: entry of balance transaction %s isn't balanced", e.Entry.BalanceTransaction.ID)
+}
+
+//
+// Public functions
+//
+
+// Export writes the journal entries of balance transactions to w. See
+// Client.Export.
+func Export(params *stripe.BalanceTransactionListParams, chart *ChartOfAccounts, w Writer) (string, error) {
+	return getC().Export(params, chart, w)
+}
+
+// Export lists balance transactions with params, writes their journal entries
+// to w, and flushes w. It returns the ID of the newest balance transaction
+// written, to set as EndingBefore of the next export, or EndingBefore itself
+// if there were none.
+//
+// Balance transactions are written from the oldest to the newest when
+// EndingBefore is set, and from the newest to the oldest otherwise, like for
+// a first export.
+//
+// Exports stop at the first error. When EndingBefore is set, the ID returned
+// with the error is the one of the last balance transaction written, so that
+// the export can be resumed from it. Otherwise the export can't be resumed,
+// and an empty string is returned.
+//
+// An entry which isn't balanced stops the export with an
+// *UnbalancedEntryError, before it's written. Resuming the export would stop
+// at it again, so it has to be recorded by other means, after which the
+// export is resumed with the ID of its balance transaction.
+func (c Client) Export(params *stripe.BalanceTransactionListParams, chart *ChartOfAccounts, w Writer) (string, error) {
+	if params == nil {
+		params = &stripe.BalanceTransactionListParams{}
+	}
+	oldestFirst := params.EndingBefore != nil
+
+	// The cursor stays where it was until something is written.
+	var err error
+	newestID := stripe.StringValue(params.EndingBefore)
+	lastID := newestID
+	i := balancetransaction.Client{B: c.B, Key: c.Key}.List(params)
+	for i.Next() {
+		bt := i.BalanceTransaction()
+		entry := NewEntry(bt, chart)
+		if !entry.IsBalanced() {
+			err = &UnbalancedEntryError{Entry: entry}
+			break
+		}
+		if err = w.WriteEntry(entry); err != nil {
+			break
+		}
+		lastID = bt.ID
+		if oldestFirst || newestID == "" {
+			newestID = bt.ID
+		}
+	}
+	if err == nil {
+		err = i.Err()
+	}
+
+	// What was written is flushed even when the export fails, so that it
+	// can be resumed.
+	if flushErr := w.Flush(); err == nil {
+		err = flushErr
+	}
+	if err != nil {
+		if oldestFirst {
+			return lastID, err
+		}
+		return "", err
+	}
+	return newestID, nil
+}
+
+// NewEntry returns the journal entry of a balance transaction, with the
+// accounts of a chart of accounts, which may be nil to use the defaults.
+func NewEntry(bt *stripe.BalanceTransaction, chart *ChartOfAccounts) *Entry {
+	if chart == nil {
+		chart = &ChartOfAccounts{}
+	}
+
+	entry := &Entry{BalanceTransaction: bt}
+	entry.addLine(chart.balance(), bt.Net)
+
+	// Fees which aren't detailed go to the default fee account.
+	fees := bt.Fee
+	for _, detail := range bt.FeeDetails {
+		entry.addLine(chart.fee(detail.Type), detail.Amount)
+		fees -= detail.Amount
+	}
+	entry.addLine(chart.fee(""), fees)
+
+	entry.addLine(chart.category(bt.ReportingCategory), -bt.Amount)
+	return entry
+}
+
+//
+// Private functions
+//
+
+func getC() Client {
+	return Client{stripe.GetBackend(stripe.APIBackend), stripe.Key}
+}
+
+// addLine adds a line debiting an account of a positive amount, or crediting
+// it of a negative one.
+func (e *Entry) addLine(account string, amount int64) {
+	if amount == 0 {
+		return
+	}
+
+	bt := e.BalanceTransaction
+	line := &Line{
+		Account:            account,
+		BalanceTransaction: bt.ID,
+		Created:            bt.Created,
+		Currency:           bt.Currency,
+		Description:        bt.Description,
+		ExchangeRate:       bt.ExchangeRate,
+		ReportingCategory:  bt.ReportingCategory,
+	}
+	if bt.Source != nil {
+		line.Source = bt.Source.ID
+	}
+	if amount > 0 {
+		line.Debit = amount
+	} else {
+		line.Credit = -amount
+	}
+	e.Lines = append(e.Lines, line)
+}
+
+func (c *ChartOfAccounts) balance() string {
+	if c.Balance != "" {
+		return c.Balance
+	}
+	return DefaultBalanceAccount
+}
+
+func (c *ChartOfAccounts) category(category stripe.BalanceTransactionReportingCategory) string {
+	if account, ok := c.Categories[category]; ok {
+		return account
+	}
+	if c.Uncategorized != "" {
+		return c.Uncategorized
+	}
+	return DefaultUncategorizedAccount
+}
+
+func (c *ChartOfAccounts) fee(feeType string) string {
+	if account, ok := c.Fees[feeType]; ok && feeType != "" {
+		return account
+	}
+	if c.Fee != "" {
+		return c.Fee
+	}
+	return DefaultFeeAccount
+}
@@ -0,0 +1,187 @@
+package journal_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+	stripe "github.com/stripe/stripe-go/v81"
+	"github.com/stripe/stripe-go/v81/journal"
+	"github.com/stripe/stripe-go/v81/stripetest"
+)
+
+var testChart = &journal.ChartOfAccounts{
+	Balance: "1010 Stripe",
+	Categories: map[stripe.BalanceTransactionReportingCategory]string{
+		stripe.BalanceTransactionReportingCategoryCharge: "4000 Sales",
+		stripe.BalanceTransactionReportingCategoryRefund: "4010 Refunds",
+	},
+	Fees: map[string]string{
+		"stripe_fee": "6010 Processing fees",
+	},
+}
+
+func TestNewEntry(t *testing.T) {
+	bt := &stripe.BalanceTransaction{
+		Amount:   10000,
+		Created:  1704164645,
+		Currency: stripe.CurrencyUSD,
+		Fee:      350,
+		FeeDetails: []*stripe.BalanceTransactionFeeDetail{
+			{Amount: 320, Type: "stripe_fee"},
+			{Amount: 20, Type: "tax"},
+		},
+		ID:                "txn_123",
+		Net:               9650,
+		ReportingCategory: stripe.BalanceTransactionReportingCategoryCharge,
+		Source:            &stripe.BalanceTransactionSource{ID: "ch_123"},
+	}
+
+	entry := journal.NewEntry(bt, testChart)
+	assert.True(t, entry.IsBalanced())
+
+	type line struct {
+		account       string
+		debit, credit int64
+	}
+	var lines []line
+	for _, l := range entry.Lines {
+		assert.Equal(t, "txn_123", l.BalanceTransaction)
+		assert.Equal(t, "ch_123", l.Source)
+		lines = append(lines, line{l.Account, l.Debit, l.Credit})
+	}
+	assert.Equal(t, []line{
+		{"1010 Stripe", 9650, 0},
+		{"6010 Processing fees", 320, 0},
+		{journal.DefaultFeeAccount, 20, 0},
+		{journal.DefaultFeeAccount, 10, 0},
+		{"4000 Sales", 0, 10000},
+	}, lines)
+}
+
+func TestNewEntryNegative(t *testing.T) {
+	entry := journal.NewEntry(&stripe.BalanceTransaction{
+		Amount:            -2500,
+		ID:                "txn_123",
+		Net:               -2500,
+		ReportingCategory: stripe.BalanceTransactionReportingCategoryPayout,
+	}, nil)
+	assert.True(t, entry.IsBalanced())
+	assert.Equal(t, 2, len(entry.Lines))
+	assert.Equal(t, journal.DefaultBalanceAccount, entry.Lines[0].Account)
+	assert.Equal(t, int64(2500), entry.Lines[0].Credit)
+	assert.Equal(t, journal.DefaultUncategorizedAccount, entry.Lines[1].Account)
+	assert.Equal(t, int64(2500), entry.Lines[1].Debit)
+
+	entry = journal.NewEntry(&stripe.BalanceTransaction{Amount: 1000, Fee: 30, Net: 900}, nil)
+	assert.False(t, entry.IsBalanced())
+}
+
+func TestExport(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/balance_transactions", r.URL.Path)
+		assert.Equal(t, "txn_0", r.URL.Query().Get("ending_before"))
+		fmt.Fprint(w, `{"object":"list","has_more":false,"data":[
+			{"id":"txn_2","created":1704164645,"currency":"usd","amount":-1000,"net":-1000,"reporting_category":"refund","source":"re_123"},
+			{"id":"txn_1","created":1704164645,"currency":"eur","amount":1000,"fee":59,"net":941,"exchange_rate":1.1,"reporting_category":"charge","description":"Order, 1","source":"ch_123"}
+		]}`)
+	}))
+	defer ts.Close()
+
+	c := journal.Client{B: stripetest.NewBackend(stripe.APIBackend, ts.URL), Key: "sk_test_123"}
+	params := &stripe.BalanceTransactionListParams{}
+	params.EndingBefore = stripe.String("txn_0")
+
+	var out bytes.Buffer
+	lastID, err := c.Export(params, testChart, journal.NewCSVWriter(&out))
+	assert.NoError(t, err)
+	assert.Equal(t, "txn_2", lastID)
+	assert.Equal(t, strings.Join([]string{
+		"balance_transaction,created,account,debit,credit,currency,reporting_category,source,description,exchange_rate",
+		`txn_1,2024-01-02T03:04:05Z,1010 Stripe,941,0,eur,charge,ch_123,"Order, 1",1.1`,
+		`txn_1,2024-01-02T03:04:05Z,stripe_fees,59,0,eur,charge,ch_123,"Order, 1",1.1`,
+		`txn_1,2024-01-02T03:04:05Z,4000 Sales,0,1000,eur,charge,ch_123,"Order, 1",1.1`,
+		"txn_2,2024-01-02T03:04:05Z,1010 Stripe,0,1000,usd,refund,re_123,,",
+		"txn_2,2024-01-02T03:04:05Z,4010 Refunds,1000,0,usd,refund,re_123,,",
+		"",
+	}, "\n"), out.String())
+
+	out.Reset()
+	params.EndingBefore = stripe.String("txn_0")
+	_, err = c.Export(params, testChart, journal.NewJSONWriter(&out))
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Equal(t, 5, len(lines))
+	assert.Equal(t, `{"account":"1010 Stripe","balance_transaction":"txn_1","created":1704164645,"credit":0,"currency":"eur","debit":941,"description":"Order, 1","exchange_rate":1.1,"reporting_category":"charge","source":"ch_123"}`, lines[0])
+}
+
+func TestExportFirst(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "", r.URL.Query().Get("ending_before"))
+		fmt.Fprint(w, `{"object":"list","has_more":false,"data":[
+			{"id":"txn_2","amount":1000,"net":1000},
+			{"id":"txn_1","amount":1000,"net":1000}
+		]}`)
+	}))
+	defer ts.Close()
+
+	// Without a cursor, balance transactions are listed newest first, and the
+	// newest is still the one returned.
+	c := journal.Client{B: stripetest.NewBackend(stripe.APIBackend, ts.URL), Key: "sk_test_123"}
+	var out bytes.Buffer
+	lastID, err := c.Export(nil, nil, journal.NewJSONWriter(&out))
+	assert.NoError(t, err)
+	assert.Equal(t, "txn_2", lastID)
+}
+
+func TestExportUnbalanced(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"object":"list","has_more":false,"data":[
+			{"id":"txn_3","amount":1000,"net":1000},
+			{"id":"txn_2","amount":1000,"fee":30,"net":900},
+			{"id":"txn_1","amount":1000,"net":1000}
+		]}`)
+	}))
+	defer ts.Close()
+
+	c := journal.Client{B: stripetest.NewBackend(stripe.APIBackend, ts.URL), Key: "sk_test_123"}
+	params := &stripe.BalanceTransactionListParams{}
+	params.EndingBefore = stripe.String("txn_0")
+	var out bytes.Buffer
+	lastID, err := c.Export(params, nil, journal.NewJSONWriter(&out))
+	assert.EqualError(t, err, "journal: entry of balance transaction txn_2 isn't balanced")
+	unbalanced, ok := err.(*journal.UnbalancedEntryError)
+	assert.True(t, ok)
+	assert.Equal(t, "txn_2", unbalanced.Entry.BalanceTransaction.ID)
+	assert.Equal(t, "txn_1", lastID)
+	assert.Equal(t, 2, strings.Count(out.String(), "\n"))
+
+	// Without a cursor, the export can't be resumed.
+	lastID, err = c.Export(nil, nil, journal.NewJSONWriter(&out))
+	assert.Error(t, err)
+	assert.Equal(t, "", lastID)
+
+	// Flush errors are reported.
+	params.EndingBefore = stripe.String("txn_2")
+	ts.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"object":"list","has_more":false,"data":[{"id":"txn_3","amount":1000,"net":1000}]}`)
+	})
+	lastID, err = c.Export(params, nil, &failingWriter{})
+	assert.EqualError(t, err, "flush failed")
+	assert.Equal(t, "txn_3", lastID)
+}
+
+type failingWriter struct{}
+
+func (w *failingWriter) Flush() error {
+	return errors.New("flush failed")
+}
+
+func (w *failingWriter) WriteEntry(entry *journal.Entry) error {
+	return nil
+}
@@ -0,0 +1,106 @@
+package journal
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+)
+
+//
+// Public functions
+//
+
+// NewCSVWriter returns a Writer writing the lines of entries as CSV rows,
+// after a header naming the columns. Times are formatted in RFC 3339, in UTC.
+func NewCSVWriter(w io.Writer) Writer {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+// NewJSONWriter returns a Writer writing the lines of entries as
+// newline-delimited JSON objects, so that they can be streamed.
+func NewJSONWriter(w io.Writer) Writer {
+	return &jsonWriter{enc: json.NewEncoder(w)}
+}
+
+//
+// Private types
+//
+
+type csvWriter struct {
+	headerWritten bool
+	w             *csv.Writer
+}
+
+func (w *csvWriter) Flush() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+func (w *csvWriter) WriteEntry(entry *Entry) error {
+	if !w.headerWritten {
+		if err := w.w.Write(csvHeader); err != nil {
+			return err
+		}
+		w.headerWritten = true
+	}
+
+	for _, line := range entry.Lines {
+		exchangeRate := ""
+		if line.ExchangeRate != 0 {
+			exchangeRate = strconv.FormatFloat(line.ExchangeRate, 'f', -1, 64)
+		}
+
+		err := w.w.Write([]string{
+			line.BalanceTransaction,
+			time.Unix(line.Created, 0).UTC().Format(time.RFC3339),
+			line.Account,
+			strconv.FormatInt(line.Debit, 10),
+			strconv.FormatInt(line.Credit, 10),
+			string(line.Currency),
+			string(line.ReportingCategory),
+			line.Source,
+			line.Description,
+			exchangeRate,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type jsonWriter struct {
+	enc *json.Encoder
+}
+
+func (w *jsonWriter) Flush() error {
+	return nil
+}
+
+func (w *jsonWriter) WriteEntry(entry *Entry) error {
+	for _, line := range entry.Lines {
+		if err := w.enc.Encode(line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//
+// Private variables
+//
+
+var csvHeader = []string{
+	"balance_transaction",
+	"created",
+	"account",
+	"debit",
+	"credit",
+	"currency",
+	"reporting_category",
+	"source",
+	"description",
+	"exchange_rate",
+}
@@ -0,0 +1,197 @@
+package stripe
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+//
+// Public constants
+//
+
+const (
+	// MaxMetadataKeys is the maximum number of keys in the metadata of an
+	// object.
+	MaxMetadataKeys = 50
+
+	// MaxMetadataKeyLength is the maximum number of characters of a metadata
+	// key.
+	MaxMetadataKeyLength = 40
+
+	// MaxMetadataValueLength is the maximum number of characters of a
+	// metadata value.
+	MaxMetadataValueLength = 500
+)
+
+//
+// Public variables
+//
+
+// ErrMetadataKeyNotFound is returned by the typed getters of Metadata when
+// the key isn't set.
+var ErrMetadataKeyNotFound = errors.New("stripe: metadata key not found")
+
+//
+// Public types
+//
+
+// Metadata is the set of key-value pairs that can be attached to objects,
+// both in params and in the objects returned by the API.
+//
+// In params, setting a key to an empty string unsets it, which is what Unset
+// does. Values of other types are stored as strings by the typed setters,
+// like SetInt64, and can be read back with the matching getters.
+type Metadata map[string]string
+
+// Bool returns the value of a key set with SetBool, or an error if the key
+// isn't set or isn't a boolean.
+func (m Metadata) Bool(key string) (bool, error) {
+	s, err := m.lookup(key)
+	if err != nil {
+		return false, err
+	}
+	v, err := strconv.ParseBool(s)
+	if err != nil {
+		return false, fmt.Errorf("stripe: metadata %q isn't a boolean: %q", key, s)
+	}
+	return v, nil
+}
+
+// Int64 returns the value of a key set with SetInt64, or an error if the key
+// isn't set or isn't an integer.
+func (m Metadata) Int64(key string) (int64, error) {
+	s, err := m.lookup(key)
+	if err != nil {
+		return 0, err
+	}
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("stripe: metadata %q isn't an integer: %q", key, s)
+	}
+	return v, nil
+}
+
+// Set sets a key to a value, allocating the metadata if needed.
+func (m *Metadata) Set(key, value string) {
+	if *m == nil {
+		*m = make(Metadata)
+	}
+	(*m)[key] = value
+}
+
+// SetBool sets a key to a boolean, stored as "true" or "false".
+func (m *Metadata) SetBool(key string, value bool) {
+	m.Set(key, strconv.FormatBool(value))
+}
+
+// SetInt64 sets a key to an integer.
+func (m *Metadata) SetInt64(key string, value int64) {
+	m.Set(key, strconv.FormatInt(value, 10))
+}
+
+// SetTime sets a key to a time, stored in RFC 3339 format in UTC, like
+// "2024-01-02T15:04:05Z".
+func (m *Metadata) SetTime(key string, value time.Time) {
+	m.Set(key, value.UTC().Format(time.RFC3339))
+}
+
+// Time returns the value of a key set with SetTime, or an error if the key
+// isn't set or isn't a time. Unix timestamps are accepted as well.
+func (m Metadata) Time(key string) (time.Time, error) {
+	s, err := m.lookup(key)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if v, err := time.Parse(time.RFC3339, s); err == nil {
+		return v, nil
+	}
+	if v, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(v, 0).UTC(), nil
+	}
+	return time.Time{}, fmt.Errorf("stripe: metadata %q isn't a time: %q", key, s)
+}
+
+// Unset unsets a key when the metadata is sent in params, by setting it to an
+// empty string.
+func (m *Metadata) Unset(key string) {
+	m.Set(key, "")
+}
+
+// Validate checks the metadata against the limits of the API, so that
+// invalid metadata can be caught before making a request: at most 50 keys,
+// keys of at most 40 characters without square brackets, and values of at
+// most 500 characters. Keys being unset aren't counted.
+//
+// Metadata in params, including that of nested params like SubscriptionData
+// or line items, is validated before each request.
+func (m Metadata) Validate() error {
+	keys := make([]string, 0, len(m))
+	count := 0
+	for key, value := range m {
+		keys = append(keys, key)
+		if value != "" {
+			count++
+		}
+	}
+	if count > MaxMetadataKeys {
+		return fmt.Errorf("stripe: metadata has %d keys, more than the maximum of %d", count, MaxMetadataKeys)
+	}
+
+	// Check the keys in order so that errors are deterministic.
+	sort.Strings(keys)
+	for _, key := range keys {
+		switch {
+		case key == "":
+			return errors.New("stripe: metadata key can't be empty")
+		case utf8.RuneCountInString(key) > MaxMetadataKeyLength:
+			return fmt.Errorf("stripe: metadata key %q is longer than %d characters", key, MaxMetadataKeyLength)
+		case strings.ContainsAny(key, "[]"):
+			return fmt.Errorf("stripe: metadata key %q can't contain square brackets", key)
+		case utf8.RuneCountInString(m[key]) > MaxMetadataValueLength:
+			return fmt.Errorf("stripe: metadata value of %q is longer than %d characters", key, MaxMetadataValueLength)
+		}
+	}
+	return nil
+}
+
+//
+// Public functions
+//
+
+// DiffMetadata returns the minimal metadata to send in update params to
+// change the metadata of an object from from to to: the keys which were added
+// or changed, and the keys which were removed, unset. It returns nil when
+// there's nothing to update.
+func DiffMetadata(from, to Metadata) Metadata {
+	var diff Metadata
+	for key, value := range to {
+		if previous, ok := from[key]; !ok || previous != value {
+			diff.Set(key, value)
+		}
+	}
+	for key := range from {
+		if _, ok := to[key]; !ok {
+			diff.Unset(key)
+		}
+	}
+	return diff
+}
+
+//
+// Private functions
+//
+
+// lookup returns the value of a key, or ErrMetadataKeyNotFound if the key
+// isn't set.
+func (m Metadata) lookup(key string) (string, error) {
+	s, ok := m[key]
+	if !ok || s == "" {
+		return "", fmt.Errorf("%w: %q", ErrMetadataKeyNotFound, key)
+	}
+	return s, nil
+}
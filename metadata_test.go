@@ -0,0 +1,137 @@
+package stripe
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+	"github.com/stripe/stripe-go/v81/form"
+)
+
+func TestDiffMetadata(t *testing.T) {
+	from := Metadata{"order_id": "6735", "plan": "gold", "source": "web"}
+	to := Metadata{"order_id": "6735", "plan": "platinum", "ref": "abc"}
+
+	assert.Equal(t, Metadata{"plan": "platinum", "ref": "abc", "source": ""}, DiffMetadata(from, to))
+	assert.Nil(t, DiffMetadata(from, from))
+	assert.Equal(t, Metadata{"order_id": "6735"}, DiffMetadata(nil, Metadata{"order_id": "6735"}))
+}
+
+func TestMetadata_TypedValues(t *testing.T) {
+	var m Metadata
+	m.SetBool("trial", true)
+	m.SetInt64("seats", 12)
+	m.SetTime("renewed_at", time.Date(2024, 1, 2, 16, 4, 5, 0, time.FixedZone("CET", 3600)))
+
+	assert.Equal(t, Metadata{
+		"renewed_at": "2024-01-02T15:04:05Z",
+		"seats":      "12",
+		"trial":      "true",
+	}, m)
+
+	trial, err := m.Bool("trial")
+	assert.NoError(t, err)
+	assert.True(t, trial)
+
+	seats, err := m.Int64("seats")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), seats)
+
+	renewedAt, err := m.Time("renewed_at")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1704207845), renewedAt.Unix())
+
+	m.Set("legacy_renewed_at", "1704207845")
+	renewedAt, err = m.Time("legacy_renewed_at")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1704207845), renewedAt.Unix())
+
+	_, err = m.Int64("trial")
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, ErrMetadataKeyNotFound))
+
+	m.Unset("seats")
+	assert.Equal(t, "", m["seats"])
+	_, err = m.Int64("seats")
+	assert.True(t, errors.Is(err, ErrMetadataKeyNotFound))
+	_, err = m.Bool("missing")
+	assert.True(t, errors.Is(err, ErrMetadataKeyNotFound))
+}
+
+func TestMetadata_Unset(t *testing.T) {
+	params := &CustomerParams{}
+	params.Metadata.Unset("order_id")
+
+	body := &form.Values{}
+	form.AppendTo(body, params)
+	assert.Equal(t, "metadata[order_id]=", body.Encode())
+}
+
+func TestMetadata_Validate(t *testing.T) {
+	assert.NoError(t, Metadata(nil).Validate())
+	assert.NoError(t, Metadata{strings.Repeat("k", 40): strings.Repeat("v", 500)}.Validate())
+	assert.NoError(t, Metadata{"clé": strings.Repeat("é", 500)}.Validate())
+
+	testCases := []struct {
+		name     string
+		metadata Metadata
+	}{
+		{"empty key", Metadata{"": "v"}},
+		{"long key", Metadata{strings.Repeat("k", 41): "v"}},
+		{"brackets", Metadata{"items[0]": "v"}},
+		{"long value", Metadata{"k": strings.Repeat("v", 501)}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Error(t, tc.metadata.Validate())
+		})
+	}
+
+	m := Metadata{}
+	for i := 0; i < MaxMetadataKeys; i++ {
+		m.SetInt64(fmt.Sprintf("key_%d", i), int64(i))
+	}
+	assert.Len(t, m, MaxMetadataKeys)
+	assert.NoError(t, m.Validate())
+
+	// Keys being unset don't count toward the limit.
+	m.Unset("removed")
+	assert.NoError(t, m.Validate())
+
+	m.Set("one_too_many", "v")
+	assert.Error(t, m.Validate())
+}
+
+func TestMetadata_ValidatedBeforeRequest(t *testing.T) {
+	c := GetBackend(APIBackend).(*BackendImplementation)
+
+	params := &CustomerParams{}
+	params.AddMetadata(strings.Repeat("k", 41), "v")
+	err := c.Call("POST", "/v1/customers", "sk_test_xyz", params, &Customer{})
+	assert.EqualError(t, err, `stripe: metadata key "`+strings.Repeat("k", 41)+`" is longer than 40 characters`)
+
+	params = &CustomerParams{}
+	params.Params.AddMetadata("items[0]", "v")
+	err = c.Call("POST", "/v1/customers", "sk_test_xyz", params, &Customer{})
+	assert.Error(t, err)
+
+	nested := &CheckoutSessionParams{
+		LineItems: []*CheckoutSessionLineItemParams{{
+			PriceData: &CheckoutSessionLineItemPriceDataParams{
+				ProductData: &CheckoutSessionLineItemPriceDataProductDataParams{
+					Metadata: Metadata{"k": strings.Repeat("v", 501)},
+				},
+			},
+		}},
+	}
+	err = c.Call("POST", "/v1/checkout/sessions", "sk_test_xyz", nested, &CheckoutSession{})
+	assert.EqualError(t, err, `stripe: metadata value of "k" is longer than 500 characters`)
+
+	nested = &CheckoutSessionParams{SubscriptionData: &CheckoutSessionSubscriptionDataParams{}}
+	nested.SubscriptionData.AddMetadata("items[0]", "v")
+	err = c.Call("POST", "/v1/checkout/sessions", "sk_test_xyz", nested, &CheckoutSession{})
+	assert.Error(t, err)
+}
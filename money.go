@@ -0,0 +1,423 @@
+package stripe
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+//
+// Public variables
+//
+
+// ErrCurrencyMismatch is returned when combining amounts of money in
+// different currencies.
+var ErrCurrencyMismatch = errors.New("stripe: currencies don't match")
+
+// ErrMoneyOverflow is returned when an amount of money doesn't fit in an
+// int64 of minor units.
+var ErrMoneyOverflow = errors.New("stripe: amount of money overflows")
+
+//
+// Public types
+//
+
+// Money is an amount of money in a currency, as represented by the API: an
+// integer amount in the smallest unit of the currency, like cents for USD or
+// yen for JPY.
+//
+// The number of decimal places of each currency, and the special cases of the
+// API, are taken into account when converting from and to decimal strings.
+type Money struct {
+	// Amount is the amount in the smallest unit of the currency.
+	Amount int64
+
+	// Currency is the currency of the amount.
+	Currency Currency
+}
+
+// Exponent returns the number of decimal places used by the API for amounts
+// in the currency, like 2 for USD, 0 for JPY or 3 for KWD. Currencies which
+// aren't known default to 2.
+//
+// This is the exponent used by the API, which differs from ISO 4217 for
+// some currencies: ISK amounts have 2 decimal places, but must be multiples
+// of 100. See AmountIncrement.
+func (c Currency) Exponent() int {
+	if exponent, ok := currencyExponents[Currency(strings.ToLower(string(c)))]; ok {
+		return exponent
+	}
+	return 2
+}
+
+// AmountIncrement returns the number of minor units that amounts in the
+// currency must be a multiple of, like 100 for ISK, which is a zero-decimal
+// currency represented with 2 decimal places, or 10 for three-decimal
+// currencies like KWD, whose last digit must be 0 when paying by card.
+//
+// HUF and TWD amounts must also be multiples of 100, but only for payouts,
+// so this returns 1 for them.
+func (c Currency) AmountIncrement() int64 {
+	switch c := Currency(strings.ToLower(string(c))); {
+	case c == CurrencyISK:
+		return 100
+	case currencyExponents[c] == 3:
+		return 10
+	default:
+		return 1
+	}
+}
+
+// IsZeroDecimal returns whether amounts in the currency have no decimal
+// places, like JPY.
+func (c Currency) IsZeroDecimal() bool {
+	return c.Exponent() == 0
+}
+
+// ParseMoney parses a decimal amount of money in the currency, like "12.34"
+// for 12.34 USD. The amount can't have more decimal places than the currency,
+// apart from trailing zeros.
+func ParseMoney(decimal string, currency Currency) (Money, error) {
+	amount, err := parseDecimalAmount(decimal, currency.Exponent())
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+// Add returns the sum of two amounts of money in the same currency.
+func (m Money) Add(o Money) (Money, error) {
+	if err := m.checkCurrency(o); err != nil {
+		return Money{}, err
+	}
+	if (o.Amount > 0 && m.Amount > math.MaxInt64-o.Amount) ||
+		(o.Amount < 0 && m.Amount < math.MinInt64-o.Amount) {
+		return Money{}, fmt.Errorf("%w: %s + %s", ErrMoneyOverflow, m, o)
+	}
+	return Money{Amount: m.Amount + o.Amount, Currency: m.Currency}, nil
+}
+
+// AmountParam returns the amount as a parameter, like
+// PaymentIntentParams.Amount.
+func (m Money) AmountParam() *int64 {
+	return Int64(m.Amount)
+}
+
+// CurrencyParam returns the currency as a parameter, like
+// PaymentIntentParams.Currency.
+func (m Money) CurrencyParam() *string {
+	return String(strings.ToLower(string(m.Currency)))
+}
+
+// Decimal formats the amount as a decimal string with the number of decimal
+// places of the currency, like "12.34" for 1234 USD minor units.
+func (m Money) Decimal() string {
+	exponent := m.Currency.Exponent()
+
+	// Negating math.MinInt64 overflows, which the conversion to uint64 takes
+	// care of.
+	abs := uint64(m.Amount)
+	if m.Amount < 0 {
+		abs = -abs
+	}
+	digits := strconv.FormatUint(abs, 10)
+	if exponent > 0 {
+		if len(digits) <= exponent {
+			digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-exponent] + "." + digits[len(digits)-exponent:]
+	}
+	if m.Amount < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+// IsNegative returns whether the amount is negative.
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+// IsZero returns whether the amount is zero.
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// Mul returns the amount of money multiplied by n.
+func (m Money) Mul(n int64) (Money, error) {
+	product := m.Amount * n
+	if m.Amount != 0 && (product/m.Amount != n || (m.Amount == -1 && n == math.MinInt64)) {
+		return Money{}, fmt.Errorf("%w: %s * %d", ErrMoneyOverflow, m, n)
+	}
+	return Money{Amount: product, Currency: m.Currency}, nil
+}
+
+// Neg returns the opposite amount of money.
+func (m Money) Neg() (Money, error) {
+	if m.Amount == math.MinInt64 {
+		return Money{}, fmt.Errorf("%w: -(%s)", ErrMoneyOverflow, m)
+	}
+	return Money{Amount: -m.Amount, Currency: m.Currency}, nil
+}
+
+// String formats the amount of money with its currency, like "12.34 USD".
+func (m Money) String() string {
+	return m.Decimal() + " " + strings.ToUpper(string(m.Currency))
+}
+
+// Sub returns the difference of two amounts of money in the same currency.
+func (m Money) Sub(o Money) (Money, error) {
+	if err := m.checkCurrency(o); err != nil {
+		return Money{}, err
+	}
+	if (o.Amount < 0 && m.Amount > math.MaxInt64+o.Amount) ||
+		(o.Amount > 0 && m.Amount < math.MinInt64+o.Amount) {
+		return Money{}, fmt.Errorf("%w: %s - %s", ErrMoneyOverflow, m, o)
+	}
+	return Money{Amount: m.Amount - o.Amount, Currency: m.Currency}, nil
+}
+
+// Validate checks that the amount can be used with the API, which requires
+// amounts in some currencies to be multiples of their AmountIncrement.
+func (m Money) Validate() error {
+	if increment := m.Currency.AmountIncrement(); m.Amount%increment != 0 {
+		return fmt.Errorf("stripe: amounts in %s must be multiples of %d minor units, got %d",
+			strings.ToUpper(string(m.Currency)), increment, m.Amount)
+	}
+	return nil
+}
+
+//
+// Public functions
+//
+
+// AmountFromDecimal returns a decimal amount in the currency as a parameter
+// in minor units, like PaymentIntentParams.Amount. For example, "12.34" is
+// 1234 for USD, and "1234" is 1234 for JPY.
+func AmountFromDecimal(decimal string, currency Currency) (*int64, error) {
+	m, err := ParseMoney(decimal, currency)
+	if err != nil {
+		return nil, err
+	}
+	return m.AmountParam(), nil
+}
+
+// MustAmountFromDecimal is like AmountFromDecimal but panics if the amount
+// can't be parsed. It's meant for amounts known in advance.
+func MustAmountFromDecimal(decimal string, currency Currency) *int64 {
+	amount, err := AmountFromDecimal(decimal, currency)
+	if err != nil {
+		panic(err)
+	}
+	return amount
+}
+
+//
+// Private variables
+//
+
+// currencyExponents are the number of decimal places used by the API for
+// amounts in each currency.
+var currencyExponents = map[Currency]int{
+	CurrencyAED: 2,
+	CurrencyAFN: 2,
+	CurrencyALL: 2,
+	CurrencyAMD: 2,
+	CurrencyANG: 2,
+	CurrencyAOA: 2,
+	CurrencyARS: 2,
+	CurrencyAUD: 2,
+	CurrencyAWG: 2,
+	CurrencyAZN: 2,
+	CurrencyBAM: 2,
+	CurrencyBBD: 2,
+	CurrencyBDT: 2,
+	CurrencyBGN: 2,
+	CurrencyBIF: 0,
+	CurrencyBMD: 2,
+	CurrencyBND: 2,
+	CurrencyBOB: 2,
+	CurrencyBRL: 2,
+	CurrencyBSD: 2,
+	CurrencyBWP: 2,
+	CurrencyBZD: 2,
+	CurrencyCAD: 2,
+	CurrencyCDF: 2,
+	CurrencyCHF: 2,
+	CurrencyCLP: 0,
+	CurrencyCNY: 2,
+	CurrencyCOP: 2,
+	CurrencyCRC: 2,
+	CurrencyCVE: 2,
+	CurrencyCZK: 2,
+	CurrencyDJF: 0,
+	CurrencyDKK: 2,
+	CurrencyDOP: 2,
+	CurrencyDZD: 2,
+	CurrencyEEK: 2,
+	CurrencyEGP: 2,
+	CurrencyETB: 2,
+	CurrencyEUR: 2,
+	CurrencyFJD: 2,
+	CurrencyFKP: 2,
+	CurrencyGBP: 2,
+	CurrencyGEL: 2,
+	CurrencyGIP: 2,
+	CurrencyGMD: 2,
+	CurrencyGNF: 0,
+	CurrencyGTQ: 2,
+	CurrencyGYD: 2,
+	CurrencyHKD: 2,
+	CurrencyHNL: 2,
+	CurrencyHRK: 2,
+	CurrencyHTG: 2,
+	CurrencyHUF: 2,
+	CurrencyIDR: 2,
+	CurrencyILS: 2,
+	CurrencyINR: 2,
+	CurrencyISK: 2, // zero-decimal in ISO 4217, but kept at 2 decimal places by the API
+	CurrencyJMD: 2,
+	CurrencyJPY: 0,
+	CurrencyKES: 2,
+	CurrencyKGS: 2,
+	CurrencyKHR: 2,
+	CurrencyKMF: 0,
+	CurrencyKRW: 0,
+	CurrencyKYD: 2,
+	CurrencyKZT: 2,
+	CurrencyLAK: 2,
+	CurrencyLBP: 2,
+	CurrencyLKR: 2,
+	CurrencyLRD: 2,
+	CurrencyLSL: 2,
+	CurrencyLTL: 2,
+	CurrencyLVL: 2,
+	CurrencyMAD: 2,
+	CurrencyMDL: 2,
+	CurrencyMGA: 0,
+	CurrencyMKD: 2,
+	CurrencyMNT: 2,
+	CurrencyMOP: 2,
+	CurrencyMRO: 2,
+	CurrencyMUR: 2,
+	CurrencyMVR: 2,
+	CurrencyMWK: 2,
+	CurrencyMXN: 2,
+	CurrencyMYR: 2,
+	CurrencyMZN: 2,
+	CurrencyNAD: 2,
+	CurrencyNGN: 2,
+	CurrencyNIO: 2,
+	CurrencyNOK: 2,
+	CurrencyNPR: 2,
+	CurrencyNZD: 2,
+	CurrencyPAB: 2,
+	CurrencyPEN: 2,
+	CurrencyPGK: 2,
+	CurrencyPHP: 2,
+	CurrencyPKR: 2,
+	CurrencyPLN: 2,
+	CurrencyPYG: 0,
+	CurrencyQAR: 2,
+	CurrencyRON: 2,
+	CurrencyRSD: 2,
+	CurrencyRUB: 2,
+	CurrencyRWF: 0,
+	CurrencySAR: 2,
+	CurrencySBD: 2,
+	CurrencySCR: 2,
+	CurrencySEK: 2,
+	CurrencySGD: 2,
+	CurrencySHP: 2,
+	CurrencySLL: 2,
+	CurrencySOS: 2,
+	CurrencySRD: 2,
+	CurrencySTD: 2,
+	CurrencySVC: 2,
+	CurrencySZL: 2,
+	CurrencyTHB: 2,
+	CurrencyTJS: 2,
+	CurrencyTOP: 2,
+	CurrencyTRY: 2,
+	CurrencyTTD: 2,
+	CurrencyTWD: 2,
+	CurrencyTZS: 2,
+	CurrencyUAH: 2,
+	CurrencyUGX: 0,
+	CurrencyUSD: 2,
+	CurrencyUYU: 2,
+	CurrencyUZS: 2,
+	CurrencyVEF: 2,
+	CurrencyVND: 0,
+	CurrencyVUV: 0,
+	CurrencyWST: 2,
+	CurrencyXAF: 0,
+	CurrencyXCD: 2,
+	CurrencyXOF: 0,
+	CurrencyXPF: 0,
+	CurrencyYER: 2,
+	CurrencyZAR: 2,
+	CurrencyZMW: 2,
+
+	// Three-decimal currencies, which have no constant of their own.
+	Currency("bhd"): 3,
+	Currency("jod"): 3,
+	Currency("kwd"): 3,
+	Currency("omr"): 3,
+	Currency("tnd"): 3,
+}
+
+//
+// Private functions
+//
+
+func (m Money) checkCurrency(o Money) error {
+	if !strings.EqualFold(string(m.Currency), string(o.Currency)) {
+		return fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch,
+			strings.ToUpper(string(m.Currency)), strings.ToUpper(string(o.Currency)))
+	}
+	return nil
+}
+
+// parseDecimalAmount parses a decimal amount into minor units with the given
+// number of decimal places.
+func parseDecimalAmount(decimal string, exponent int) (int64, error) {
+	s := strings.TrimSpace(decimal)
+	sign := ""
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		sign, s = s[:1], s[1:]
+	}
+
+	whole, fraction := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		whole, fraction = s[:i], s[i+1:]
+	}
+	if whole == "" || !isDigits(whole) || !isDigits(fraction) || (fraction == "" && strings.HasSuffix(s, ".")) {
+		return 0, fmt.Errorf("stripe: invalid decimal amount %q", decimal)
+	}
+
+	if len(fraction) > exponent {
+		if strings.TrimRight(fraction[exponent:], "0") != "" {
+			return 0, fmt.Errorf("stripe: decimal amount %q has more than %d decimal places", decimal, exponent)
+		}
+		fraction = fraction[:exponent]
+	}
+	fraction += strings.Repeat("0", exponent-len(fraction))
+
+	amount, err := strconv.ParseInt(sign+whole+fraction, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrMoneyOverflow, decimal)
+	}
+	return amount, nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,133 @@
+package stripe
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestCurrencyExponent(t *testing.T) {
+	assert.Equal(t, 2, CurrencyUSD.Exponent())
+	assert.Equal(t, 0, CurrencyJPY.Exponent())
+	assert.Equal(t, 0, Currency("KRW").Exponent())
+	assert.Equal(t, 3, Currency("kwd").Exponent())
+	assert.Equal(t, 2, CurrencyISK.Exponent())
+	assert.Equal(t, 2, Currency("xyz").Exponent())
+	assert.True(t, CurrencyVND.IsZeroDecimal())
+	assert.False(t, CurrencyEUR.IsZeroDecimal())
+
+	assert.Equal(t, int64(100), CurrencyISK.AmountIncrement())
+	assert.Equal(t, int64(10), Currency("bhd").AmountIncrement())
+	assert.Equal(t, int64(1), CurrencyHUF.AmountIncrement())
+}
+
+func TestParseMoney(t *testing.T) {
+	testCases := []struct {
+		decimal  string
+		currency Currency
+		amount   int64
+	}{
+		{"12.34", CurrencyUSD, 1234},
+		{"12", CurrencyUSD, 1200},
+		{"0.5", CurrencyEUR, 50},
+		{"-1.99", CurrencyUSD, -199},
+		{"+1.990", CurrencyUSD, 199},
+		{"1234", CurrencyJPY, 1234},
+		{"1234.00", CurrencyJPY, 1234},
+		{"1.234", Currency("kwd"), 1234},
+		{"5", CurrencyISK, 500},
+		{"92233720368547758.07", CurrencyUSD, math.MaxInt64},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.decimal+" "+string(tc.currency), func(t *testing.T) {
+			m, err := ParseMoney(tc.decimal, tc.currency)
+			assert.NoError(t, err)
+			assert.Equal(t, Money{Amount: tc.amount, Currency: tc.currency}, m)
+		})
+	}
+}
+
+func TestParseMoneyErrors(t *testing.T) {
+	for _, decimal := range []string{"", "-", "1.", ".5", "1.2.3", "1,5", "abc", "1.234"} {
+		_, err := ParseMoney(decimal, CurrencyUSD)
+		assert.Error(t, err, decimal)
+	}
+
+	_, err := ParseMoney("1.5", CurrencyJPY)
+	assert.Error(t, err)
+
+	_, err = ParseMoney("92233720368547758.08", CurrencyUSD)
+	assert.True(t, errors.Is(err, ErrMoneyOverflow))
+}
+
+func TestMoneyDecimal(t *testing.T) {
+	assert.Equal(t, "12.34", Money{1234, CurrencyUSD}.Decimal())
+	assert.Equal(t, "0.05", Money{5, CurrencyUSD}.Decimal())
+	assert.Equal(t, "-0.05", Money{-5, CurrencyUSD}.Decimal())
+	assert.Equal(t, "1234", Money{1234, CurrencyJPY}.Decimal())
+	assert.Equal(t, "0.001", Money{1, Currency("kwd")}.Decimal())
+	assert.Equal(t, "-92233720368547758.08", Money{math.MinInt64, CurrencyUSD}.Decimal())
+	assert.Equal(t, "12.34 USD", Money{1234, CurrencyUSD}.String())
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	a := Money{1000, CurrencyUSD}
+
+	sum, err := a.Add(Money{250, CurrencyUSD})
+	assert.NoError(t, err)
+	assert.Equal(t, Money{1250, CurrencyUSD}, sum)
+
+	diff, err := a.Sub(Money{1250, CurrencyUSD})
+	assert.NoError(t, err)
+	assert.Equal(t, Money{-250, CurrencyUSD}, diff)
+	assert.True(t, diff.IsNegative())
+
+	product, err := a.Mul(3)
+	assert.NoError(t, err)
+	assert.Equal(t, Money{3000, CurrencyUSD}, product)
+
+	neg, err := a.Neg()
+	assert.NoError(t, err)
+	assert.Equal(t, Money{-1000, CurrencyUSD}, neg)
+
+	_, err = a.Add(Money{1000, CurrencyEUR})
+	assert.True(t, errors.Is(err, ErrCurrencyMismatch))
+
+	max := Money{math.MaxInt64, CurrencyUSD}
+	_, err = max.Add(Money{1, CurrencyUSD})
+	assert.True(t, errors.Is(err, ErrMoneyOverflow))
+	_, err = Money{math.MinInt64, CurrencyUSD}.Sub(Money{1, CurrencyUSD})
+	assert.True(t, errors.Is(err, ErrMoneyOverflow))
+	_, err = max.Mul(2)
+	assert.True(t, errors.Is(err, ErrMoneyOverflow))
+	_, err = Money{-1, CurrencyUSD}.Mul(math.MinInt64)
+	assert.True(t, errors.Is(err, ErrMoneyOverflow))
+	_, err = Money{math.MinInt64, CurrencyUSD}.Neg()
+	assert.True(t, errors.Is(err, ErrMoneyOverflow))
+}
+
+func TestMoneyValidate(t *testing.T) {
+	assert.NoError(t, Money{500, CurrencyISK}.Validate())
+	assert.Error(t, Money{550, CurrencyISK}.Validate())
+	assert.NoError(t, Money{1230, Currency("kwd")}.Validate())
+	assert.Error(t, Money{1234, Currency("kwd")}.Validate())
+	assert.NoError(t, Money{1234, CurrencyUSD}.Validate())
+}
+
+func TestAmountFromDecimal(t *testing.T) {
+	params := &PaymentIntentParams{
+		Amount:   MustAmountFromDecimal("19.99", CurrencyEUR),
+		Currency: String(string(CurrencyEUR)),
+	}
+	assert.Equal(t, int64(1999), *params.Amount)
+
+	_, err := AmountFromDecimal("19.999", CurrencyEUR)
+	assert.Error(t, err)
+
+	m := Money{500, CurrencyJPY}
+	assert.Equal(t, int64(500), *m.AmountParam())
+	assert.Equal(t, "jpy", *m.CurrencyParam())
+}
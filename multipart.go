@@ -0,0 +1,224 @@
+package stripe
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+)
+
+//
+// Public types
+//
+
+// MultipartBody is the body of a multipart/form-data request, like a file
+// upload, which is read from its source as the request is sent rather than
+// buffered into memory. It's built by FileParams.GetMultipartBody.
+//
+// Sources which implement io.Seeker and can seek are rewound when requests
+// are retried. What's read from others, like buffers or pipes, is kept in
+// memory as it's sent, up to 32MB, so that it can be sent again. Requests
+// with larger sources which can't seek aren't retried.
+type MultipartBody struct {
+	// Boundary is the boundary separating the parts of the body.
+	Boundary string
+
+	// Size is the size of the body, or -1 when the size of its source can't
+	// be known, in which case it's sent with chunked transfer encoding.
+	Size int64
+
+	opened     bool
+	progress   func(sent, total int64)
+	prefix     []byte
+	rewindable bool
+	source     io.Reader
+	start      int64
+	suffix     []byte
+	total      int64
+}
+
+// MultipartStreamingBackend is implemented by backends able to send
+// multipart requests without buffering their body into memory. Use
+// CallMultipartStreaming to fall back to Backend.CallMultipart for those
+// which aren't.
+type MultipartStreamingBackend interface {
+	CallMultipartStreaming(method, path, key string, body *MultipartBody, params *Params, v LastResponseSetter) error
+}
+
+// ContentType returns the value of the Content-Type header of the body.
+func (b *MultipartBody) ContentType() string {
+	return "multipart/form-data; boundary=" + b.Boundary
+}
+
+// Open returns a reader of the body from its beginning. It rewinds the
+// source when called again, and fails if the source can't be rewound.
+func (b *MultipartBody) Open() (io.ReadCloser, error) {
+	var source io.Reader = bytes.NewReader(nil)
+	if b.source != nil {
+		source = b.source
+		switch {
+		case b.rewindable && b.opened:
+			if _, err := b.source.(io.Seeker).Seek(b.start, io.SeekStart); err != nil {
+				return nil, err
+			}
+		case b.opened:
+			replay, ok := b.source.(*recordingReader).replay()
+			if !ok {
+				return nil, errMultipartBodyNotRewindable
+			}
+			source = replay
+		case !b.rewindable:
+			b.source = &recordingReader{r: b.source}
+			source = b.source
+		}
+		source = &progressReader{r: source, progress: b.progress, total: b.total}
+	}
+	b.opened = true
+
+	return ioutil.NopCloser(io.MultiReader(
+		bytes.NewReader(b.prefix),
+		source,
+		bytes.NewReader(b.suffix),
+	)), nil
+}
+
+//
+// Public functions
+//
+
+// CallMultipartStreaming sends a multipart request with a backend, streaming
+// its body if the backend implements MultipartStreamingBackend, and
+// buffering it into memory with Backend.CallMultipart otherwise.
+func CallMultipartStreaming(b Backend, method, path, key string, body *MultipartBody, params *Params, v LastResponseSetter) error {
+	if sb, ok := b.(MultipartStreamingBackend); ok {
+		return sb.CallMultipartStreaming(method, path, key, body, params, v)
+	}
+
+	buffer, err := body.buffer()
+	if err != nil {
+		return err
+	}
+	return b.CallMultipart(method, path, key, body.Boundary, buffer, params, v)
+}
+
+//
+// Private types
+//
+
+// recordingReader records what's read from a source which can't seek, up to
+// maxRecordedSize bytes, so that it can be read again from its beginning.
+type recordingReader struct {
+	overflow bool
+	r        io.Reader
+	recorded []byte
+}
+
+func (r *recordingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if n > 0 && !r.overflow {
+		if int64(len(r.recorded)+n) > maxRecordedSize {
+			r.overflow = true
+			r.recorded = nil
+		} else {
+			r.recorded = append(r.recorded, p[:n]...)
+		}
+	}
+	return n, err
+}
+
+// replay returns a reader of the source from its beginning, reading what was
+// recorded before the rest of the source, or false if too much was read to
+// be recorded.
+func (r *recordingReader) replay() (io.Reader, bool) {
+	if r.overflow {
+		return nil, false
+	}
+	return io.MultiReader(bytes.NewReader(r.recorded), r), true
+}
+
+// progressReader reports the progress of reading a multipart body source.
+type progressReader struct {
+	progress func(sent, total int64)
+	r        io.Reader
+	sent     int64
+	total    int64
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if n > 0 && r.progress != nil {
+		r.sent += int64(n)
+		r.progress(r.sent, r.total)
+	}
+	return n, err
+}
+
+//
+// Private variables
+//
+
+var errMultipartBodyNotRewindable = errors.New("stripe: multipart body source can't seek and is too large to be sent again")
+
+// maxRecordedSize is the maximum number of bytes recorded from a multipart
+// body source which can't seek, so that it can be sent again. It's a variable
+// so that tests can lower it.
+var maxRecordedSize int64 = 32 << 20
+
+//
+// Private functions
+//
+
+// buffer reads the whole body into memory.
+func (b *MultipartBody) buffer() (*bytes.Buffer, error) {
+	r, err := b.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	buffer := &bytes.Buffer{}
+	if _, err := buffer.ReadFrom(r); err != nil {
+		return nil, err
+	}
+	return buffer, nil
+}
+
+// readerSize returns the number of bytes left to read from r, or -1 when it
+// can't be known without reading it, like when r is an io.Seeker which can't
+// seek.
+func readerSize(r io.Reader) int64 {
+	if l, ok := r.(interface{ Len() int }); ok {
+		return int64(l.Len())
+	}
+
+	seeker, ok := r.(io.Seeker)
+	if !ok {
+		return -1
+	}
+	current, err := seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return -1
+	}
+	end, err := seeker.Seek(0, io.SeekEnd)
+	if err != nil {
+		return -1
+	}
+	if _, err := seeker.Seek(current, io.SeekStart); err != nil {
+		return -1
+	}
+	return end - current
+}
+
+// readerStart returns the offset of r, to rewind it to, and whether it can
+// be rewound at all.
+func readerStart(r io.Reader) (int64, bool) {
+	seeker, ok := r.(io.Seeker)
+	if !ok {
+		return 0, false
+	}
+	start, err := seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, false
+	}
+	return start, true
+}
@@ -0,0 +1,283 @@
+package stripe
+
+import (
+	"bytes"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestFileParams_GetMultipartBody(t *testing.T) {
+	p := &FileParams{
+		FileReader: strings.NewReader("file contents"),
+		Filename:   String("/tmp/evidence.txt"),
+		FileLinkData: &FileFileLinkDataParams{
+			Create: Bool(true),
+		},
+		Purpose: String(string(FilePurposeDisputeEvidence)),
+	}
+
+	body, err := p.GetMultipartBody()
+	assert.NoError(t, err)
+
+	r, err := body.Open()
+	assert.NoError(t, err)
+	contents, err := ioutil.ReadAll(r)
+	assert.NoError(t, err)
+	assert.Equal(t, body.Size, int64(len(contents)))
+
+	values := readMultipart(t, body.Boundary, contents)
+	assert.Equal(t, "dispute_evidence", values["purpose"])
+	assert.Equal(t, "file contents", values["file"])
+	assert.Equal(t, "true", values["file_link_data[create]"])
+}
+
+func TestMultipartBody_Open(t *testing.T) {
+	var progress []int64
+	p := &FileParams{
+		FileReader: strings.NewReader("file contents"),
+		Filename:   String("evidence.txt"),
+		Progress: func(sent, total int64) {
+			assert.Equal(t, int64(13), total)
+			progress = append(progress, sent)
+		},
+	}
+	body, err := p.GetMultipartBody()
+	assert.NoError(t, err)
+
+	// Seekable sources are rewound.
+	for i := 0; i < 2; i++ {
+		progress = nil
+		r, err := body.Open()
+		assert.NoError(t, err)
+		contents, err := ioutil.ReadAll(r)
+		assert.NoError(t, err)
+		assert.Equal(t, "file contents", readMultipart(t, body.Boundary, contents)["file"])
+		assert.Equal(t, int64(13), progress[len(progress)-1])
+	}
+
+	// Others are replayed from what was read of them, even partially.
+	p.FileReader = ioutil.NopCloser(strings.NewReader("file contents"))
+	p.Progress = nil
+	body, err = p.GetMultipartBody()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-1), body.Size)
+	r, err := body.Open()
+	assert.NoError(t, err)
+	_, err = r.Read(make([]byte, len(body.prefix)+4))
+	assert.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		r, err = body.Open()
+		assert.NoError(t, err)
+		contents, err := ioutil.ReadAll(r)
+		assert.NoError(t, err)
+		assert.Equal(t, "file contents", readMultipart(t, body.Boundary, contents)["file"])
+	}
+
+	// Unless too much was read to be kept in memory.
+	defer func(size int64) { maxRecordedSize = size }(maxRecordedSize)
+	maxRecordedSize = 4
+	p.FileReader = ioutil.NopCloser(strings.NewReader("file contents"))
+	body, err = p.GetMultipartBody()
+	assert.NoError(t, err)
+	r, err = body.Open()
+	assert.NoError(t, err)
+	_, err = ioutil.ReadAll(r)
+	assert.NoError(t, err)
+	_, err = body.Open()
+	assert.Equal(t, errMultipartBodyNotRewindable, err)
+}
+
+func TestMultipartBody_OpenPipe(t *testing.T) {
+	// Files which can't seek, like pipes, are sent without size, and replayed
+	// from memory.
+	r, w, err := os.Pipe()
+	assert.NoError(t, err)
+	defer r.Close()
+	go func() {
+		w.WriteString("file contents")
+		w.Close()
+	}()
+
+	p := &FileParams{FileReader: r, Filename: String("evidence.txt")}
+	body, err := p.GetMultipartBody()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-1), body.Size)
+
+	opened, err := body.Open()
+	assert.NoError(t, err)
+	contents, err := ioutil.ReadAll(opened)
+	assert.NoError(t, err)
+	assert.Equal(t, "file contents", readMultipart(t, body.Boundary, contents)["file"])
+
+	opened, err = body.Open()
+	assert.NoError(t, err)
+	contents, err = ioutil.ReadAll(opened)
+	assert.NoError(t, err)
+	assert.Equal(t, "file contents", readMultipart(t, body.Boundary, contents)["file"])
+}
+
+func TestCallMultipartStreaming(t *testing.T) {
+	requests := 0
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, int64(-1), r.ContentLength)
+
+		reader, err := r.MultipartReader()
+		assert.NoError(t, err)
+		part, err := reader.NextPart()
+		assert.NoError(t, err)
+		contents, err := ioutil.ReadAll(part)
+		assert.NoError(t, err)
+		assert.Equal(t, "file contents", string(contents))
+
+		w.Write([]byte(`{"id":"file_123"}`))
+	}))
+	defer testServer.Close()
+
+	backend := GetBackendWithConfig(UploadsBackend, &BackendConfig{
+		LeveledLogger:     nullLeveledLogger,
+		MaxNetworkRetries: Int64(0),
+		URL:               String(testServer.URL),
+	})
+
+	p := &FileParams{
+		FileReader: ioutil.NopCloser(strings.NewReader("file contents")),
+		Filename:   String("evidence.txt"),
+	}
+	body, err := p.GetMultipartBody()
+	assert.NoError(t, err)
+
+	file := &File{}
+	err = CallMultipartStreaming(backend, http.MethodPost, "/v1/files", "sk_test_123", body, &p.Params, file)
+	assert.NoError(t, err)
+	assert.Equal(t, "file_123", file.ID)
+	assert.Equal(t, 1, requests)
+}
+
+func TestCallMultipartStreaming_Retry(t *testing.T) {
+	requests := 0
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		contents, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		if r.ContentLength >= 0 {
+			assert.Equal(t, r.ContentLength, int64(len(contents)))
+		}
+		assert.True(t, bytes.Contains(contents, []byte("file contents")))
+
+		if requests == 1 {
+			w.Header().Set("Stripe-Should-Retry", "true")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"error":{"type":"api_error"}}`))
+			return
+		}
+		w.Write([]byte(`{"id":"file_123"}`))
+	}))
+	defer testServer.Close()
+
+	backend := GetBackendWithConfig(UploadsBackend, &BackendConfig{
+		LeveledLogger:     nullLeveledLogger,
+		MaxNetworkRetries: Int64(1),
+		URL:               String(testServer.URL),
+	}).(*BackendImplementation)
+	backend.SetNetworkRetriesSleep(false)
+
+	// Seekable sources are sent again.
+	p := &FileParams{
+		FileReader: strings.NewReader("file contents"),
+		Filename:   String("evidence.txt"),
+	}
+	body, err := p.GetMultipartBody()
+	assert.NoError(t, err)
+
+	file := &File{}
+	err = backend.CallMultipartStreaming(http.MethodPost, "/v1/files", "sk_test_123", body, &p.Params, file)
+	assert.NoError(t, err)
+	assert.Equal(t, "file_123", file.ID)
+	assert.Equal(t, 2, requests)
+
+	// Others are sent again from memory.
+	requests = 0
+	p.FileReader = ioutil.NopCloser(strings.NewReader("file contents"))
+	body, err = p.GetMultipartBody()
+	assert.NoError(t, err)
+
+	err = backend.CallMultipartStreaming(http.MethodPost, "/v1/files", "sk_test_123", body, &p.Params, &File{})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, requests)
+
+	// Unless they're too large, and the error of the first attempt is
+	// returned.
+	defer func(size int64) { maxRecordedSize = size }(maxRecordedSize)
+	maxRecordedSize = 4
+	requests = 0
+	p.FileReader = ioutil.NopCloser(strings.NewReader("file contents"))
+	body, err = p.GetMultipartBody()
+	assert.NoError(t, err)
+
+	err = backend.CallMultipartStreaming(http.MethodPost, "/v1/files", "sk_test_123", body, &p.Params, &File{})
+	assert.Error(t, err)
+	assert.Equal(t, ErrorTypeAPI, err.(*Error).Type)
+	assert.Equal(t, 1, requests)
+}
+
+func TestCallMultipartStreaming_RetryConnectionReset(t *testing.T) {
+	requests := 0
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		contents, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.True(t, bytes.Contains(contents, []byte("file contents")))
+
+		if requests == 1 {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			assert.NoError(t, err)
+			conn.Close()
+			return
+		}
+		w.Write([]byte(`{"id":"file_123"}`))
+	}))
+	defer testServer.Close()
+
+	backend := GetBackendWithConfig(UploadsBackend, &BackendConfig{
+		LeveledLogger:     nullLeveledLogger,
+		MaxNetworkRetries: Int64(1),
+		URL:               String(testServer.URL),
+	}).(*BackendImplementation)
+	backend.SetNetworkRetriesSleep(false)
+
+	p := &FileParams{
+		FileReader: bytes.NewBufferString("file contents"),
+		Filename:   String("evidence.txt"),
+	}
+	body, err := p.GetMultipartBody()
+	assert.NoError(t, err)
+
+	file := &File{}
+	err = backend.CallMultipartStreaming(http.MethodPost, "/v1/files", "sk_test_123", body, &p.Params, file)
+	assert.NoError(t, err)
+	assert.Equal(t, "file_123", file.ID)
+	assert.Equal(t, 2, requests)
+}
+
+func readMultipart(t *testing.T, boundary string, contents []byte) map[string]string {
+	values := make(map[string]string)
+	reader := multipart.NewReader(bytes.NewReader(contents), boundary)
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			break
+		}
+		value, err := ioutil.ReadAll(part)
+		assert.NoError(t, err)
+		values[part.FormName()] = string(value)
+	}
+	return values
+}
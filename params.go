@@ -3,7 +3,9 @@ package stripe
 import (
 	"context"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -200,7 +202,7 @@ type Params struct {
 	IdempotencyKey *string `form:"-"` // Passed as header
 
 	// Deprecated: Please use Metadata in the surrounding struct instead.
-	Metadata map[string]string `form:"metadata"`
+	Metadata Metadata `form:"metadata"`
 
 	// StripeAccount may contain the ID of a connected account. By including
 	// this field, the request is made as if it originated from the connected
@@ -236,7 +238,7 @@ func (p *Params) AddExtra(key, value string) {
 // Deprecated: please use .AddMetadata of the surrounding struct.
 func (p *Params) AddMetadata(key, value string) {
 	if p.Metadata == nil {
-		p.Metadata = make(map[string]string)
+		p.Metadata = make(Metadata)
 	}
 
 	p.Metadata[key] = value
@@ -305,6 +307,25 @@ func NewIdempotencyKey() string {
 	return fmt.Sprintf("%v_%v", now, base64.URLEncoding.EncodeToString(buf)[:6])
 }
 
+// NewDeterministicIdempotencyKey generates an idempotency key derived from
+// the given parts, like a job ID and an object ID. The same parts always
+// produce the same key, which makes it safe to rerun an interrupted batch of
+// requests without duplicating the ones that already went through.
+//
+// Stripe keeps the response of a request for 24 hours after it's made with an
+// idempotency key, including error responses, so rerunning a request which
+// failed with the same key within that time replays the error. Add a part like
+// an attempt number to retry it for real.
+func NewDeterministicIdempotencyKey(parts ...string) string {
+	h := sha256.New()
+	for _, part := range parts {
+		// Length-prefix every part so that ("ab", "c") and ("a", "bc") don't
+		// collide.
+		fmt.Fprintf(h, "%d:%s", len(part), part)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
 //
 // Private types
 //
@@ -235,6 +235,17 @@ func TestParams_SetIdempotencyKey(t *testing.T) {
 	assert.Equal(t, "my-idempotency-key", *p.IdempotencyKey)
 }
 
+func TestNewDeterministicIdempotencyKey(t *testing.T) {
+	key := stripe.NewDeterministicIdempotencyKey("job_1", "sub_123")
+	assert.Equal(t, key, stripe.NewDeterministicIdempotencyKey("job_1", "sub_123"))
+	assert.Equal(t, 64, len(key))
+
+	assert.NotEqual(t, key, stripe.NewDeterministicIdempotencyKey("job_2", "sub_123"))
+	assert.NotEqual(t,
+		stripe.NewDeterministicIdempotencyKey("ab", "c"),
+		stripe.NewDeterministicIdempotencyKey("a", "bc"))
+}
+
 func TestParams_SetStripeAccount(t *testing.T) {
 	p := &stripe.Params{}
 	p.SetStripeAccount(TestMerchantID)
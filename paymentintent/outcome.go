@@ -0,0 +1,366 @@
+package paymentintent
+
+import (
+	stripe "github.com/stripe/stripe-go/v81"
+	"github.com/stripe/stripe-go/v81/customer"
+)
+
+//
+// Public constants
+//
+
+// List of values that ActionType can take.
+const (
+	// ActionTypeAwaitNotification is for card payments which are attempted
+	// later, after the customer has been notified, and which may need their
+	// approval. Nothing needs to be displayed.
+	ActionTypeAwaitNotification ActionType = "await_notification"
+
+	// ActionTypeDisplayDetails is for payments made by the customer outside
+	// of the checkout, like vouchers or bank transfers, whose details are
+	// displayed on Action.HostedURL.
+	ActionTypeDisplayDetails ActionType = "display_details"
+
+	// ActionTypeDisplayQRCode is for QR codes to be scanned by the customer,
+	// available as Action.QRCode. Some also have an Action.RedirectURL to
+	// use instead on mobile.
+	ActionTypeDisplayQRCode ActionType = "display_qr_code"
+
+	// ActionTypeRedirect is for redirecting the customer to
+	// Action.RedirectURL, like for 3D Secure or wallets.
+	ActionTypeRedirect ActionType = "redirect"
+
+	// ActionTypeUseStripeSDK is for actions handled by Stripe.js or the
+	// mobile SDKs, with `handleNextAction`. Next actions which aren't known
+	// by this package are reported with this type too, since the SDKs handle
+	// all of them.
+	ActionTypeUseStripeSDK ActionType = "use_stripe_sdk"
+
+	// ActionTypeVerifyWithMicrodeposits is for bank accounts verified with
+	// microdeposits, on Action.HostedURL.
+	ActionTypeVerifyWithMicrodeposits ActionType = "verify_with_microdeposits"
+)
+
+// List of values that OutcomeType can take.
+const (
+	// OutcomeTypeIncomplete is for payment intents which haven't been
+	// confirmed yet, or which need a payment method before their first
+	// attempt.
+	OutcomeTypeIncomplete OutcomeType = "incomplete"
+
+	// OutcomeTypeProcessing is for payments being processed. Waiting is all
+	// there is to do.
+	OutcomeTypeProcessing OutcomeType = "processing"
+
+	// OutcomeTypeRequiresAction is for payments which need the customer to
+	// do something, described by Outcome.Action.
+	OutcomeTypeRequiresAction OutcomeType = "requires_action"
+
+	// OutcomeTypeRequiresCapture is for authorized payments which need to be
+	// captured.
+	OutcomeTypeRequiresCapture OutcomeType = "requires_capture"
+
+	// OutcomeTypeRetryableFailure is for failed payment attempts which can be
+	// retried with a new payment method. Outcome.Error has the reason of the
+	// failure.
+	OutcomeTypeRetryableFailure OutcomeType = "retryable_failure"
+
+	// OutcomeTypeSucceeded is for successful payments.
+	OutcomeTypeSucceeded OutcomeType = "succeeded"
+
+	// OutcomeTypeTerminalFailure is for canceled payment intents, which
+	// can't be used anymore.
+	OutcomeTypeTerminalFailure OutcomeType = "terminal_failure"
+)
+
+//
+// Public types
+//
+
+// Action is a normalized description of what the customer needs to do for a
+// payment intent to proceed, whatever the type of its next action.
+type Action struct {
+	// ExpiresAt is the time after which the action can't be completed
+	// anymore, like the expiry of a voucher or a QR code, or 0 when it
+	// doesn't expire or its expiry isn't known.
+	ExpiresAt int64
+
+	// HostedURL is the page hosted by Stripe on which the customer can
+	// complete the action, like the page of a voucher, of bank transfer
+	// instructions or of a microdeposits verification.
+	HostedURL string
+
+	// NextAction is the next action of the payment intent, with all the
+	// details specific to its type.
+	NextAction *stripe.PaymentIntentNextAction
+
+	// QRCode is the QR code to display for ActionTypeDisplayQRCode.
+	QRCode *QRCode
+
+	// RedirectURL is the URL to redirect the customer to.
+	RedirectURL string
+
+	// ReturnURL is the URL the customer is sent back to after RedirectURL,
+	// when there's one.
+	ReturnURL string
+
+	// Type is the type of the action.
+	Type ActionType
+}
+
+// ActionType is the type of an Action.
+type ActionType string
+
+// Outcome is the classification of a payment intent into what can be done
+// about it.
+type Outcome struct {
+	// Action is what the customer needs to do for OutcomeTypeRequiresAction.
+	Action *Action
+
+	// Error is the error of the last payment attempt for
+	// OutcomeTypeRetryableFailure, and for OutcomeTypeTerminalFailure when
+	// the payment intent was canceled after an attempt failed.
+	Error *stripe.Error
+
+	// FailedPaymentMethod is the payment method whose attempt failed for
+	// OutcomeTypeRetryableFailure. The customer should be asked for a
+	// different one, of one of the PaymentMethodTypes of the payment intent,
+	// like the one suggested by SuggestPaymentMethod.
+	FailedPaymentMethod *stripe.PaymentMethod
+
+	// PaymentIntent is the payment intent that was classified.
+	PaymentIntent *stripe.PaymentIntent
+
+	// SuggestedPaymentMethod is the payment method to retry with for
+	// OutcomeTypeRetryableFailure, once found by SuggestPaymentMethod.
+	SuggestedPaymentMethod *stripe.PaymentMethod
+
+	// Type is the type of the outcome.
+	Type OutcomeType
+}
+
+// IsFinal returns whether the outcome won't change without doing something,
+// either on the side of the customer or of the integration. Payment intents
+// which are processing, or whose card payments are awaiting a notification
+// which doesn't need the approval of the customer, aren't final.
+func (o *Outcome) IsFinal() bool {
+	switch o.Type {
+	case OutcomeTypeProcessing:
+		return false
+	case OutcomeTypeRequiresAction:
+		next := o.PaymentIntent.NextAction
+		if o.Action.Type == ActionTypeAwaitNotification && next.CardAwaitNotification != nil {
+			return next.CardAwaitNotification.CustomerApprovalRequired
+		}
+	}
+	return true
+}
+
+// OutcomeType is the type of an Outcome.
+type OutcomeType string
+
+// QRCode is a QR code to display to the customer.
+type QRCode struct {
+	// Data is the data encoded in the QR code, for rendering it directly.
+	Data string
+
+	// ImageURLPNG is the URL of a PNG image of the QR code.
+	ImageURLPNG string
+
+	// ImageURLSVG is the URL of a SVG image of the QR code.
+	ImageURLSVG string
+}
+
+//
+// Public functions
+//
+
+// Classify returns the outcome of a payment intent, from its status, its next
+// action and the error of its last payment attempt.
+func Classify(pi *stripe.PaymentIntent) *Outcome {
+	o := &Outcome{PaymentIntent: pi}
+
+	switch pi.Status {
+	case stripe.PaymentIntentStatusCanceled:
+		o.Type = OutcomeTypeTerminalFailure
+		o.Error = pi.LastPaymentError
+	case stripe.PaymentIntentStatusProcessing:
+		o.Type = OutcomeTypeProcessing
+	case stripe.PaymentIntentStatusRequiresAction:
+		o.Type = OutcomeTypeRequiresAction
+		o.Action = newAction(pi.NextAction)
+	case stripe.PaymentIntentStatusRequiresCapture:
+		o.Type = OutcomeTypeRequiresCapture
+	case stripe.PaymentIntentStatusRequiresPaymentMethod:
+		if pi.LastPaymentError == nil {
+			o.Type = OutcomeTypeIncomplete
+			break
+		}
+		o.Type = OutcomeTypeRetryableFailure
+		o.Error = pi.LastPaymentError
+		o.FailedPaymentMethod = pi.LastPaymentError.PaymentMethod
+	case stripe.PaymentIntentStatusSucceeded:
+		o.Type = OutcomeTypeSucceeded
+	default:
+		o.Type = OutcomeTypeIncomplete
+	}
+
+	return o
+}
+
+// SuggestPaymentMethod suggests a payment method to retry a failed payment
+// with. See Client.SuggestPaymentMethod.
+func SuggestPaymentMethod(o *Outcome, params *stripe.CustomerListPaymentMethodsParams) (*stripe.PaymentMethod, error) {
+	return getC().SuggestPaymentMethod(o, params)
+}
+
+// SuggestPaymentMethod suggests a payment method to retry the payment of an
+// outcome of type OutcomeTypeRetryableFailure with, among the payment methods
+// attached to the customer of the payment intent: the most recent one of one
+// of the PaymentMethodTypes of the payment intent, other than
+// FailedPaymentMethod and other cards with the same fingerprint. The
+// suggestion is also set as Outcome.SuggestedPaymentMethod.
+//
+// Nil is returned when there's no such payment method, and for other outcomes
+// or payment intents without a customer. The Customer of params is set from
+// the payment intent.
+func (c Client) SuggestPaymentMethod(o *Outcome, params *stripe.CustomerListPaymentMethodsParams) (*stripe.PaymentMethod, error) {
+	pi := o.PaymentIntent
+	if o.Type != OutcomeTypeRetryableFailure || pi.Customer == nil {
+		return nil, nil
+	}
+
+	listParams := &stripe.CustomerListPaymentMethodsParams{}
+	if params != nil {
+		*listParams = *params
+	}
+	listParams.Customer = stripe.String(pi.Customer.ID)
+
+	customers := customer.Client{B: c.B, Key: c.Key}
+	i := customers.ListPaymentMethods(listParams)
+	for i.Next() {
+		pm := i.PaymentMethod()
+		if isSuggestable(pm, pi, o.FailedPaymentMethod) {
+			o.SuggestedPaymentMethod = pm
+			return pm, nil
+		}
+	}
+	return nil, i.Err()
+}
+
+//
+// Private functions
+//
+
+// isSuggestable returns whether a payment method of the customer can be
+// suggested to retry the payment of a payment intent after an attempt with
+// the failed payment method.
+func isSuggestable(pm *stripe.PaymentMethod, pi *stripe.PaymentIntent, failed *stripe.PaymentMethod) bool {
+	if failed != nil {
+		if pm.ID == failed.ID {
+			return false
+		}
+		if pm.Card != nil && failed.Card != nil && pm.Card.Fingerprint != "" &&
+			pm.Card.Fingerprint == failed.Card.Fingerprint {
+			return false
+		}
+	}
+	if len(pi.PaymentMethodTypes) == 0 {
+		return true
+	}
+	for _, t := range pi.PaymentMethodTypes {
+		if t == string(pm.Type) {
+			return true
+		}
+	}
+	return false
+}
+
+// newAction normalizes the next action of a payment intent.
+func newAction(next *stripe.PaymentIntentNextAction) *Action {
+	a := &Action{NextAction: next, Type: ActionTypeUseStripeSDK}
+	if next == nil {
+		return a
+	}
+
+	switch {
+	case next.RedirectToURL != nil:
+		a.Type = ActionTypeRedirect
+		a.RedirectURL = next.RedirectToURL.URL
+		a.ReturnURL = next.RedirectToURL.ReturnURL
+	case next.AlipayHandleRedirect != nil:
+		a.Type = ActionTypeRedirect
+		a.RedirectURL = next.AlipayHandleRedirect.URL
+		a.ReturnURL = next.AlipayHandleRedirect.ReturnURL
+	case next.WeChatPayRedirectToIOSApp != nil:
+		a.Type = ActionTypeRedirect
+		a.RedirectURL = next.WeChatPayRedirectToIOSApp.NativeURL
+
+	case next.CashAppHandleRedirectOrDisplayQRCode != nil:
+		details := next.CashAppHandleRedirectOrDisplayQRCode
+		a.Type = ActionTypeDisplayQRCode
+		a.HostedURL = details.HostedInstructionsURL
+		a.RedirectURL = details.MobileAuthURL
+		if details.QRCode != nil {
+			a.ExpiresAt = details.QRCode.ExpiresAt
+			a.QRCode = &QRCode{ImageURLPNG: details.QRCode.ImageURLPNG, ImageURLSVG: details.QRCode.ImageURLSVG}
+		}
+	case next.PayNowDisplayQRCode != nil:
+		details := next.PayNowDisplayQRCode
+		a.Type = ActionTypeDisplayQRCode
+		a.HostedURL = details.HostedInstructionsURL
+		a.QRCode = &QRCode{Data: details.Data, ImageURLPNG: details.ImageURLPNG, ImageURLSVG: details.ImageURLSVG}
+	case next.PixDisplayQRCode != nil:
+		details := next.PixDisplayQRCode
+		a.Type = ActionTypeDisplayQRCode
+		a.ExpiresAt = details.ExpiresAt
+		a.HostedURL = details.HostedInstructionsURL
+		a.QRCode = &QRCode{Data: details.Data, ImageURLPNG: details.ImageURLPNG, ImageURLSVG: details.ImageURLSVG}
+	case next.PromptPayDisplayQRCode != nil:
+		details := next.PromptPayDisplayQRCode
+		a.Type = ActionTypeDisplayQRCode
+		a.HostedURL = details.HostedInstructionsURL
+		a.QRCode = &QRCode{Data: details.Data, ImageURLPNG: details.ImageURLPNG, ImageURLSVG: details.ImageURLSVG}
+	case next.SwishHandleRedirectOrDisplayQRCode != nil:
+		details := next.SwishHandleRedirectOrDisplayQRCode
+		a.Type = ActionTypeDisplayQRCode
+		a.HostedURL = details.HostedInstructionsURL
+		a.RedirectURL = details.MobileAuthURL
+		if details.QRCode != nil {
+			a.QRCode = &QRCode{Data: details.QRCode.Data, ImageURLPNG: details.QRCode.ImageURLPNG, ImageURLSVG: details.QRCode.ImageURLSVG}
+		}
+	case next.WeChatPayDisplayQRCode != nil:
+		details := next.WeChatPayDisplayQRCode
+		a.Type = ActionTypeDisplayQRCode
+		a.HostedURL = details.HostedInstructionsURL
+		a.QRCode = &QRCode{Data: details.Data, ImageURLPNG: details.ImageURLPNG, ImageURLSVG: details.ImageURLSVG}
+
+	case next.BoletoDisplayDetails != nil:
+		a.Type = ActionTypeDisplayDetails
+		a.ExpiresAt = next.BoletoDisplayDetails.ExpiresAt
+		a.HostedURL = next.BoletoDisplayDetails.HostedVoucherURL
+	case next.DisplayBankTransferInstructions != nil:
+		a.Type = ActionTypeDisplayDetails
+		a.HostedURL = next.DisplayBankTransferInstructions.HostedInstructionsURL
+	case next.KonbiniDisplayDetails != nil:
+		a.Type = ActionTypeDisplayDetails
+		a.ExpiresAt = next.KonbiniDisplayDetails.ExpiresAt
+		a.HostedURL = next.KonbiniDisplayDetails.HostedVoucherURL
+	case next.MultibancoDisplayDetails != nil:
+		a.Type = ActionTypeDisplayDetails
+		a.ExpiresAt = next.MultibancoDisplayDetails.ExpiresAt
+		a.HostedURL = next.MultibancoDisplayDetails.HostedVoucherURL
+	case next.OXXODisplayDetails != nil:
+		a.Type = ActionTypeDisplayDetails
+		a.ExpiresAt = next.OXXODisplayDetails.ExpiresAfter
+		a.HostedURL = next.OXXODisplayDetails.HostedVoucherURL
+
+	case next.CardAwaitNotification != nil:
+		a.Type = ActionTypeAwaitNotification
+	case next.VerifyWithMicrodeposits != nil:
+		a.Type = ActionTypeVerifyWithMicrodeposits
+		a.HostedURL = next.VerifyWithMicrodeposits.HostedVerificationURL
+	}
+
+	return a
+}
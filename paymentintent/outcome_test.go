@@ -0,0 +1,216 @@
+package paymentintent_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+	stripe "github.com/stripe/stripe-go/v81"
+	"github.com/stripe/stripe-go/v81/paymentintent"
+	"github.com/stripe/stripe-go/v81/stripetest"
+)
+
+func TestClassify(t *testing.T) {
+	testCases := []struct {
+		status stripe.PaymentIntentStatus
+		want   paymentintent.OutcomeType
+	}{
+		{stripe.PaymentIntentStatusCanceled, paymentintent.OutcomeTypeTerminalFailure},
+		{stripe.PaymentIntentStatusProcessing, paymentintent.OutcomeTypeProcessing},
+		{stripe.PaymentIntentStatusRequiresCapture, paymentintent.OutcomeTypeRequiresCapture},
+		{stripe.PaymentIntentStatusRequiresConfirmation, paymentintent.OutcomeTypeIncomplete},
+		{stripe.PaymentIntentStatusRequiresPaymentMethod, paymentintent.OutcomeTypeIncomplete},
+		{stripe.PaymentIntentStatusSucceeded, paymentintent.OutcomeTypeSucceeded},
+	}
+	for _, tc := range testCases {
+		t.Run(string(tc.status), func(t *testing.T) {
+			outcome := paymentintent.Classify(&stripe.PaymentIntent{Status: tc.status})
+			assert.Equal(t, tc.want, outcome.Type)
+			assert.Equal(t, tc.status != stripe.PaymentIntentStatusProcessing, outcome.IsFinal())
+		})
+	}
+}
+
+func TestClassifyRetryableFailure(t *testing.T) {
+	pm := &stripe.PaymentMethod{ID: "pm_123"}
+	outcome := paymentintent.Classify(&stripe.PaymentIntent{
+		LastPaymentError: &stripe.Error{
+			Code:          stripe.ErrorCodeCardDeclined,
+			DeclineCode:   stripe.DeclineCodeInsufficientFunds,
+			PaymentMethod: pm,
+			Type:          stripe.ErrorTypeCard,
+		},
+		Status: stripe.PaymentIntentStatusRequiresPaymentMethod,
+	})
+	assert.Equal(t, paymentintent.OutcomeTypeRetryableFailure, outcome.Type)
+	assert.Equal(t, stripe.DeclineCodeInsufficientFunds, outcome.Error.DeclineCode)
+	assert.Equal(t, pm, outcome.FailedPaymentMethod)
+	assert.True(t, outcome.IsFinal())
+}
+
+func TestClassifyActions(t *testing.T) {
+	testCases := []struct {
+		name       string
+		nextAction *stripe.PaymentIntentNextAction
+		want       *paymentintent.Action
+		final      bool
+	}{
+		{
+			name: "redirect_to_url",
+			nextAction: &stripe.PaymentIntentNextAction{
+				RedirectToURL: &stripe.PaymentIntentNextActionRedirectToURL{
+					ReturnURL: "https://example.com/return",
+					URL:       "https://hooks.stripe.com/3d_secure",
+				},
+				Type: stripe.PaymentIntentNextActionTypeRedirectToURL,
+			},
+			want: &paymentintent.Action{
+				RedirectURL: "https://hooks.stripe.com/3d_secure",
+				ReturnURL:   "https://example.com/return",
+				Type:        paymentintent.ActionTypeRedirect,
+			},
+			final: true,
+		},
+		{
+			name: "use_stripe_sdk",
+			nextAction: &stripe.PaymentIntentNextAction{
+				Type:         stripe.PaymentIntentNextActionTypeUseStripeSDK,
+				UseStripeSDK: &stripe.PaymentIntentNextActionUseStripeSDK{},
+			},
+			want:  &paymentintent.Action{Type: paymentintent.ActionTypeUseStripeSDK},
+			final: true,
+		},
+		{
+			name: "verify_with_microdeposits",
+			nextAction: &stripe.PaymentIntentNextAction{
+				Type: stripe.PaymentIntentNextActionTypeVerifyWithMicrodeposits,
+				VerifyWithMicrodeposits: &stripe.PaymentIntentNextActionVerifyWithMicrodeposits{
+					HostedVerificationURL: "https://payments.stripe.com/microdeposit",
+				},
+			},
+			want: &paymentintent.Action{
+				HostedURL: "https://payments.stripe.com/microdeposit",
+				Type:      paymentintent.ActionTypeVerifyWithMicrodeposits,
+			},
+			final: true,
+		},
+		{
+			name: "pix_display_qr_code",
+			nextAction: &stripe.PaymentIntentNextAction{
+				PixDisplayQRCode: &stripe.PaymentIntentNextActionPixDisplayQRCode{
+					Data:                  "00020101",
+					ExpiresAt:             1700000000,
+					HostedInstructionsURL: "https://payments.stripe.com/pix",
+					ImageURLPNG:           "https://qr.stripe.com/pix.png",
+					ImageURLSVG:           "https://qr.stripe.com/pix.svg",
+				},
+				Type: "pix_display_qr_code",
+			},
+			want: &paymentintent.Action{
+				ExpiresAt: 1700000000,
+				HostedURL: "https://payments.stripe.com/pix",
+				QRCode: &paymentintent.QRCode{
+					Data:        "00020101",
+					ImageURLPNG: "https://qr.stripe.com/pix.png",
+					ImageURLSVG: "https://qr.stripe.com/pix.svg",
+				},
+				Type: paymentintent.ActionTypeDisplayQRCode,
+			},
+			final: true,
+		},
+		{
+			name: "oxxo_display_details",
+			nextAction: &stripe.PaymentIntentNextAction{
+				OXXODisplayDetails: &stripe.PaymentIntentNextActionOXXODisplayDetails{
+					ExpiresAfter:     1700000000,
+					HostedVoucherURL: "https://payments.stripe.com/oxxo",
+				},
+				Type: stripe.PaymentIntentNextActionTypeOXXODisplayDetails,
+			},
+			want: &paymentintent.Action{
+				ExpiresAt: 1700000000,
+				HostedURL: "https://payments.stripe.com/oxxo",
+				Type:      paymentintent.ActionTypeDisplayDetails,
+			},
+			final: true,
+		},
+		{
+			name: "card_await_notification",
+			nextAction: &stripe.PaymentIntentNextAction{
+				CardAwaitNotification: &stripe.PaymentIntentNextActionCardAwaitNotification{
+					ChargeAttemptAt: 1700000000,
+				},
+				Type: "card_await_notification",
+			},
+			want:  &paymentintent.Action{Type: paymentintent.ActionTypeAwaitNotification},
+			final: false,
+		},
+		{
+			name:       "unknown",
+			nextAction: &stripe.PaymentIntentNextAction{Type: "new_action"},
+			want:       &paymentintent.Action{Type: paymentintent.ActionTypeUseStripeSDK},
+			final:      true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			outcome := paymentintent.Classify(&stripe.PaymentIntent{
+				NextAction: tc.nextAction,
+				Status:     stripe.PaymentIntentStatusRequiresAction,
+			})
+			assert.Equal(t, paymentintent.OutcomeTypeRequiresAction, outcome.Type)
+
+			tc.want.NextAction = tc.nextAction
+			assert.Equal(t, tc.want, outcome.Action)
+			assert.Equal(t, tc.final, outcome.IsFinal())
+		})
+	}
+}
+
+func TestSuggestPaymentMethod(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/customers/cus_123/payment_methods", r.URL.Path)
+		fmt.Fprint(w, `{"object":"list","data":[
+			{"id":"pm_failed","type":"card","card":{"fingerprint":"fp_1"}},
+			{"id":"pm_same_card","type":"card","card":{"fingerprint":"fp_1"}},
+			{"id":"pm_sepa","type":"sepa_debit"},
+			{"id":"pm_other_card","type":"card","card":{"fingerprint":"fp_2"}}
+		]}`)
+	}))
+	defer ts.Close()
+
+	pi := &stripe.PaymentIntent{
+		Customer: &stripe.Customer{ID: "cus_123"},
+		LastPaymentError: &stripe.Error{
+			Code: stripe.ErrorCodeCardDeclined,
+			PaymentMethod: &stripe.PaymentMethod{
+				Card: &stripe.PaymentMethodCard{Fingerprint: "fp_1"},
+				ID:   "pm_failed",
+			},
+		},
+		PaymentMethodTypes: []string{"card"},
+		Status:             stripe.PaymentIntentStatusRequiresPaymentMethod,
+	}
+	c := paymentintent.Client{B: stripetest.NewBackend(stripe.APIBackend, ts.URL), Key: "sk_test_123"}
+	outcome := paymentintent.Classify(pi)
+	pm, err := c.SuggestPaymentMethod(outcome, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "pm_other_card", pm.ID)
+	assert.Equal(t, pm, outcome.SuggestedPaymentMethod)
+
+	pi.PaymentMethodTypes = []string{"sepa_debit"}
+	pm, err = c.SuggestPaymentMethod(paymentintent.Classify(pi), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "pm_sepa", pm.ID)
+
+	pi.PaymentMethodTypes = []string{"us_bank_account"}
+	pm, err = c.SuggestPaymentMethod(paymentintent.Classify(pi), nil)
+	assert.NoError(t, err)
+	assert.Nil(t, pm)
+
+	pm, err = c.SuggestPaymentMethod(paymentintent.Classify(&stripe.PaymentIntent{Status: stripe.PaymentIntentStatusSucceeded}), nil)
+	assert.NoError(t, err)
+	assert.Nil(t, pm)
+}
@@ -0,0 +1,179 @@
+package paymentintent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	stripe "github.com/stripe/stripe-go/v81"
+)
+
+//
+// Public constants
+//
+
+// DefaultWaitPollInterval is the interval at which ConfirmAndWait retrieves
+// the payment intent when ConfirmAndWaitParams.PollInterval isn't set.
+const DefaultWaitPollInterval = 2 * time.Second
+
+//
+// Public types
+//
+
+// ConfirmAndWaitParams configures ConfirmAndWait.
+type ConfirmAndWaitParams struct {
+	// ConfirmParams are the parameters used to confirm the payment intent.
+	// Their Context is used for the whole wait, and their StripeAccount for
+	// retrieving the payment intent too.
+	ConfirmParams *stripe.PaymentIntentConfirmParams
+
+	// Events is an optional channel of events, like those received by a
+	// webhook endpoint, which are consumed along with polling so that the
+	// wait ends as soon as a `payment_intent.*` event of the payment intent
+	// has a final outcome. Events of other objects are ignored.
+	Events <-chan *stripe.Event
+
+	// PollInterval is the interval at which the payment intent is retrieved
+	// while its outcome isn't final. Set to a negative value to only rely on
+	// Events.
+	//
+	// Defaults to DefaultWaitPollInterval.
+	PollInterval time.Duration
+
+	// Timeout is the maximum duration of the wait, after which the last
+	// outcome is returned along with context.DeadlineExceeded. Waits are
+	// otherwise only bounded by the context of ConfirmParams, and by Events
+	// being closed when only relying on them.
+	Timeout time.Duration
+}
+
+//
+// Public functions
+//
+
+// ConfirmAndWait confirms a payment intent and waits for a final outcome.
+// See Client.ConfirmAndWait.
+func ConfirmAndWait(id string, params *ConfirmAndWaitParams) (*Outcome, error) {
+	return getC().ConfirmAndWait(id, params)
+}
+
+// ConfirmAndWait confirms a payment intent, then waits until its outcome is
+// final (see Outcome.IsFinal), either by polling or by consuming events, and
+// returns it.
+//
+// A confirmation declined by the API isn't returned as an error, but as an
+// outcome of type OutcomeTypeRetryableFailure, like when the payment fails
+// later on. Errors are returned for failed requests, and when the wait is
+// over before the outcome is final, along with the last outcome.
+func (c Client) ConfirmAndWait(id string, params *ConfirmAndWaitParams) (*Outcome, error) {
+	if params == nil {
+		params = &ConfirmAndWaitParams{}
+	}
+	confirmParams := params.ConfirmParams
+	if confirmParams == nil {
+		confirmParams = &stripe.PaymentIntentConfirmParams{}
+	}
+
+	ctx := confirmParams.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if params.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, params.Timeout)
+		defer cancel()
+	}
+
+	pollInterval := params.PollInterval
+	if pollInterval == 0 {
+		pollInterval = DefaultWaitPollInterval
+	}
+
+	pi, err := c.Confirm(id, confirmParams)
+	if err != nil {
+		pi = declinedPaymentIntent(err)
+		if pi == nil {
+			return nil, err
+		}
+	}
+
+	outcome := Classify(pi)
+	events := params.Events
+	for !outcome.IsFinal() {
+		var poll <-chan time.Time
+		if pollInterval > 0 {
+			poll = time.After(pollInterval)
+		} else if events == nil {
+			return outcome, errors.New("paymentintent: no more events to wait for a final outcome")
+		}
+
+		select {
+		case <-ctx.Done():
+			return outcome, ctx.Err()
+
+		case <-poll:
+			getParams := &stripe.PaymentIntentParams{}
+			getParams.Context = ctx
+			getParams.StripeAccount = confirmParams.StripeAccount
+			pi, err := c.Get(id, getParams)
+			if err != nil {
+				// Report the end of the wait rather than the request it
+				// interrupted.
+				if ctx.Err() != nil {
+					return outcome, ctx.Err()
+				}
+				return outcome, err
+			}
+			outcome = Classify(pi)
+
+		case e, ok := <-events:
+			if !ok {
+				// Receiving from a nil channel blocks, so this stops
+				// consuming events.
+				events = nil
+				continue
+			}
+			if pi := eventPaymentIntent(e, id); pi != nil {
+				outcome = Classify(pi)
+			}
+		}
+	}
+
+	return outcome, nil
+}
+
+//
+// Private functions
+//
+
+// declinedPaymentIntent returns the payment intent of a confirmation declined
+// by the API, with the error as its last payment error, or nil for other
+// errors.
+func declinedPaymentIntent(err error) *stripe.PaymentIntent {
+	var stripeErr *stripe.Error
+	if !errors.As(err, &stripeErr) || stripeErr.Type != stripe.ErrorTypeCard || stripeErr.PaymentIntent == nil {
+		return nil
+	}
+
+	pi := stripeErr.PaymentIntent
+	if pi.LastPaymentError == nil {
+		pi.LastPaymentError = stripeErr
+	}
+	return pi
+}
+
+// eventPaymentIntent returns the payment intent of an event if it's a
+// `payment_intent.*` event of the payment intent with the given ID.
+func eventPaymentIntent(e *stripe.Event, id string) *stripe.PaymentIntent {
+	if e == nil || e.Data == nil || !strings.HasPrefix(string(e.Type), "payment_intent.") {
+		return nil
+	}
+
+	pi := &stripe.PaymentIntent{}
+	if err := json.Unmarshal(e.Data.Raw, pi); err != nil || pi.ID != id {
+		return nil
+	}
+	return pi
+}
@@ -0,0 +1,113 @@
+package paymentintent_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+	stripe "github.com/stripe/stripe-go/v81"
+	"github.com/stripe/stripe-go/v81/paymentintent"
+	"github.com/stripe/stripe-go/v81/stripetest"
+	"github.com/stripe/stripe-go/v81/stripetest/fixtures"
+)
+
+func TestConfirmAndWaitPolls(t *testing.T) {
+	var gets int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/payment_intents/pi_123/confirm":
+			assert.Equal(t, http.MethodPost, r.Method)
+			fmt.Fprint(w, `{"id":"pi_123","object":"payment_intent","status":"processing"}`)
+		case "/v1/payment_intents/pi_123":
+			assert.Equal(t, "acct_123", r.Header.Get("Stripe-Account"))
+			status := "processing"
+			if atomic.AddInt32(&gets, 1) >= 2 {
+				status = "succeeded"
+			}
+			fmt.Fprintf(w, `{"id":"pi_123","object":"payment_intent","status":%q}`, status)
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	confirmParams := &stripe.PaymentIntentConfirmParams{}
+	confirmParams.SetStripeAccount("acct_123")
+
+	c := paymentintent.Client{B: stripetest.NewBackend(stripe.APIBackend, ts.URL), Key: "sk_test_123"}
+	outcome, err := c.ConfirmAndWait("pi_123", &paymentintent.ConfirmAndWaitParams{
+		ConfirmParams: confirmParams,
+		PollInterval:  time.Millisecond,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, paymentintent.OutcomeTypeSucceeded, outcome.Type)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&gets))
+}
+
+func TestConfirmAndWaitConsumesEvents(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/payment_intents/pi_123/confirm", r.URL.Path)
+		fmt.Fprint(w, `{"id":"pi_123","object":"payment_intent","status":"processing"}`)
+	}))
+	defer ts.Close()
+
+	events := make(chan *stripe.Event, 3)
+	events <- fixtures.CustomerCreatedEvent(fixtures.Customer(nil))
+	events <- fixtures.PaymentIntentSucceededEvent(fixtures.PaymentIntent(&stripe.PaymentIntent{ID: "pi_other"}))
+	events <- fixtures.PaymentIntentPaymentFailedEvent(fixtures.PaymentIntent(&stripe.PaymentIntent{
+		ID: "pi_123",
+		LastPaymentError: &stripe.Error{
+			Code: stripe.ErrorCodeCardDeclined,
+			Type: stripe.ErrorTypeCard,
+		},
+		Status: stripe.PaymentIntentStatusRequiresPaymentMethod,
+	}))
+
+	c := paymentintent.Client{B: stripetest.NewBackend(stripe.APIBackend, ts.URL), Key: "sk_test_123"}
+	outcome, err := c.ConfirmAndWait("pi_123", &paymentintent.ConfirmAndWaitParams{
+		Events:       events,
+		PollInterval: -1,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, paymentintent.OutcomeTypeRetryableFailure, outcome.Type)
+	assert.Equal(t, stripe.ErrorCodeCardDeclined, outcome.Error.Code)
+
+	close(events)
+}
+
+func TestConfirmAndWaitDeclined(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusPaymentRequired)
+		fmt.Fprint(w, `{"error":{"type":"card_error","code":"card_declined","decline_code":"insufficient_funds",
+			"payment_method":{"id":"pm_123","object":"payment_method"},
+			"payment_intent":{"id":"pi_123","object":"payment_intent","status":"requires_payment_method"}}}`)
+	}))
+	defer ts.Close()
+
+	c := paymentintent.Client{B: stripetest.NewBackend(stripe.APIBackend, ts.URL), Key: "sk_test_123"}
+	outcome, err := c.ConfirmAndWait("pi_123", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, paymentintent.OutcomeTypeRetryableFailure, outcome.Type)
+	assert.Equal(t, stripe.DeclineCodeInsufficientFunds, outcome.Error.DeclineCode)
+	assert.Equal(t, "pm_123", outcome.FailedPaymentMethod.ID)
+}
+
+func TestConfirmAndWaitTimeout(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"pi_123","object":"payment_intent","status":"processing"}`)
+	}))
+	defer ts.Close()
+
+	c := paymentintent.Client{B: stripetest.NewBackend(stripe.APIBackend, ts.URL), Key: "sk_test_123"}
+	outcome, err := c.ConfirmAndWait("pi_123", &paymentintent.ConfirmAndWaitParams{
+		PollInterval: time.Millisecond,
+		Timeout:      20 * time.Millisecond,
+	})
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Equal(t, paymentintent.OutcomeTypeProcessing, outcome.Type)
+}
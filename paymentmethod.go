@@ -684,7 +684,7 @@ type PaymentMethodParams struct {
 	// If this is an `Link` PaymentMethod, this hash contains details about the Link payment method.
 	Link *PaymentMethodLinkParams `form:"link"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format. Individual keys can be unset by posting an empty value to them. All keys can be unset by posting an empty value to `metadata`.
-	Metadata map[string]string `form:"metadata"`
+	Metadata Metadata `form:"metadata"`
 	// If this is a `mobilepay` PaymentMethod, this hash contains details about the MobilePay payment method.
 	Mobilepay *PaymentMethodMobilepayParams `form:"mobilepay"`
 	// If this is a `multibanco` PaymentMethod, this hash contains details about the Multibanco payment method.
@@ -744,7 +744,7 @@ func (p *PaymentMethodParams) AddExpand(f string) {
 // AddMetadata adds a new key-value pair to the Metadata.
 func (p *PaymentMethodParams) AddMetadata(key string, value string) {
 	if p.Metadata == nil {
-		p.Metadata = make(map[string]string)
+		p.Metadata = make(Metadata)
 	}
 
 	p.Metadata[key] = value
@@ -1344,7 +1344,7 @@ type PaymentMethod struct {
 	// Has the value `true` if the object exists in live mode or the value `false` if the object exists in test mode.
 	Livemode bool `json:"livemode"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format.
-	Metadata   map[string]string        `json:"metadata"`
+	Metadata   Metadata                 `json:"metadata"`
 	Mobilepay  *PaymentMethodMobilepay  `json:"mobilepay"`
 	Multibanco *PaymentMethodMultibanco `json:"multibanco"`
 	NaverPay   *PaymentMethodNaverPay   `json:"naver_pay"`
@@ -106,7 +106,7 @@ type PaymentSourceParams struct {
 	// Four digit number representing the card's expiration year.
 	ExpYear *string `form:"exp_year"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format. Individual keys can be unset by posting an empty value to them. All keys can be unset by posting an empty value to `metadata`.
-	Metadata map[string]string `form:"metadata"`
+	Metadata Metadata `form:"metadata"`
 	// Cardholder name.
 	Name  *string                   `form:"name"`
 	Owner *PaymentSourceOwnerParams `form:"owner"`
@@ -123,7 +123,7 @@ func (p *PaymentSourceParams) AddExpand(f string) {
 // AddMetadata adds a new key-value pair to the Metadata.
 func (p *PaymentSourceParams) AddMetadata(key string, value string) {
 	if p.Metadata == nil {
-		p.Metadata = make(map[string]string)
+		p.Metadata = make(Metadata)
 	}
 
 	p.Metadata[key] = value
@@ -111,7 +111,7 @@ type PersonParams struct {
 	// The person's maiden name.
 	MaidenName *string `form:"maiden_name"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format. Individual keys can be unset by posting an empty value to them. All keys can be unset by posting an empty value to `metadata`.
-	Metadata map[string]string `form:"metadata"`
+	Metadata Metadata `form:"metadata"`
 	// The country where the person is a national. Two-letter country code ([ISO 3166-1 alpha-2](https://en.wikipedia.org/wiki/ISO_3166-1_alpha-2)), or "XX" if unavailable.
 	Nationality *string `form:"nationality"`
 	// A [person token](https://docs.stripe.com/connect/account-tokens), used to securely provide details to the person.
@@ -138,7 +138,7 @@ func (p *PersonParams) AddExpand(f string) {
 // AddMetadata adds a new key-value pair to the Metadata.
 func (p *PersonParams) AddMetadata(key string, value string) {
 	if p.Metadata == nil {
-		p.Metadata = make(map[string]string)
+		p.Metadata = make(Metadata)
 	}
 
 	p.Metadata[key] = value
@@ -506,7 +506,7 @@ type Person struct {
 	// The person's maiden name.
 	MaidenName string `json:"maiden_name"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format.
-	Metadata map[string]string `json:"metadata"`
+	Metadata Metadata `json:"metadata"`
 	// The country where the person is a national.
 	Nationality string `json:"nationality"`
 	// String representing the object's type. Objects of the same type share the same value.
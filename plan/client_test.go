@@ -32,7 +32,7 @@ func TestPlanList(t *testing.T) {
 
 func TestPlanNew(t *testing.T) {
 	plan, err := New(&stripe.PlanParams{
-		AmountDecimal: stripe.Float64(0.0123456789),
+		AmountDecimal: stripe.MustParseDecimal("0.0123456789").Param(),
 		BillingScheme: stripe.String(string(stripe.PlanBillingSchemeTiered)),
 		Currency:      stripe.String(string(stripe.CurrencyUSD)),
 		ID:            stripe.String("sapphire-elite"),
@@ -24,7 +24,7 @@ func TestPlan_Unmarshal(t *testing.T) {
 	err = json.Unmarshal(bytes, &plan)
 	assert.NoError(t, err)
 
-	assert.Equal(t, 0.0123456789, plan.AmountDecimal)
+	assert.Equal(t, MustParseDecimal("0.0123456789"), plan.AmountDecimal)
 }
 
 func TestPlanListParams_AppendTo(t *testing.T) {
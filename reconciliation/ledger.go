@@ -0,0 +1,248 @@
+package reconciliation
+
+import (
+	"fmt"
+	"sort"
+
+	stripe "github.com/stripe/stripe-go/v81"
+)
+
+//
+// Public constants
+//
+
+// List of values that DiscrepancyType can take.
+const (
+	// DiscrepancyTypeFeeDetails is for balance transactions whose fee details
+	// don't add up to their fee.
+	DiscrepancyTypeFeeDetails DiscrepancyType = "fee_details"
+
+	// DiscrepancyTypeNet is for balance transactions whose net isn't their
+	// amount minus their fee.
+	DiscrepancyTypeNet DiscrepancyType = "net"
+
+	// DiscrepancyTypePayoutAmount is for payouts whose amount isn't the sum
+	// of the nets of their balance transactions.
+	DiscrepancyTypePayoutAmount DiscrepancyType = "payout_amount"
+
+	// DiscrepancyTypePayoutCurrency is for balance transactions of a payout
+	// in another currency than the payout.
+	DiscrepancyTypePayoutCurrency DiscrepancyType = "payout_currency"
+
+	// DiscrepancyTypePayoutTransaction is for payouts whose own balance
+	// transaction doesn't debit the balance of their amount.
+	DiscrepancyTypePayoutTransaction DiscrepancyType = "payout_transaction"
+)
+
+//
+// Public types
+//
+
+// Discrepancy is an amount of a ledger which doesn't match what it's
+// expected to be. Amounts are in the smallest unit of their currency.
+type Discrepancy struct {
+	// Actual is the amount found.
+	Actual int64
+
+	// BalanceTransaction is the balance transaction of the discrepancy, or
+	// nil for discrepancies of a whole payout.
+	BalanceTransaction *stripe.BalanceTransaction
+
+	// Expected is the amount expected.
+	Expected int64
+
+	// Message describes the discrepancy.
+	Message string
+
+	// Type is the type of the discrepancy.
+	Type DiscrepancyType
+}
+
+// DiscrepancyType is the type of a Discrepancy.
+type DiscrepancyType string
+
+// Entry is a balance transaction of a ledger, along with the objects it
+// relates to when its source was expanded.
+type Entry struct {
+	// BalanceTransaction is the balance transaction, with its source
+	// expanded.
+	BalanceTransaction *stripe.BalanceTransaction
+
+	// ChargeID is the ID of the charge the balance transaction originates
+	// from: the charge itself, or the charge refunded, disputed, or on which
+	// an application fee was collected. It's empty for other sources.
+	ChargeID string
+
+	// ConnectedAccountID is the ID of the connected account the balance
+	// transaction involves: the destination of a transfer, or the account an
+	// application fee was collected from. It's empty for other sources.
+	ConnectedAccountID string
+}
+
+// Ledger is the list of the balance transactions of a payout or of a period,
+// with their totals and the discrepancies found between their amounts.
+type Ledger struct {
+	// Discrepancies are the discrepancies found, which are empty when the
+	// ledger is reconciled.
+	Discrepancies []*Discrepancy
+
+	// Entries are the balance transactions of the ledger, from the newest to
+	// the oldest. The payout's own balance transaction isn't an entry.
+	Entries []*Entry
+
+	// Payout is the payout reconciled, or nil for the ledger of a period.
+	Payout *stripe.Payout
+
+	// PayoutTransaction is the payout's own balance transaction, debiting
+	// the balance of its amount, or nil for the ledger of a period.
+	PayoutTransaction *stripe.BalanceTransaction
+
+	// Totals are the totals of the entries per currency and reporting
+	// category, sorted by currency then reporting category.
+	Totals []*Total
+}
+
+// Total is the sum of the entries of a ledger with the same currency and
+// reporting category. Amounts are in the smallest unit of the currency.
+type Total struct {
+	Amount            int64
+	Count             int
+	Currency          stripe.Currency
+	Fee               int64
+	Net               int64
+	ReportingCategory stripe.BalanceTransactionReportingCategory
+}
+
+// IsReconciled returns whether no discrepancies were found.
+func (l *Ledger) IsReconciled() bool {
+	return len(l.Discrepancies) == 0
+}
+
+// Net returns the sum of the nets of the entries in a currency.
+func (l *Ledger) Net(currency stripe.Currency) int64 {
+	var net int64
+	for _, total := range l.Totals {
+		if total.Currency == currency {
+			net += total.Net
+		}
+	}
+	return net
+}
+
+//
+// Private functions
+//
+
+// add adds a balance transaction to the ledger, checking its amounts.
+func (l *Ledger) add(bt *stripe.BalanceTransaction) {
+	entry := &Entry{BalanceTransaction: bt}
+	if bt.Source != nil {
+		entry.ChargeID, entry.ConnectedAccountID = sourceRelations(bt.Source)
+	}
+	l.Entries = append(l.Entries, entry)
+
+	if bt.Amount-bt.Fee != bt.Net {
+		l.addDiscrepancy(bt, DiscrepancyTypeNet, bt.Amount-bt.Fee, bt.Net,
+			"net of balance transaction %s isn't its amount minus its fee", bt.ID)
+	}
+	if len(bt.FeeDetails) > 0 {
+		var fees int64
+		for _, detail := range bt.FeeDetails {
+			fees += detail.Amount
+		}
+		if fees != bt.Fee {
+			l.addDiscrepancy(bt, DiscrepancyTypeFeeDetails, bt.Fee, fees,
+				"fee details of balance transaction %s don't add up to its fee", bt.ID)
+		}
+	}
+}
+
+func (l *Ledger) addDiscrepancy(bt *stripe.BalanceTransaction, t DiscrepancyType, expected, actual int64, format string, args ...interface{}) {
+	l.Discrepancies = append(l.Discrepancies, &Discrepancy{
+		Actual:             actual,
+		BalanceTransaction: bt,
+		Expected:           expected,
+		Message:            fmt.Sprintf(format, args...) + fmt.Sprintf(" (expected %d, got %d)", expected, actual),
+		Type:               t,
+	})
+}
+
+// computeTotals computes the totals of the entries.
+func (l *Ledger) computeTotals() {
+	type key struct {
+		currency stripe.Currency
+		category stripe.BalanceTransactionReportingCategory
+	}
+	totals := make(map[key]*Total)
+	for _, entry := range l.Entries {
+		bt := entry.BalanceTransaction
+		k := key{bt.Currency, bt.ReportingCategory}
+		total, ok := totals[k]
+		if !ok {
+			total = &Total{Currency: bt.Currency, ReportingCategory: bt.ReportingCategory}
+			totals[k] = total
+			l.Totals = append(l.Totals, total)
+		}
+		total.Amount += bt.Amount
+		total.Count++
+		total.Fee += bt.Fee
+		total.Net += bt.Net
+	}
+
+	sort.Slice(l.Totals, func(i, j int) bool {
+		if l.Totals[i].Currency != l.Totals[j].Currency {
+			return l.Totals[i].Currency < l.Totals[j].Currency
+		}
+		return l.Totals[i].ReportingCategory < l.Totals[j].ReportingCategory
+	})
+}
+
+// reconcilePayout checks that the entries of the ledger add up to its
+// payout.
+func (l *Ledger) reconcilePayout() {
+	payout := l.Payout
+
+	var net int64
+	for _, entry := range l.Entries {
+		bt := entry.BalanceTransaction
+		if bt.Currency != payout.Currency {
+			l.addDiscrepancy(bt, DiscrepancyTypePayoutCurrency, 0, bt.Net,
+				"balance transaction %s is in %s, not in the currency of payout %s", bt.ID, bt.Currency, payout.ID)
+			continue
+		}
+		net += bt.Net
+	}
+
+	if net != payout.Amount {
+		l.addDiscrepancy(nil, DiscrepancyTypePayoutAmount, payout.Amount, net,
+			"balance transactions don't add up to the amount of payout %s", payout.ID)
+	}
+	if l.PayoutTransaction != nil && l.PayoutTransaction.Net != -payout.Amount {
+		l.addDiscrepancy(l.PayoutTransaction, DiscrepancyTypePayoutTransaction, -payout.Amount, l.PayoutTransaction.Net,
+			"balance transaction %s doesn't debit the amount of payout %s", l.PayoutTransaction.ID, payout.ID)
+	}
+}
+
+// sourceRelations returns the IDs of the charge and of the connected account
+// an expanded source relates to.
+func sourceRelations(source *stripe.BalanceTransactionSource) (string, string) {
+	var chargeID, accountID string
+	switch {
+	case source.Charge != nil:
+		chargeID = source.Charge.ID
+	case source.Refund != nil && source.Refund.Charge != nil:
+		chargeID = source.Refund.Charge.ID
+	case source.Dispute != nil && source.Dispute.Charge != nil:
+		chargeID = source.Dispute.Charge.ID
+	case source.ApplicationFee != nil:
+		if source.ApplicationFee.Charge != nil {
+			chargeID = source.ApplicationFee.Charge.ID
+		}
+		if source.ApplicationFee.Account != nil {
+			accountID = source.ApplicationFee.Account.ID
+		}
+	case source.Transfer != nil && source.Transfer.Destination != nil:
+		accountID = source.Transfer.Destination.ID
+	}
+	return chargeID, accountID
+}
@@ -0,0 +1,172 @@
+// Package reconciliation builds ledgers of the balance transactions of
+// payouts or of periods, to reconcile them with bank deposits or accounting.
+//
+// A ledger has one entry per balance transaction, with its source (charge,
+// refund, dispute, transfer, application fee, and so on) expanded, and totals
+// per currency and reporting category. The amounts of every balance
+// transaction are checked, and for payouts the nets of their balance
+// transactions are checked to add up to their amount. Anything which doesn't
+// add up is reported as a Discrepancy rather than as an error.
+package reconciliation
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	stripe "github.com/stripe/stripe-go/v81"
+	"github.com/stripe/stripe-go/v81/balancetransaction"
+	"github.com/stripe/stripe-go/v81/payout"
+)
+
+//
+// Public types
+//
+
+// Client is used to build ledgers.
+type Client struct {
+	B   stripe.Backend
+	Key string
+}
+
+// ManualPayoutError is the error returned when reconciling a manual payout,
+// whose balance transactions can't be listed.
+type ManualPayoutError struct {
+	// Payout is the manual payout.
+	Payout *stripe.Payout
+}
+
+// Error describes the payout.
+func (e *ManualPayoutError) Error() string {
+	return fmt.Sprintf("reconciliation: payout %s is manual, so its balance transactions can't be listed", e.Payout.ID)
+}
+
+// PayoutParams are the parameters of the ledger of a payout.
+type PayoutParams struct {
+	// Params are used for every request, so that their Context can cancel
+	// the reconciliation and their StripeAccount can reconcile the payouts of
+	// connected accounts.
+	stripe.Params `form:"*"`
+}
+
+// PeriodParams are the parameters of the ledger of a period.
+type PeriodParams struct {
+	// Params are used for every request, so that their Context can cancel
+	// the reconciliation and their StripeAccount can reconcile the balance of
+	// connected accounts.
+	stripe.Params `form:"*"`
+
+	// Currency only includes balance transactions in a currency when set.
+	Currency stripe.Currency `form:"-"`
+
+	// End is the end of the period, exclusive, in which balance transactions
+	// were created.
+	End time.Time `form:"-"`
+
+	// Start is the start of the period, inclusive, in which balance
+	// transactions were created.
+	Start time.Time `form:"-"`
+}
+
+//
+// Public functions
+//
+
+// ReconcilePayout builds the ledger of a payout. See Client.ReconcilePayout.
+func ReconcilePayout(id string, params *PayoutParams) (*Ledger, error) {
+	return getC().ReconcilePayout(id, params)
+}
+
+// ReconcilePayout builds the ledger of the balance transactions paid out by
+// an automatic payout, and checks that their nets add up to its amount.
+// Balance transactions can't be listed per payout for manual payouts, so
+// they're reconciled per period with ReconcilePeriod instead.
+//
+// Errors are only returned for failed requests and, as *ManualPayoutError,
+// for manual payouts. Discrepancies are in the ledger.
+func (c Client) ReconcilePayout(id string, params *PayoutParams) (*Ledger, error) {
+	if params == nil {
+		params = &PayoutParams{}
+	}
+
+	payoutParams := &stripe.PayoutParams{Params: params.Params}
+	p, err := payout.Client{B: c.B, Key: c.Key}.Get(id, payoutParams)
+	if err != nil {
+		return nil, err
+	}
+	if !p.Automatic {
+		return nil, &ManualPayoutError{Payout: p}
+	}
+
+	listParams := &stripe.BalanceTransactionListParams{Payout: stripe.String(id)}
+	ledger := &Ledger{Payout: p}
+	err = c.walk(listParams, params.Params, func(bt *stripe.BalanceTransaction) {
+		if bt.Type == stripe.BalanceTransactionTypePayout && bt.Source != nil && bt.Source.ID == id {
+			ledger.PayoutTransaction = bt
+			return
+		}
+		ledger.add(bt)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ledger.computeTotals()
+	ledger.reconcilePayout()
+	return ledger, nil
+}
+
+// ReconcilePeriod builds the ledger of a period. See Client.ReconcilePeriod.
+func ReconcilePeriod(params *PeriodParams) (*Ledger, error) {
+	return getC().ReconcilePeriod(params)
+}
+
+// ReconcilePeriod builds the ledger of the balance transactions created in a
+// period, including payouts, and checks their amounts.
+//
+// Errors are only returned for failed requests. Discrepancies are in the
+// ledger.
+func (c Client) ReconcilePeriod(params *PeriodParams) (*Ledger, error) {
+	if params == nil || params.Start.IsZero() || params.End.IsZero() {
+		return nil, errors.New("reconciliation: params.Start and params.End must be set")
+	}
+
+	listParams := &stripe.BalanceTransactionListParams{
+		CreatedRange: &stripe.RangeQueryParams{
+			GreaterThanOrEqual: params.Start.Unix(),
+			LesserThan:         params.End.Unix(),
+		},
+	}
+	if params.Currency != "" {
+		listParams.Currency = stripe.String(string(params.Currency))
+	}
+
+	ledger := &Ledger{}
+	if err := c.walk(listParams, params.Params, ledger.add); err != nil {
+		return nil, err
+	}
+
+	ledger.computeTotals()
+	return ledger, nil
+}
+
+//
+// Private functions
+//
+
+func getC() Client {
+	return Client{stripe.GetBackend(stripe.APIBackend), stripe.Key}
+}
+
+// walk lists balance transactions with their source expanded.
+func (c Client) walk(listParams *stripe.BalanceTransactionListParams, params stripe.Params, f func(*stripe.BalanceTransaction)) error {
+	listParams.Context = params.Context
+	listParams.StripeAccount = params.StripeAccount
+	listParams.AddExpand("data.source")
+
+	i := balancetransaction.Client{B: c.B, Key: c.Key}.List(listParams)
+	for i.Next() {
+		f(i.BalanceTransaction())
+	}
+	return i.Err()
+}
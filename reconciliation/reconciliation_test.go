@@ -0,0 +1,124 @@
+package reconciliation_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+	stripe "github.com/stripe/stripe-go/v81"
+	"github.com/stripe/stripe-go/v81/reconciliation"
+	"github.com/stripe/stripe-go/v81/stripetest"
+)
+
+const testBalanceTransactions = `{"object":"list","has_more":false,"data":[
+	{"id":"txn_payout","type":"payout","currency":"usd","amount":-9741,"fee":0,"net":-9741,"reporting_category":"payout",
+		"source":{"id":"po_123","object":"payout"}},
+	{"id":"txn_charge","type":"charge","currency":"usd","amount":10000,"fee":320,"net":9680,"reporting_category":"charge",
+		"fee_details":[{"amount":320,"type":"stripe_fee"}],
+		"source":{"id":"ch_123","object":"charge"}},
+	{"id":"txn_refund","type":"refund","currency":"usd","amount":-1000,"fee":0,"net":-1000,"reporting_category":"refund",
+		"source":{"id":"re_123","object":"refund","charge":"ch_456"}},
+	{"id":"txn_fee","type":"application_fee","currency":"usd","amount":1061,"fee":0,"net":1061,"reporting_category":"platform_earning",
+		"source":{"id":"fee_123","object":"application_fee","charge":"ch_789","account":"acct_123"}}
+]}`
+
+func TestReconcilePayout(t *testing.T) {
+	ts := newTestServer(t, `{"id":"po_123","object":"payout","automatic":true,"amount":9741,"currency":"usd"}`)
+	defer ts.Close()
+
+	c := reconciliation.Client{B: stripetest.NewBackend(stripe.APIBackend, ts.URL), Key: "sk_test_123"}
+	ledger, err := c.ReconcilePayout("po_123", nil)
+	assert.NoError(t, err)
+	assert.True(t, ledger.IsReconciled(), "%v", ledger.Discrepancies)
+
+	assert.Equal(t, "txn_payout", ledger.PayoutTransaction.ID)
+	assert.Equal(t, 3, len(ledger.Entries))
+	assert.Equal(t, "ch_123", ledger.Entries[0].ChargeID)
+	assert.Equal(t, "ch_456", ledger.Entries[1].ChargeID)
+	assert.Equal(t, "ch_789", ledger.Entries[2].ChargeID)
+	assert.Equal(t, "acct_123", ledger.Entries[2].ConnectedAccountID)
+
+	assert.Equal(t, []*reconciliation.Total{
+		{Amount: 10000, Count: 1, Currency: "usd", Fee: 320, Net: 9680, ReportingCategory: "charge"},
+		{Amount: 1061, Count: 1, Currency: "usd", Net: 1061, ReportingCategory: "platform_earning"},
+		{Amount: -1000, Count: 1, Currency: "usd", Net: -1000, ReportingCategory: "refund"},
+	}, ledger.Totals)
+	assert.Equal(t, int64(9741), ledger.Net(stripe.CurrencyUSD))
+}
+
+func TestReconcilePayoutDiscrepancies(t *testing.T) {
+	ts := newTestServer(t, `{"id":"po_123","object":"payout","automatic":true,"amount":10000,"currency":"usd"}`)
+	defer ts.Close()
+
+	c := reconciliation.Client{B: stripetest.NewBackend(stripe.APIBackend, ts.URL), Key: "sk_test_123"}
+	ledger, err := c.ReconcilePayout("po_123", nil)
+	assert.NoError(t, err)
+	assert.False(t, ledger.IsReconciled())
+
+	assert.Equal(t, 2, len(ledger.Discrepancies))
+	assert.Equal(t, reconciliation.DiscrepancyTypePayoutAmount, ledger.Discrepancies[0].Type)
+	assert.Equal(t, int64(10000), ledger.Discrepancies[0].Expected)
+	assert.Equal(t, int64(9741), ledger.Discrepancies[0].Actual)
+	assert.Nil(t, ledger.Discrepancies[0].BalanceTransaction)
+	assert.Equal(t, reconciliation.DiscrepancyTypePayoutTransaction, ledger.Discrepancies[1].Type)
+	assert.Equal(t, "txn_payout", ledger.Discrepancies[1].BalanceTransaction.ID)
+}
+
+func TestReconcilePayoutManual(t *testing.T) {
+	ts := newTestServer(t, `{"id":"po_123","object":"payout","automatic":false}`)
+	defer ts.Close()
+
+	c := reconciliation.Client{B: stripetest.NewBackend(stripe.APIBackend, ts.URL), Key: "sk_test_123"}
+	_, err := c.ReconcilePayout("po_123", nil)
+	assert.EqualError(t, err, "reconciliation: payout po_123 is manual, so its balance transactions can't be listed")
+
+	var manualErr *reconciliation.ManualPayoutError
+	assert.True(t, errors.As(err, &manualErr))
+	assert.Equal(t, "po_123", manualErr.Payout.ID)
+}
+
+func TestReconcilePeriod(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/balance_transactions", r.URL.Path)
+		assert.Equal(t, "1704067200", r.URL.Query().Get("created[gte]"))
+		assert.Equal(t, "1704153600", r.URL.Query().Get("created[lt]"))
+		fmt.Fprint(w, `{"object":"list","has_more":false,"data":[
+			{"id":"txn_1","currency":"usd","amount":1000,"fee":59,"net":900,"reporting_category":"charge",
+				"fee_details":[{"amount":50}]}
+		]}`)
+	}))
+	defer ts.Close()
+
+	c := reconciliation.Client{B: stripetest.NewBackend(stripe.APIBackend, ts.URL), Key: "sk_test_123"}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ledger, err := c.ReconcilePeriod(&reconciliation.PeriodParams{Start: start, End: start.AddDate(0, 0, 1)})
+	assert.NoError(t, err)
+	assert.Nil(t, ledger.Payout)
+
+	assert.Equal(t, 2, len(ledger.Discrepancies))
+	assert.Equal(t, reconciliation.DiscrepancyTypeNet, ledger.Discrepancies[0].Type)
+	assert.Equal(t, int64(941), ledger.Discrepancies[0].Expected)
+	assert.Equal(t, reconciliation.DiscrepancyTypeFeeDetails, ledger.Discrepancies[1].Type)
+
+	_, err = c.ReconcilePeriod(nil)
+	assert.Error(t, err)
+}
+
+func newTestServer(t *testing.T, payout string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/payouts/po_123":
+			fmt.Fprint(w, payout)
+		case "/v1/balance_transactions":
+			assert.Equal(t, "po_123", r.URL.Query().Get("payout"))
+			assert.Equal(t, "data.source", r.URL.Query().Get("expand[0]"))
+			fmt.Fprint(w, testBalanceTransactions)
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+}
@@ -96,7 +96,7 @@ type RefundParams struct {
 	// For payment methods without native refund support (e.g., Konbini, PromptPay), use this email from the customer to receive refund instructions.
 	InstructionsEmail *string `form:"instructions_email"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format. Individual keys can be unset by posting an empty value to them. All keys can be unset by posting an empty value to `metadata`.
-	Metadata map[string]string `form:"metadata"`
+	Metadata Metadata `form:"metadata"`
 	// Origin of the refund
 	Origin *string `form:"origin"`
 	// The identifier of the PaymentIntent to refund.
@@ -119,7 +119,7 @@ func (p *RefundParams) AddExpand(f string) {
 // AddMetadata adds a new key-value pair to the Metadata.
 func (p *RefundParams) AddMetadata(key string, value string) {
 	if p.Metadata == nil {
-		p.Metadata = make(map[string]string)
+		p.Metadata = make(Metadata)
 	}
 
 	p.Metadata[key] = value
@@ -319,7 +319,7 @@ type Refund struct {
 	// For payment methods without native refund support (for example, Konbini, PromptPay), provide an email address for the customer to receive refund instructions.
 	InstructionsEmail string `json:"instructions_email"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format.
-	Metadata   map[string]string `json:"metadata"`
+	Metadata   Metadata          `json:"metadata"`
 	NextAction *RefundNextAction `json:"next_action"`
 	// String representing the object's type. Objects of the same type share the same value.
 	Object string `json:"object"`
@@ -0,0 +1,91 @@
+package reportrun
+
+import (
+	"time"
+
+	stripe "github.com/stripe/stripe-go/v81"
+)
+
+//
+// Public constants
+//
+
+// Report types of which the rows are decoded by the row types of this
+// package, in the versions they were written for. Other versions of the same
+// reports can be decoded too, as long as they have the same columns.
+const (
+	ReportTypeBalanceChangeFromActivityItemized = "balance_change_from_activity.itemized.3"
+	ReportTypePayoutReconciliationItemized      = "payout_reconciliation.itemized.5"
+)
+
+//
+// Public types
+//
+
+// BalanceChangeRow is a row of an itemized balance change from activity
+// report, run with ReportTypeBalanceChangeFromActivityItemized: a balance
+// transaction, with its amounts in major units of its currency.
+type BalanceChangeRow struct {
+	AvailableOn            time.Time                                  `csv:"available_on_utc"`
+	BalanceTransactionID   string                                     `csv:"balance_transaction_id"`
+	Created                time.Time                                  `csv:"created_utc"`
+	Currency               stripe.Currency                            `csv:"currency"`
+	CustomerFacingAmount   *stripe.Decimal                            `csv:"customer_facing_amount"`
+	CustomerFacingCurrency stripe.Currency                            `csv:"customer_facing_currency"`
+	Description            string                                     `csv:"description"`
+	Fee                    stripe.Decimal                             `csv:"fee"`
+	Gross                  stripe.Decimal                             `csv:"gross"`
+	Net                    stripe.Decimal                             `csv:"net"`
+	ReportingCategory      stripe.BalanceTransactionReportingCategory `csv:"reporting_category"`
+	SourceID               string                                     `csv:"source_id"`
+}
+
+// ItemizedFeeRow is a row of an itemized balance change from activity report
+// run for the `fee` reporting category, like with ItemizedFeesParams: a fee
+// charged by Stripe separately from payments, like for Billing or Radar,
+// with its amount as a negative Net in major units of its currency. Fees of
+// payments are in the Fee of their BalanceChangeRow instead.
+type ItemizedFeeRow struct {
+	BalanceTransactionID string          `csv:"balance_transaction_id"`
+	Created              time.Time       `csv:"created_utc"`
+	Currency             stripe.Currency `csv:"currency"`
+	Description          string          `csv:"description"`
+	Net                  stripe.Decimal  `csv:"net"`
+	SourceID             string          `csv:"source_id"`
+}
+
+// PayoutReconciliationRow is a row of an itemized payout reconciliation
+// report, run with ReportTypePayoutReconciliationItemized: a balance
+// transaction, along with the automatic payout which settled it, if any.
+type PayoutReconciliationRow struct {
+	BalanceChangeRow
+
+	AutomaticPayoutEffectiveAt *time.Time `csv:"automatic_payout_effective_at_utc"`
+	AutomaticPayoutID          string     `csv:"automatic_payout_id"`
+}
+
+//
+// Public functions
+//
+
+// ItemizedFeesParams returns the parameters of a report run itemizing the
+// fees charged by Stripe separately from payments between two times, to be
+// decoded into ItemizedFeeRows. The interval end is exclusive.
+func ItemizedFeesParams(intervalStart, intervalEnd time.Time) *stripe.ReportingReportRunParams {
+	return &stripe.ReportingReportRunParams{
+		Parameters: &stripe.ReportingReportRunParametersParams{
+			Columns: stripe.StringSlice([]string{
+				"balance_transaction_id",
+				"created_utc",
+				"currency",
+				"description",
+				"net",
+				"source_id",
+			}),
+			IntervalEnd:       stripe.Int64(intervalEnd.Unix()),
+			IntervalStart:     stripe.Int64(intervalStart.Unix()),
+			ReportingCategory: stripe.String(string(stripe.BalanceTransactionReportingCategoryFee)),
+		},
+		ReportType: stripe.String(ReportTypeBalanceChangeFromActivityItemized),
+	}
+}
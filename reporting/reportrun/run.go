@@ -0,0 +1,233 @@
+package reportrun
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	stripe "github.com/stripe/stripe-go/v81"
+	"github.com/stripe/stripe-go/v81/file"
+)
+
+//
+// Public constants
+//
+
+// DefaultWaitPollInterval is the interval at which RunAndWait retrieves the
+// report run when RunAndWaitParams.PollInterval isn't set. Report runs
+// usually take from a few seconds to a few minutes.
+const DefaultWaitPollInterval = 10 * time.Second
+
+//
+// Public types
+//
+
+// ResultParams are the parameters of downloading the result of a report run.
+type ResultParams struct {
+	file.DownloadParams
+
+	// Files is the client used to download the result file.
+	//
+	// Defaults to a client with the uploads backend from
+	// stripe.GetBackend, and the backend and key of the report run client.
+	Files *file.Client
+}
+
+// RunAndWaitParams configures RunAndWait.
+type RunAndWaitParams struct {
+	// Events is an optional channel of events, like those received by a
+	// webhook endpoint, which are consumed along with polling so that the
+	// wait ends as soon as a `reporting.report_run.succeeded` or
+	// `reporting.report_run.failed` event of the report run is received.
+	// Events of other objects are ignored.
+	Events <-chan *stripe.Event
+
+	// PollInterval is the interval at which the report run is retrieved
+	// while it's pending. Set to a negative value to only rely on Events.
+	//
+	// Defaults to DefaultWaitPollInterval.
+	PollInterval time.Duration
+
+	// ReportRunParams are the parameters used to create the report run. Their
+	// Context is used for the whole wait, and their StripeAccount for
+	// retrieving the report run too.
+	ReportRunParams *stripe.ReportingReportRunParams
+
+	// Timeout is the maximum duration of the wait, after which the report
+	// run is returned as last retrieved, along with
+	// context.DeadlineExceeded. Waits are otherwise only bounded by the
+	// context of ReportRunParams, and by Events being closed when only relying
+	// on them.
+	Timeout time.Duration
+}
+
+// RunError is the error returned when a report run failed.
+type RunError struct {
+	ReportRun *stripe.ReportingReportRun
+}
+
+// Error returns the error of the report run.
+func (e *RunError) Error() string {
+	return fmt.Sprintf("reportrun: report run %s failed: %s", e.ReportRun.ID, e.ReportRun.Error)
+}
+
+//
+// Public functions
+//
+
+// DecodeResult downloads the result of a succeeded report run and decodes
+// its rows. See Client.DecodeResult.
+func DecodeResult(run *stripe.ReportingReportRun, rows interface{}, params *ResultParams) error {
+	return getC().DecodeResult(run, rows, params)
+}
+
+// DecodeResult downloads the CSV result of a succeeded report run and decodes
+// its rows into rows, which must be a pointer to a slice of structs, like
+// BalanceChangeRow, or of map[string]string. See stripe.CSVDecoder for how
+// columns are matched to fields.
+func (c Client) DecodeResult(run *stripe.ReportingReportRun, rows interface{}, params *ResultParams) error {
+	r, err := c.OpenResult(run, params)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	return stripe.NewCSVDecoder(r).DecodeAll(rows)
+}
+
+// OpenResult opens the result of a succeeded report run for streaming. See
+// Client.OpenResult.
+func OpenResult(run *stripe.ReportingReportRun, params *ResultParams) (*file.DownloadReader, error) {
+	return getC().OpenResult(run, params)
+}
+
+// OpenResult opens the result file of a succeeded report run for streaming
+// from the files backend, without loading it into memory. Decode its rows
+// with stripe.NewCSVDecoder.
+func (c Client) OpenResult(run *stripe.ReportingReportRun, params *ResultParams) (*file.DownloadReader, error) {
+	if run == nil || run.Status != stripe.ReportingReportRunStatusSucceeded || run.Result == nil {
+		return nil, errors.New("reportrun: report run hasn't succeeded and doesn't have a result")
+	}
+
+	files := &file.Client{B: c.B, BUploads: stripe.GetBackend(stripe.UploadsBackend), Key: c.Key}
+	var downloadParams *file.DownloadParams
+	if params != nil {
+		downloadParams = &params.DownloadParams
+		if params.Files != nil {
+			files = params.Files
+		}
+	}
+	return files.Download(run.Result.ID, downloadParams)
+}
+
+// RunAndWait creates a report run and waits for it to finish. See
+// Client.RunAndWait.
+func RunAndWait(params *RunAndWaitParams) (*stripe.ReportingReportRun, error) {
+	return getC().RunAndWait(params)
+}
+
+// RunAndWait creates a report run, then waits until it's no longer pending,
+// either by polling or by consuming events, and returns it. Its result can
+// then be read with OpenResult or DecodeResult.
+//
+// A *RunError is returned along with the report run when it failed. Errors
+// are also returned for failed requests, and when the wait is over before
+// the report run finished, along with the report run as last retrieved.
+func (c Client) RunAndWait(params *RunAndWaitParams) (*stripe.ReportingReportRun, error) {
+	if params == nil {
+		params = &RunAndWaitParams{}
+	}
+	runParams := params.ReportRunParams
+	if runParams == nil {
+		runParams = &stripe.ReportingReportRunParams{}
+	}
+
+	ctx := runParams.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if params.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, params.Timeout)
+		defer cancel()
+	}
+
+	pollInterval := params.PollInterval
+	if pollInterval == 0 {
+		pollInterval = DefaultWaitPollInterval
+	}
+
+	run, err := c.New(runParams)
+	if err != nil {
+		return nil, err
+	}
+
+	events := params.Events
+	for run.Status == stripe.ReportingReportRunStatusPending {
+		var poll <-chan time.Time
+		if pollInterval > 0 {
+			poll = time.After(pollInterval)
+		} else if events == nil {
+			return run, errors.New("reportrun: no more events to wait for the report run")
+		}
+
+		select {
+		case <-ctx.Done():
+			return run, ctx.Err()
+
+		case <-poll:
+			getParams := &stripe.ReportingReportRunParams{}
+			getParams.Context = ctx
+			getParams.StripeAccount = runParams.StripeAccount
+			latest, err := c.Get(run.ID, getParams)
+			if err != nil {
+				// Report the end of the wait rather than the request it
+				// interrupted.
+				if ctx.Err() != nil {
+					return run, ctx.Err()
+				}
+				return run, err
+			}
+			run = latest
+
+		case e, ok := <-events:
+			if !ok {
+				// Receiving from a nil channel blocks, so this stops
+				// consuming events.
+				events = nil
+				continue
+			}
+			if latest := eventReportRun(e, run.ID); latest != nil {
+				run = latest
+			}
+		}
+	}
+
+	if run.Status == stripe.ReportingReportRunStatusFailed {
+		return run, &RunError{ReportRun: run}
+	}
+	return run, nil
+}
+
+//
+// Private functions
+//
+
+// eventReportRun returns the report run of an event if it's a
+// `reporting.report_run.*` event of the report run with the given ID.
+func eventReportRun(e *stripe.Event, id string) *stripe.ReportingReportRun {
+	if e == nil || e.Data == nil {
+		return nil
+	}
+	if e.Type != stripe.EventTypeReportingReportRunSucceeded && e.Type != stripe.EventTypeReportingReportRunFailed {
+		return nil
+	}
+
+	run := &stripe.ReportingReportRun{}
+	if err := json.Unmarshal(e.Data.Raw, run); err != nil || run.ID != id {
+		return nil
+	}
+	return run
+}
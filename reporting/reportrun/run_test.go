@@ -0,0 +1,123 @@
+package reportrun_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+	stripe "github.com/stripe/stripe-go/v81"
+	"github.com/stripe/stripe-go/v81/file"
+	"github.com/stripe/stripe-go/v81/reporting/reportrun"
+	"github.com/stripe/stripe-go/v81/stripetest"
+)
+
+const testBalanceChangeCSV = `balance_transaction_id,created_utc,available_on_utc,currency,gross,fee,net,reporting_category,source_id,description,customer_facing_amount,customer_facing_currency
+txn_1,2024-01-02 03:04:05,2024-01-04 00:00:00,usd,10.00,0.59,9.41,charge,ch_1,Order 1,10.00,usd
+txn_2,2024-01-02 04:00:00,2024-01-04 00:00:00,usd,-10.00,0.00,-10.00,refund,re_1,,,
+`
+
+func TestRunAndWaitPolls(t *testing.T) {
+	var gets int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost:
+			assert.NoError(t, r.ParseForm())
+			assert.Equal(t, reportrun.ReportTypeBalanceChangeFromActivityItemized, r.PostForm.Get("report_type"))
+			fmt.Fprint(w, `{"id":"frr_123","status":"pending"}`)
+		case atomic.AddInt32(&gets, 1) < 2:
+			fmt.Fprint(w, `{"id":"frr_123","status":"pending"}`)
+		default:
+			fmt.Fprint(w, `{"id":"frr_123","status":"succeeded","result":{"id":"file_123"}}`)
+		}
+	}))
+	defer ts.Close()
+
+	c := reportrun.Client{B: stripetest.NewBackend(stripe.APIBackend, ts.URL), Key: "sk_test_123"}
+	run, err := c.RunAndWait(&reportrun.RunAndWaitParams{
+		PollInterval: time.Millisecond,
+		ReportRunParams: &stripe.ReportingReportRunParams{
+			ReportType: stripe.String(reportrun.ReportTypeBalanceChangeFromActivityItemized),
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, stripe.ReportingReportRunStatusSucceeded, run.Status)
+	assert.Equal(t, "file_123", run.Result.ID)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&gets))
+}
+
+func TestRunAndWaitEvents(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		fmt.Fprint(w, `{"id":"frr_123","status":"pending"}`)
+	}))
+	defer ts.Close()
+
+	events := make(chan *stripe.Event, 2)
+	events <- newReportRunEvent(t, stripe.EventTypeReportingReportRunFailed, `{"id":"frr_456","status":"failed"}`)
+	events <- newReportRunEvent(t, stripe.EventTypeReportingReportRunFailed, `{"id":"frr_123","status":"failed","error":"Internal error"}`)
+
+	c := reportrun.Client{B: stripetest.NewBackend(stripe.APIBackend, ts.URL), Key: "sk_test_123"}
+	run, err := c.RunAndWait(&reportrun.RunAndWaitParams{
+		Events:       events,
+		PollInterval: -1,
+	})
+	assert.EqualError(t, err, "reportrun: report run frr_123 failed: Internal error")
+	_, ok := err.(*reportrun.RunError)
+	assert.True(t, ok)
+	assert.Equal(t, stripe.ReportingReportRunStatusFailed, run.Status)
+}
+
+func TestDecodeResult(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/files/file_123/contents", r.URL.Path)
+		fmt.Fprint(w, testBalanceChangeCSV)
+	}))
+	defer ts.Close()
+
+	c := reportrun.Client{Key: "sk_test_123"}
+	params := &reportrun.ResultParams{
+		Files: &file.Client{BUploads: stripetest.NewBackend(stripe.UploadsBackend, ts.URL), Key: "sk_test_123"},
+	}
+	run := &stripe.ReportingReportRun{
+		ID:     "frr_123",
+		Result: &stripe.File{ID: "file_123"},
+		Status: stripe.ReportingReportRunStatusSucceeded,
+	}
+
+	var rows []reportrun.BalanceChangeRow
+	assert.NoError(t, c.DecodeResult(run, &rows, params))
+	assert.Equal(t, 2, len(rows))
+	assert.Equal(t, "txn_1", rows[0].BalanceTransactionID)
+	assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), rows[0].Created)
+	assert.Equal(t, "9.41", rows[0].Net.String())
+	assert.Equal(t, "10", rows[0].CustomerFacingAmount.String())
+	assert.Equal(t, stripe.BalanceTransactionReportingCategoryRefund, rows[1].ReportingCategory)
+	assert.Nil(t, rows[1].CustomerFacingAmount)
+
+	var payouts []reportrun.PayoutReconciliationRow
+	assert.NoError(t, c.DecodeResult(run, &payouts, params))
+	assert.Equal(t, "txn_2", payouts[1].BalanceTransactionID)
+	assert.Nil(t, payouts[1].AutomaticPayoutEffectiveAt)
+
+	run.Status = stripe.ReportingReportRunStatusPending
+	assert.Error(t, c.DecodeResult(run, &rows, params))
+}
+
+func TestItemizedFeesParams(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	params := reportrun.ItemizedFeesParams(start, start.AddDate(0, 1, 0))
+	assert.Equal(t, reportrun.ReportTypeBalanceChangeFromActivityItemized, *params.ReportType)
+	assert.Equal(t, "fee", *params.Parameters.ReportingCategory)
+	assert.Equal(t, int64(1704067200), *params.Parameters.IntervalStart)
+}
+
+func newReportRunEvent(t *testing.T, eventType stripe.EventType, run string) *stripe.Event {
+	e := &stripe.Event{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"type":"`+string(eventType)+`","data":{"object":`+run+`}}`), e))
+	return e
+}
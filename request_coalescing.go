@@ -0,0 +1,119 @@
+package stripe
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/stripe/stripe-go/v81/form"
+)
+
+//
+// Private variables
+//
+
+// errRequestNotCompleted is returned to the callers waiting on a coalesced
+// request which didn't complete.
+var errRequestNotCompleted = errors.New("stripe: coalesced request did not complete")
+
+//
+// Private types
+//
+
+// inflightRequest is a GET request in flight whose outcome is shared with
+// the identical requests made while it's running.
+type inflightRequest struct {
+	done     chan struct{}
+	err      error
+	response *APIResponse
+}
+
+// requestGroup deduplicates identical GET requests made concurrently, in the
+// style of a singleflight group.
+type requestGroup struct {
+	mu       sync.Mutex
+	requests map[string]*inflightRequest
+}
+
+//
+// Private functions
+//
+
+// callCoalesced makes a GET request, unless an identical one is already in
+// flight in which case it waits for that one and decodes its response into v.
+func (s *BackendImplementation) callCoalesced(path, key string, body *form.Values, params *Params, v LastResponseSetter) error {
+	requestKey := newRequestKey(path, key, body, params)
+
+	g := s.inflight
+	g.mu.Lock()
+	if g.requests == nil {
+		g.requests = make(map[string]*inflightRequest)
+	}
+	if req, ok := g.requests[requestKey]; ok {
+		g.mu.Unlock()
+		return s.waitForRequest(req, path, key, body, params, v)
+	}
+	req := &inflightRequest{done: make(chan struct{})}
+	g.requests[requestKey] = req
+	g.mu.Unlock()
+
+	// Release the waiting callers even if the request panics, in which case
+	// they see an error rather than blocking forever.
+	req.err = errRequestNotCompleted
+	defer func() {
+		g.mu.Lock()
+		delete(g.requests, requestKey)
+		g.mu.Unlock()
+		close(req.done)
+	}()
+
+	setter := &capturingResponseSetter{LastResponseSetter: v}
+	err := s.CallRaw(http.MethodGet, path, key, body, params, setter)
+	req.err, req.response = err, setter.response
+	return err
+}
+
+// waitForRequest waits for an in flight request and decodes its response
+// into v. If the request failed because its own context was canceled while
+// the waiting caller's context is still live, the request is made again on
+// behalf of the caller.
+func (s *BackendImplementation) waitForRequest(req *inflightRequest, path, key string, body *form.Values, params *Params, v LastResponseSetter) error {
+	ctx := context.Background()
+	if params != nil && params.Context != nil {
+		ctx = params.Context
+	}
+
+	select {
+	case <-req.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	if req.err != nil {
+		if isContextError(req.err) && ctx.Err() == nil {
+			return s.CallRaw(http.MethodGet, path, key, body, params, v)
+		}
+		return req.err
+	}
+
+	if req.response == nil {
+		// Shouldn't happen since the backend always sets the last response
+		// on success, but make the request again rather than return nothing.
+		return s.CallRaw(http.MethodGet, path, key, body, params, v)
+	}
+
+	if err := json.Unmarshal(req.response.RawJSON, v); err != nil {
+		return err
+	}
+	response := *req.response
+	v.SetLastResponse(&response)
+	return nil
+}
+
+// isContextError returns whether err was caused by the request's context
+// being canceled or timing out.
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
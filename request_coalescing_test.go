@@ -0,0 +1,151 @@
+package stripe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestRequestCoalescing(t *testing.T) {
+	var requests int32
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&requests, 1)
+		<-release
+		fmt.Fprintf(w, `{"id":"cus_123","object":"customer","name":"v%d"}`, n)
+	}))
+	defer ts.Close()
+
+	backend := newCoalescingTestBackend(ts.URL)
+
+	const callers = 5
+	customers := make([]*Customer, callers)
+	errs := make([]error, callers)
+
+	var wg sync.WaitGroup
+	call := func(i int) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			customers[i] = &Customer{}
+			errs[i] = backend.Call(http.MethodGet, "/v1/customers/cus_123", "sk_test_123", nil, customers[i])
+		}()
+	}
+	call(0)
+	waitForRequests(t, &requests, 1)
+	for i := 1; i < callers; i++ {
+		call(i)
+	}
+
+	// Give the other callers time to wait on the request in flight before
+	// letting it complete. Any not coalesced with it would make their own
+	// request, counted by the server.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+	for i := 0; i < callers; i++ {
+		assert.NoError(t, errs[i])
+		assert.Equal(t, "v1", customers[i].Name)
+		assert.NotNil(t, customers[i].LastResponse)
+	}
+	assert.NotSame(t, customers[0], customers[1])
+	assert.NotSame(t, customers[0].LastResponse, customers[1].LastResponse)
+
+	// Requests made once the first has completed aren't coalesced with it.
+	customer := &Customer{}
+	err := backend.Call(http.MethodGet, "/v1/customers/cus_123", "sk_test_123", nil, customer)
+	assert.NoError(t, err)
+	assert.Equal(t, "v2", customer.Name)
+}
+
+func TestRequestCoalescingDistinctRequests(t *testing.T) {
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write([]byte(`{"id":"cus_123","object":"customer"}`))
+	}))
+	defer ts.Close()
+
+	backend := newCoalescingTestBackend(ts.URL)
+
+	params := &CustomerParams{}
+	params.SetStripeAccount("acct_123")
+	err := backend.Call(http.MethodGet, "/v1/customers/cus_123", "sk_test_123", params, &Customer{})
+	assert.NoError(t, err)
+
+	err = backend.Call(http.MethodPost, "/v1/customers/cus_123", "sk_test_123", &CustomerParams{}, &Customer{})
+	assert.NoError(t, err)
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+	assert.Equal(t, 0, len(backend.inflight.requests))
+}
+
+func TestRequestCoalescingCanceledLeader(t *testing.T) {
+	var requests int32
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			<-release
+		}
+		w.Write([]byte(`{"id":"cus_123","object":"customer"}`))
+	}))
+	defer ts.Close()
+	defer close(release)
+
+	backend := newCoalescingTestBackend(ts.URL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		params := &CustomerParams{}
+		params.Context = ctx
+		leaderErr <- backend.Call(http.MethodGet, "/v1/customers/cus_123", "sk_test_123", params, &Customer{})
+	}()
+	waitForRequests(t, &requests, 1)
+
+	followerErr := make(chan error, 1)
+	customer := &Customer{}
+	go func() {
+		followerErr <- backend.Call(http.MethodGet, "/v1/customers/cus_123", "sk_test_123", nil, customer)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+	// The follower's own context is still live, so it makes the request
+	// itself rather than failing along with the leader.
+	cancel()
+	assert.Error(t, <-leaderErr)
+	assert.NoError(t, <-followerErr)
+	assert.Equal(t, "cus_123", customer.ID)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
+
+func newCoalescingTestBackend(url string) *BackendImplementation {
+	return GetBackendWithConfig(APIBackend, &BackendConfig{
+		EnableRequestCoalescing: Bool(true),
+		LeveledLogger:           &LeveledLogger{Level: LevelNull},
+		MaxNetworkRetries:       Int64(0),
+		URL:                     String(url),
+	}).(*BackendImplementation)
+}
+
+// waitForRequests waits until the server has received n requests.
+func waitForRequests(t *testing.T, requests *int32, n int32) {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if atomic.LoadInt32(requests) >= n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d requests", n)
+}
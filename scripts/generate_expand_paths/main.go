@@ -0,0 +1,419 @@
+// A script that generates `expand_paths.go`, which lists for every API
+// resource the fields that can be expanded as typed `stripe.ExpandPath`
+// values, and `expandable.go`, which implements `stripe.Expandable` for the
+// types of those fields.
+//
+// A field can be expanded if its type is (a pointer to, or a slice of
+// pointers to) a type whose `UnmarshalJSON` accepts a bare ID through
+// `ParseID`. Such a type can be loaded with `stripe.Load` if a client package
+// has a `Get` method retrieving it by its ID alone. Run it from the root of
+// the repository:
+//
+//	go generate .
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	fset := token.NewFileSet()
+
+	paths, err := filepath.Glob("*.go")
+	if err != nil {
+		exitWithError(err)
+	}
+
+	var files []*ast.File
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") || path == outputPath || path == expandableOutputPath {
+			continue
+		}
+
+		f, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			exitWithError(err)
+		}
+		files = append(files, f)
+	}
+
+	expandable := findExpandableTypes(files)
+	resources := findResources(files, expandable)
+
+	source, err := render(resources)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	if err := ioutil.WriteFile(outputPath, source, 0644); err != nil {
+		exitWithError(err)
+	}
+
+	loadPaths, err := findLoadPaths()
+	if err != nil {
+		exitWithError(err)
+	}
+
+	source, err = renderExpandable(findStructs(files), expandable, loadPaths)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	if err := ioutil.WriteFile(expandableOutputPath, source, 0644); err != nil {
+		exitWithError(err)
+	}
+}
+
+//
+// Private
+//
+
+// Paths of the generated files, relative to the root of the repository.
+const (
+	expandableOutputPath = "expandable.go"
+	outputPath           = "expand_paths.go"
+)
+
+type expandField struct {
+	jsonName string
+	name     string
+}
+
+type resource struct {
+	fields []expandField
+	name   string
+}
+
+func exitWithError(err error) {
+	fmt.Fprintf(os.Stderr, "%v\n", err)
+	os.Exit(1)
+}
+
+// findExpandableTypes returns the set of types that have an `UnmarshalJSON`
+// method calling `ParseID`, which is how the library deserializes fields
+// that can either be an ID or an expanded object.
+func findExpandableTypes(files []*ast.File) map[string]bool {
+	expandable := make(map[string]bool)
+
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Recv == nil || funcDecl.Name.Name != "UnmarshalJSON" {
+				continue
+			}
+
+			star, ok := funcDecl.Recv.List[0].Type.(*ast.StarExpr)
+			if !ok {
+				continue
+			}
+			ident, ok := star.X.(*ast.Ident)
+			if !ok {
+				continue
+			}
+
+			ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+				if call, ok := n.(*ast.CallExpr); ok {
+					if fun, ok := call.Fun.(*ast.Ident); ok && fun.Name == "ParseID" {
+						expandable[ident.Name] = true
+					}
+				}
+				return true
+			})
+		}
+	}
+
+	return expandable
+}
+
+// findLoadPaths returns the formats of the paths retrieving resources by
+// their ID alone, from the `Get` methods of the clients of the client
+// packages. Resources retrieved by different paths are left out.
+func findLoadPaths() (map[string]string, error) {
+	loadPaths := make(map[string]string)
+	conflicts := make(map[string]bool)
+
+	err := filepath.Walk(".", func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path != "." && (path == "scripts" || strings.HasPrefix(info.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.Name() != "client.go" || filepath.Dir(path) == "." {
+			return nil
+		}
+
+		f, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+		if err != nil {
+			return err
+		}
+		for _, decl := range f.Decls {
+			name, loadPath := getLoadPath(decl)
+			if name == "" {
+				continue
+			}
+			if existing, ok := loadPaths[name]; ok && existing != loadPath {
+				conflicts[name] = true
+			}
+			loadPaths[name] = loadPath
+		}
+		return nil
+	})
+
+	for name := range conflicts {
+		delete(loadPaths, name)
+	}
+	return loadPaths, err
+}
+
+// findResources returns the API resources, which are the structs embedding
+// `APIResource` (excluding list and search results), along with their
+// expandable fields.
+func findResources(files []*ast.File, expandable map[string]bool) []resource {
+	var resources []resource
+
+	for _, f := range files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			typeSpec, ok := n.(*ast.TypeSpec)
+			if !ok {
+				return true
+			}
+			structType, ok := typeSpec.Type.(*ast.StructType)
+			if !ok || !isResource(structType) {
+				return false
+			}
+
+			r := resource{name: typeSpec.Name.Name}
+			for _, field := range structType.Fields.List {
+				if len(field.Names) != 1 || !isExpandableType(field.Type, expandable) {
+					continue
+				}
+
+				jsonName := jsonFieldName(field)
+				if jsonName == "" {
+					continue
+				}
+				r.fields = append(r.fields, expandField{jsonName: jsonName, name: field.Names[0].Name})
+			}
+
+			if len(r.fields) > 0 {
+				resources = append(resources, r)
+			}
+			return false
+		})
+	}
+
+	sort.Slice(resources, func(i, j int) bool {
+		return resources[i].name < resources[j].name
+	})
+
+	return resources
+}
+
+// findStructs returns the struct types by name.
+func findStructs(files []*ast.File) map[string]*ast.StructType {
+	structs := make(map[string]*ast.StructType)
+
+	for _, f := range files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			typeSpec, ok := n.(*ast.TypeSpec)
+			if !ok {
+				return true
+			}
+			if structType, ok := typeSpec.Type.(*ast.StructType); ok {
+				structs[typeSpec.Name.Name] = structType
+			}
+			return false
+		})
+	}
+
+	return structs
+}
+
+// getLoadPath returns the resource retrieved by a `Get` (or `GetByID`) method
+// of a client taking an ID and parameters, and the format of its path, or empty strings
+// if the declaration isn't one, or if the path needs other IDs.
+func getLoadPath(decl ast.Decl) (string, string) {
+	funcDecl, ok := decl.(*ast.FuncDecl)
+	if !ok || funcDecl.Recv == nil || funcDecl.Body == nil {
+		return "", ""
+	}
+	if funcDecl.Name.Name != "Get" && funcDecl.Name.Name != "GetByID" {
+		return "", ""
+	}
+	if funcDecl.Type.Params.NumFields() != 2 || funcDecl.Type.Results.NumFields() != 2 {
+		return "", ""
+	}
+	star, ok := funcDecl.Type.Results.List[0].Type.(*ast.StarExpr)
+	if !ok {
+		return "", ""
+	}
+	selector, ok := star.X.(*ast.SelectorExpr)
+	if !ok {
+		return "", ""
+	}
+
+	var loadPath string
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		fun, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || fun.Sel.Name != "FormatURLPath" || len(call.Args) != 2 {
+			return true
+		}
+		if lit, ok := call.Args[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+			loadPath, _ = strconv.Unquote(lit.Value)
+		}
+		return false
+	})
+	if strings.Count(loadPath, "%s") != 1 {
+		return "", ""
+	}
+	return selector.Sel.Name, loadPath
+}
+
+func hasField(structType *ast.StructType, name string) bool {
+	for _, field := range structType.Fields.List {
+		for _, ident := range field.Names {
+			if ident.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isExpandableType(expr ast.Expr, expandable map[string]bool) bool {
+	if array, ok := expr.(*ast.ArrayType); ok {
+		expr = array.Elt
+	}
+	star, ok := expr.(*ast.StarExpr)
+	if !ok {
+		return false
+	}
+	ident, ok := star.X.(*ast.Ident)
+	return ok && expandable[ident.Name]
+}
+
+func isResource(structType *ast.StructType) bool {
+	var embedsResource bool
+	for _, field := range structType.Fields.List {
+		if len(field.Names) != 0 {
+			continue
+		}
+		ident, ok := field.Type.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		switch ident.Name {
+		case "APIResource":
+			embedsResource = true
+		case "ListMeta", "SearchMeta":
+			return false
+		}
+	}
+	return embedsResource
+}
+
+func jsonFieldName(field *ast.Field) string {
+	if field.Tag == nil {
+		return ""
+	}
+	tag, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return ""
+	}
+	name := strings.Split(reflect.StructTag(tag).Get("json"), ",")[0]
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+func render(resources []resource) ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteString("// Code generated by scripts/generate_expand_paths; DO NOT EDIT.\n\n")
+	buf.WriteString("package stripe\n")
+
+	for _, r := range resources {
+		fmt.Fprintf(&buf, "\n// %sExpand lists the fields of %s that can be expanded.\n", r.name, r.name)
+		fmt.Fprintf(&buf, "var %sExpand = struct {\n", r.name)
+		for _, f := range r.fields {
+			fmt.Fprintf(&buf, "\t%s ExpandPath\n", f.name)
+		}
+		buf.WriteString("}{\n")
+		for _, f := range r.fields {
+			fmt.Fprintf(&buf, "\t%s: %q,\n", f.name, f.jsonName)
+		}
+		buf.WriteString("}\n")
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+// renderExpandable renders the implementations of `stripe.Expandable`. A type
+// is expanded when its `Object` is set, or its `Type` for the polymorphic
+// types which don't have one.
+func renderExpandable(structs map[string]*ast.StructType, expandable map[string]bool, loadPaths map[string]string) ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteString("// Code generated by scripts/generate_expand_paths; DO NOT EDIT.\n\n")
+	buf.WriteString("package stripe\n")
+
+	var names []string
+	for name := range expandable {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		structType, ok := structs[name]
+		if !ok {
+			continue
+		}
+		var field string
+		switch {
+		case hasField(structType, "Object"):
+			field = "Object"
+		case hasField(structType, "Type"):
+			field = "Type"
+		default:
+			continue
+		}
+		recv := strings.ToLower(name[:1])
+
+		fmt.Fprintf(&buf, "\n// IsExpanded returns whether the %s holds a full object, as opposed to an\n", name)
+		buf.WriteString("// unexpanded reference of which only the ID is populated.\n")
+		fmt.Fprintf(&buf, "func (%s *%s) IsExpanded() bool {\n", recv, name)
+		fmt.Fprintf(&buf, "\treturn %s != nil && %s.%s != \"\"\n", recv, recv, field)
+		buf.WriteString("}\n")
+
+		fmt.Fprintf(&buf, "\nfunc (%s *%s) loadPath() string {\n", recv, name)
+		if loadPath, ok := loadPaths[name]; ok && isResource(structType) {
+			fmt.Fprintf(&buf, "\treturn FormatURLPath(%q, %s.ID)\n", loadPath, recv)
+		} else {
+			buf.WriteString("\treturn \"\"\n")
+		}
+		buf.WriteString("}\n")
+	}
+
+	return format.Source(buf.Bytes())
+}
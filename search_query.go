@@ -0,0 +1,372 @@
+package stripe
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+//
+// Public constants
+//
+
+// MaxSearchClauses is the maximum number of clauses that a search query can
+// contain.
+const MaxSearchClauses = 10
+
+// MinSearchSubstringLength is the minimum length of a value matched as a
+// substring with the `~` operator.
+const MinSearchSubstringLength = 3
+
+//
+// Public types
+//
+
+// SearchBoolField is a boolean field of the search query language.
+type SearchBoolField string
+
+// Is matches objects for which the field has the given value.
+func (f SearchBoolField) Is(v bool) SearchTerm {
+	return SearchTerm{field: string(f), op: ":", value: quoteSearchValue(strconv.FormatBool(v))}
+}
+
+// SearchMetadataField is the metadata of an object in the search query
+// language.
+type SearchMetadataField string
+
+// Key returns the field matching the metadata value with the given key.
+func (f SearchMetadataField) Key(key string) SearchTokenField {
+	return SearchTokenField(string(f) + "[" + quoteSearchString(key, '\'') + "]")
+}
+
+// SearchNumericField is a numeric field of the search query language, like
+// an amount or a timestamp.
+type SearchNumericField string
+
+// GreaterThan matches objects for which the field is greater than v.
+func (f SearchNumericField) GreaterThan(v int64) SearchTerm {
+	return f.term(">", v)
+}
+
+// GreaterThanOrEqual matches objects for which the field is greater than or
+// equal to v.
+func (f SearchNumericField) GreaterThanOrEqual(v int64) SearchTerm {
+	return f.term(">=", v)
+}
+
+// Is matches objects for which the field is equal to v.
+func (f SearchNumericField) Is(v int64) SearchTerm {
+	return f.term(":", v)
+}
+
+// LessThan matches objects for which the field is less than v.
+func (f SearchNumericField) LessThan(v int64) SearchTerm {
+	return f.term("<", v)
+}
+
+// LessThanOrEqual matches objects for which the field is less than or equal
+// to v.
+func (f SearchNumericField) LessThanOrEqual(v int64) SearchTerm {
+	return f.term("<=", v)
+}
+
+func (f SearchNumericField) term(op string, v int64) SearchTerm {
+	return SearchTerm{field: string(f), op: op, value: strconv.FormatInt(v, 10)}
+}
+
+// SearchQueryBuilder is a search query made of terms combined with either
+// AND or OR. The search query language doesn't allow mixing both in the same
+// query, nor grouping terms with parentheses.
+type SearchQueryBuilder struct {
+	op    string
+	terms []SearchTerm
+}
+
+// Apply sets the query on the given search parameters. It returns an error
+// if the query is invalid, in which case the parameters are left untouched.
+func (q *SearchQueryBuilder) Apply(params *SearchParams) error {
+	query, err := q.Build()
+	if err != nil {
+		return err
+	}
+	params.Query = query
+	return nil
+}
+
+// Build returns the query as a string, or an error if it's invalid.
+func (q *SearchQueryBuilder) Build() (string, error) {
+	if len(q.terms) == 0 {
+		return "", fmt.Errorf("search query must contain at least one clause")
+	}
+	if len(q.terms) > MaxSearchClauses {
+		return "", fmt.Errorf("search query contains %d clauses, but at most %d are allowed",
+			len(q.terms), MaxSearchClauses)
+	}
+
+	clauses := make([]string, len(q.terms))
+	for i, t := range q.terms {
+		if t.err != nil {
+			return "", t.err
+		}
+		clauses[i] = t.String()
+	}
+	return strings.Join(clauses, " "+q.op+" "), nil
+}
+
+// String returns the query as a string. Invalid queries are rendered as is,
+// use Build to check for errors.
+func (q *SearchQueryBuilder) String() string {
+	clauses := make([]string, len(q.terms))
+	for i, t := range q.terms {
+		clauses[i] = t.String()
+	}
+	return strings.Join(clauses, " "+q.op+" ")
+}
+
+// SearchStringField is a text field of the search query language which
+// supports both exact and substring matches.
+type SearchStringField string
+
+// Contains matches objects for which the field contains v. The value must
+// be at least MinSearchSubstringLength characters long.
+func (f SearchStringField) Contains(v string) SearchTerm {
+	t := SearchTerm{field: string(f), op: "~", value: quoteSearchValue(v)}
+	if len([]rune(v)) < MinSearchSubstringLength {
+		t.err = fmt.Errorf("substring search on %s must be at least %d characters long, got %q",
+			f, MinSearchSubstringLength, v)
+	}
+	return t
+}
+
+// Is matches objects for which the field is exactly v.
+func (f SearchStringField) Is(v string) SearchTerm {
+	return SearchTokenField(f).Is(v)
+}
+
+// IsNull matches objects for which the field isn't set.
+func (f SearchStringField) IsNull() SearchTerm {
+	return SearchTokenField(f).IsNull()
+}
+
+// SearchTerm is a single clause of a search query, like `status:"active"`.
+type SearchTerm struct {
+	err     error
+	field   string
+	negated bool
+	op      string
+	value   string
+}
+
+// Not returns the term negated, matching the objects that the term doesn't
+// match.
+func (t SearchTerm) Not() SearchTerm {
+	t.negated = !t.negated
+	return t
+}
+
+// String returns the term in the search query language.
+func (t SearchTerm) String() string {
+	s := t.field + t.op + t.value
+	if t.negated {
+		s = "-" + s
+	}
+	return s
+}
+
+// SearchTokenField is a field of the search query language which only
+// supports exact matches, like an ID or an enum.
+type SearchTokenField string
+
+// Is matches objects for which the field is exactly v.
+func (f SearchTokenField) Is(v string) SearchTerm {
+	return SearchTerm{field: string(f), op: ":", value: quoteSearchValue(v)}
+}
+
+// IsNull matches objects for which the field isn't set.
+func (f SearchTokenField) IsNull() SearchTerm {
+	return SearchTerm{field: string(f), op: ":", value: "null"}
+}
+
+//
+// Public variables
+//
+
+// ChargeSearchFields lists the fields that charges can be searched by.
+var ChargeSearchFields = struct {
+	Amount                              SearchNumericField
+	BillingDetailsAddressPostalCode     SearchTokenField
+	Created                             SearchNumericField
+	Currency                            SearchTokenField
+	Customer                            SearchTokenField
+	Disputed                            SearchBoolField
+	Metadata                            SearchMetadataField
+	PaymentMethodDetailsCardBrand       SearchTokenField
+	PaymentMethodDetailsCardExpMonth    SearchNumericField
+	PaymentMethodDetailsCardExpYear     SearchNumericField
+	PaymentMethodDetailsCardFingerprint SearchTokenField
+	PaymentMethodDetailsCardLast4       SearchTokenField
+	Refunded                            SearchBoolField
+	Status                              SearchTokenField
+}{
+	Amount:                              "amount",
+	BillingDetailsAddressPostalCode:     "billing_details.address.postal_code",
+	Created:                             "created",
+	Currency:                            "currency",
+	Customer:                            "customer",
+	Disputed:                            "disputed",
+	Metadata:                            "metadata",
+	PaymentMethodDetailsCardBrand:       "payment_method_details.card.brand",
+	PaymentMethodDetailsCardExpMonth:    "payment_method_details.card.exp_month",
+	PaymentMethodDetailsCardExpYear:     "payment_method_details.card.exp_year",
+	PaymentMethodDetailsCardFingerprint: "payment_method_details.card.fingerprint",
+	PaymentMethodDetailsCardLast4:       "payment_method_details.card.last4",
+	Refunded:                            "refunded",
+	Status:                              "status",
+}
+
+// CustomerSearchFields lists the fields that customers can be searched by.
+var CustomerSearchFields = struct {
+	Created  SearchNumericField
+	Email    SearchStringField
+	Metadata SearchMetadataField
+	Name     SearchStringField
+	Phone    SearchStringField
+}{
+	Created:  "created",
+	Email:    "email",
+	Metadata: "metadata",
+	Name:     "name",
+	Phone:    "phone",
+}
+
+// InvoiceSearchFields lists the fields that invoices can be searched by.
+var InvoiceSearchFields = struct {
+	Created                   SearchNumericField
+	Currency                  SearchTokenField
+	Customer                  SearchTokenField
+	LastFinalizationErrorCode SearchTokenField
+	LastFinalizationErrorType SearchTokenField
+	Metadata                  SearchMetadataField
+	Number                    SearchTokenField
+	ReceiptNumber             SearchTokenField
+	Status                    SearchTokenField
+	Subscription              SearchTokenField
+	Total                     SearchNumericField
+}{
+	Created:                   "created",
+	Currency:                  "currency",
+	Customer:                  "customer",
+	LastFinalizationErrorCode: "last_finalization_error_code",
+	LastFinalizationErrorType: "last_finalization_error_type",
+	Metadata:                  "metadata",
+	Number:                    "number",
+	ReceiptNumber:             "receipt_number",
+	Status:                    "status",
+	Subscription:              "subscription",
+	Total:                     "total",
+}
+
+// PaymentIntentSearchFields lists the fields that payment intents can be
+// searched by.
+var PaymentIntentSearchFields = struct {
+	Amount   SearchNumericField
+	Created  SearchNumericField
+	Currency SearchTokenField
+	Customer SearchTokenField
+	Metadata SearchMetadataField
+	Status   SearchTokenField
+}{
+	Amount:   "amount",
+	Created:  "created",
+	Currency: "currency",
+	Customer: "customer",
+	Metadata: "metadata",
+	Status:   "status",
+}
+
+// PriceSearchFields lists the fields that prices can be searched by.
+var PriceSearchFields = struct {
+	Active    SearchBoolField
+	Currency  SearchTokenField
+	LookupKey SearchTokenField
+	Metadata  SearchMetadataField
+	Product   SearchTokenField
+	Type      SearchTokenField
+}{
+	Active:    "active",
+	Currency:  "currency",
+	LookupKey: "lookup_key",
+	Metadata:  "metadata",
+	Product:   "product",
+	Type:      "type",
+}
+
+// ProductSearchFields lists the fields that products can be searched by.
+var ProductSearchFields = struct {
+	Active      SearchBoolField
+	Created     SearchNumericField
+	Description SearchStringField
+	Metadata    SearchMetadataField
+	Name        SearchStringField
+	Shippable   SearchBoolField
+	URL         SearchTokenField
+}{
+	Active:      "active",
+	Created:     "created",
+	Description: "description",
+	Metadata:    "metadata",
+	Name:        "name",
+	Shippable:   "shippable",
+	URL:         "url",
+}
+
+// SubscriptionSearchFields lists the fields that subscriptions can be
+// searched by.
+var SubscriptionSearchFields = struct {
+	Created  SearchNumericField
+	Metadata SearchMetadataField
+	Status   SearchTokenField
+}{
+	Created:  "created",
+	Metadata: "metadata",
+	Status:   "status",
+}
+
+//
+// Public functions
+//
+
+// SearchAnd returns a query matching the objects that match all the given
+// terms.
+func SearchAnd(terms ...SearchTerm) *SearchQueryBuilder {
+	return &SearchQueryBuilder{op: "AND", terms: terms}
+}
+
+// SearchOr returns a query matching the objects that match any of the given
+// terms.
+func SearchOr(terms ...SearchTerm) *SearchQueryBuilder {
+	return &SearchQueryBuilder{op: "OR", terms: terms}
+}
+
+//
+// Private functions
+//
+
+// quoteSearchString wraps s in the given quote character, escaping any
+// backslash or occurrence of the quote character it contains.
+func quoteSearchString(s string, quote byte) string {
+	var b strings.Builder
+	b.WriteByte(quote)
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' || s[i] == quote {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(s[i])
+	}
+	b.WriteByte(quote)
+	return b.String()
+}
+
+func quoteSearchValue(s string) string {
+	return quoteSearchString(s, '"')
+}
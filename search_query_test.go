@@ -0,0 +1,68 @@
+package stripe
+
+import (
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestSearchAnd(t *testing.T) {
+	q := SearchAnd(
+		CustomerSearchFields.Metadata.Key("order_id").Is("123"),
+		CustomerSearchFields.Email.Contains("example.com"),
+		CustomerSearchFields.Created.GreaterThan(1600000000),
+		CustomerSearchFields.Name.IsNull().Not(),
+	)
+
+	query, err := q.Build()
+	assert.NoError(t, err)
+	assert.Equal(t, `metadata['order_id']:"123" AND email~"example.com" AND created>1600000000 AND -name:null`, query)
+	assert.Equal(t, query, q.String())
+}
+
+func TestSearchOr(t *testing.T) {
+	q := SearchOr(
+		SubscriptionSearchFields.Status.Is("active"),
+		SubscriptionSearchFields.Status.Is("trialing"),
+	)
+
+	params := &SubscriptionSearchParams{}
+	assert.NoError(t, q.Apply(&params.SearchParams))
+	assert.Equal(t, `status:"active" OR status:"trialing"`, params.Query)
+}
+
+func TestSearchTermTypes(t *testing.T) {
+	assert.Equal(t, `active:"true"`, PriceSearchFields.Active.Is(true).String())
+	assert.Equal(t, `amount:500`, ChargeSearchFields.Amount.Is(500).String())
+	assert.Equal(t, `amount>=500`, ChargeSearchFields.Amount.GreaterThanOrEqual(500).String())
+	assert.Equal(t, `amount<500`, ChargeSearchFields.Amount.LessThan(500).String())
+	assert.Equal(t, `amount<=500`, ChargeSearchFields.Amount.LessThanOrEqual(500).String())
+	assert.Equal(t, `-refunded:"false"`, ChargeSearchFields.Refunded.Is(false).Not().String())
+	assert.Equal(t, `status:"succeeded"`, ChargeSearchFields.Status.Is("succeeded").Not().Not().String())
+}
+
+func TestSearchEscaping(t *testing.T) {
+	assert.Equal(t, `name:"Joe \"The Rock\" O'Brien \\ Co"`,
+		CustomerSearchFields.Name.Is(`Joe "The Rock" O'Brien \ Co`).String())
+	assert.Equal(t, `metadata['it\'s']:"yes"`,
+		CustomerSearchFields.Metadata.Key("it's").Is("yes").String())
+}
+
+func TestSearchBuildErrors(t *testing.T) {
+	_, err := SearchAnd().Build()
+	assert.Error(t, err)
+
+	_, err = SearchAnd(CustomerSearchFields.Name.Contains("ab")).Build()
+	assert.Error(t, err)
+
+	terms := make([]SearchTerm, MaxSearchClauses+1)
+	for i := range terms {
+		terms[i] = CustomerSearchFields.Created.GreaterThan(int64(i))
+	}
+	_, err = SearchOr(terms...).Build()
+	assert.Error(t, err)
+
+	params := &CustomerSearchParams{SearchParams: SearchParams{Query: "unchanged"}}
+	assert.Error(t, SearchAnd(CustomerSearchFields.Name.Contains("ab")).Apply(&params.SearchParams))
+	assert.Equal(t, "unchanged", params.Query)
+}
@@ -0,0 +1,172 @@
+package scheduledqueryrun
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	stripe "github.com/stripe/stripe-go/v81"
+	"github.com/stripe/stripe-go/v81/file"
+)
+
+//
+// Public types
+//
+
+// ResultError is the error returned when reading the result of a scheduled
+// query run which doesn't have one, because it didn't complete or its result
+// expired.
+type ResultError struct {
+	ScheduledQueryRun *stripe.SigmaScheduledQueryRun
+}
+
+// Error describes why the scheduled query run doesn't have a result.
+func (e *ResultError) Error() string {
+	run := e.ScheduledQueryRun
+	switch {
+	case run.Status != stripe.SigmaScheduledQueryRunStatusCompleted && run.Error != nil:
+		return fmt.Sprintf("scheduledqueryrun: scheduled query run %s is %s: %s", run.ID, run.Status, run.Error.Message)
+	case run.Status != stripe.SigmaScheduledQueryRunStatusCompleted:
+		return fmt.Sprintf("scheduledqueryrun: scheduled query run %s is %s", run.ID, run.Status)
+	case run.File == nil:
+		return fmt.Sprintf("scheduledqueryrun: scheduled query run %s doesn't have a result file", run.ID)
+	default:
+		return fmt.Sprintf("scheduledqueryrun: result of scheduled query run %s expired at %s",
+			run.ID, time.Unix(run.ResultAvailableUntil, 0).UTC().Format(time.RFC3339))
+	}
+}
+
+// ResultParams are the parameters of downloading the result of a scheduled
+// query run.
+type ResultParams struct {
+	file.DownloadParams
+
+	// Files is the client used to download the result file.
+	//
+	// Defaults to a client with the uploads backend from
+	// stripe.GetBackend, and the backend and key of the scheduled query run
+	// client.
+	Files *file.Client
+}
+
+//
+// Public functions
+//
+
+// DecodeResult downloads the result of a completed scheduled query run and
+// decodes its rows. See Client.DecodeResult.
+func DecodeResult(run *stripe.SigmaScheduledQueryRun, rows interface{}, params *ResultParams) error {
+	return getC().DecodeResult(run, rows, params)
+}
+
+// DecodeResult downloads the CSV result of a completed scheduled query run
+// and decodes its rows into rows, which must be a pointer to a slice of
+// map[string]string keyed by column, or of structs with `csv` tags naming
+// the columns of their fields. See stripe.CSVDecoder for the supported
+// fields.
+func (c Client) DecodeResult(run *stripe.SigmaScheduledQueryRun, rows interface{}, params *ResultParams) error {
+	r, err := c.OpenResult(run, params)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	return stripe.NewCSVDecoder(r).DecodeAll(rows)
+}
+
+// ListSince lists the finished scheduled query runs created after another.
+// See Client.ListSince.
+func ListSince(id string, params *stripe.SigmaScheduledQueryRunListParams) ([]*stripe.SigmaScheduledQueryRun, error) {
+	return getC().ListSince(id, params)
+}
+
+// ListSince lists the scheduled query runs created after the run of the given
+// ID, or all of them if the ID is empty, from the oldest to the newest, for
+// incremental ingestion of their results: the ID of the last run returned is
+// the one to list since the next time, or the same ID if none was returned.
+//
+// Runs are listed by ID rather than by creation time, so that runs created
+// within the same second are all listed once. Listing stops before the oldest
+// run which is still running, so that it's listed once finished, along with
+// the runs created after it. Runs which are returned are finished: completed,
+// or canceled, failed or timed out.
+func (c Client) ListSince(id string, params *stripe.SigmaScheduledQueryRunListParams) ([]*stripe.SigmaScheduledQueryRun, error) {
+	listParams := stripe.SigmaScheduledQueryRunListParams{}
+	if params != nil {
+		listParams = *params
+	}
+	listParams.EndingBefore = nil
+	listParams.StartingAfter = nil
+	if id != "" {
+		listParams.EndingBefore = stripe.String(id)
+	}
+
+	// Runs are listed from the oldest when listing before an ID, and from
+	// the newest otherwise.
+	var runs []*stripe.SigmaScheduledQueryRun
+	i := c.List(&listParams)
+	for i.Next() {
+		runs = append(runs, i.SigmaScheduledQueryRun())
+	}
+	if err := i.Err(); err != nil {
+		return nil, err
+	}
+	if id == "" {
+		for left, right := 0, len(runs)-1; left < right; left, right = left+1, right-1 {
+			runs[left], runs[right] = runs[right], runs[left]
+		}
+	}
+
+	for n, run := range runs {
+		if !isFinished(run) {
+			return runs[:n], nil
+		}
+	}
+	return runs, nil
+}
+
+// OpenResult opens the result of a completed scheduled query run for
+// streaming. See Client.OpenResult.
+func OpenResult(run *stripe.SigmaScheduledQueryRun, params *ResultParams) (*file.DownloadReader, error) {
+	return getC().OpenResult(run, params)
+}
+
+// OpenResult opens the result file of a completed scheduled query run for
+// streaming from the files backend, without loading it into memory. Decode
+// its rows with stripe.NewCSVDecoder. A *ResultError is returned if the run
+// didn't complete or its result expired.
+func (c Client) OpenResult(run *stripe.SigmaScheduledQueryRun, params *ResultParams) (*file.DownloadReader, error) {
+	if run == nil {
+		return nil, errors.New("scheduledqueryrun: scheduled query run cannot be nil")
+	}
+	if run.Status != stripe.SigmaScheduledQueryRunStatusCompleted || run.File == nil ||
+		(run.ResultAvailableUntil != 0 && time.Now().Unix() >= run.ResultAvailableUntil) {
+		return nil, &ResultError{ScheduledQueryRun: run}
+	}
+
+	files := &file.Client{B: c.B, BUploads: stripe.GetBackend(stripe.UploadsBackend), Key: c.Key}
+	var downloadParams *file.DownloadParams
+	if params != nil {
+		downloadParams = &params.DownloadParams
+		if params.Files != nil {
+			files = params.Files
+		}
+	}
+	return files.Download(run.File.ID, downloadParams)
+}
+
+//
+// Private functions
+//
+
+func isFinished(run *stripe.SigmaScheduledQueryRun) bool {
+	switch run.Status {
+	case stripe.SigmaScheduledQueryRunStatusCanceled,
+		stripe.SigmaScheduledQueryRunStatusCompleted,
+		stripe.SigmaScheduledQueryRunStatusFailed,
+		stripe.SigmaScheduledQueryRunStatusTimedOut:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,121 @@
+package scheduledqueryrun_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+	stripe "github.com/stripe/stripe-go/v81"
+	"github.com/stripe/stripe-go/v81/file"
+	"github.com/stripe/stripe-go/v81/sigma/scheduledqueryrun"
+	"github.com/stripe/stripe-go/v81/stripetest"
+)
+
+func TestDecodeResult(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/files/file_123/contents", r.URL.Path)
+		fmt.Fprint(w, "id,amount,created\nch_1,1000,2024-01-02 03:04:05.000\nch_2,,2024-01-03 00:00:00.000\n")
+	}))
+	defer ts.Close()
+
+	c := scheduledqueryrun.Client{Key: "sk_test_123"}
+	params := &scheduledqueryrun.ResultParams{
+		Files: &file.Client{BUploads: stripetest.NewBackend(stripe.UploadsBackend, ts.URL), Key: "sk_test_123"},
+	}
+	run := &stripe.SigmaScheduledQueryRun{
+		File:   &stripe.File{ID: "file_123"},
+		ID:     "sqr_123",
+		Status: stripe.SigmaScheduledQueryRunStatusCompleted,
+	}
+
+	var maps []map[string]string
+	assert.NoError(t, c.DecodeResult(run, &maps, params))
+	assert.Equal(t, []map[string]string{
+		{"id": "ch_1", "amount": "1000", "created": "2024-01-02 03:04:05.000"},
+		{"id": "ch_2", "amount": "", "created": "2024-01-03 00:00:00.000"},
+	}, maps)
+
+	type charge struct {
+		Amount  *int64    `csv:"amount"`
+		Created time.Time `csv:"created"`
+		ID      string    `csv:"id"`
+	}
+	var charges []charge
+	assert.NoError(t, c.DecodeResult(run, &charges, params))
+	assert.Equal(t, int64(1000), *charges[0].Amount)
+	assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), charges[0].Created)
+	assert.Nil(t, charges[1].Amount)
+}
+
+func TestOpenResultErrors(t *testing.T) {
+	c := scheduledqueryrun.Client{Key: "sk_test_123"}
+
+	_, err := c.OpenResult(&stripe.SigmaScheduledQueryRun{
+		Error:  &stripe.SigmaScheduledQueryRunError{Message: "Syntax error"},
+		ID:     "sqr_123",
+		Status: stripe.SigmaScheduledQueryRunStatusFailed,
+	}, nil)
+	assert.EqualError(t, err, "scheduledqueryrun: scheduled query run sqr_123 is failed: Syntax error")
+
+	_, err = c.OpenResult(&stripe.SigmaScheduledQueryRun{
+		File:                 &stripe.File{ID: "file_123"},
+		ID:                   "sqr_123",
+		ResultAvailableUntil: 1704164645,
+		Status:               stripe.SigmaScheduledQueryRunStatusCompleted,
+	}, nil)
+	assert.EqualError(t, err, "scheduledqueryrun: result of scheduled query run sqr_123 expired at 2024-01-02T03:04:05Z")
+	_, ok := err.(*scheduledqueryrun.ResultError)
+	assert.True(t, ok)
+}
+
+func TestListSince(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/sigma/scheduled_query_runs", r.URL.Path)
+		switch r.URL.Query().Get("ending_before") {
+		case "":
+			assert.Equal(t, "", r.URL.Query().Get("starting_after"))
+			fmt.Fprint(w, `{"object":"list","has_more":false,"data":[
+				{"id":"sqr_2","created":100,"status":"failed"},
+				{"id":"sqr_1","created":100,"status":"completed"}
+			]}`)
+		case "sqr_2":
+			fmt.Fprint(w, `{"object":"list","has_more":true,"data":[
+				{"id":"sqr_4","created":300,"status":"completed"},
+				{"id":"sqr_3","created":100,"status":"completed"}
+			]}`)
+		case "sqr_4":
+			fmt.Fprint(w, `{"object":"list","has_more":false,"data":[
+				{"id":"sqr_6","created":500,"status":"completed"},
+				{"id":"sqr_5","created":400,"status":"running"}
+			]}`)
+		default:
+			t.Errorf("unexpected ending_before %s", r.URL.Query().Get("ending_before"))
+		}
+	}))
+	defer ts.Close()
+
+	c := scheduledqueryrun.Client{B: stripetest.NewBackend(stripe.APIBackend, ts.URL), Key: "sk_test_123"}
+	params := &stripe.SigmaScheduledQueryRunListParams{}
+	params.StartingAfter = stripe.String("sqr_0")
+	runs, err := c.ListSince("", params)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sqr_1", "sqr_2"}, runIDs(runs))
+
+	// Runs created in the same second as the last one listed are listed, and
+	// listing stops before the oldest run still running.
+	runs, err = c.ListSince("sqr_2", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sqr_3", "sqr_4"}, runIDs(runs))
+	assert.Equal(t, "sqr_0", *params.StartingAfter)
+}
+
+func runIDs(runs []*stripe.SigmaScheduledQueryRun) []string {
+	var ids []string
+	for _, run := range runs {
+		ids = append(ids, run.ID)
+	}
+	return ids
+}
@@ -141,7 +141,7 @@ type SourceParams struct {
 	// Information about a mandate possibility attached to a source object (generally for bank debits) as well as its acceptance status.
 	Mandate *SourceMandateParams `form:"mandate"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format. Individual keys can be unset by posting an empty value to them. All keys can be unset by posting an empty value to `metadata`.
-	Metadata map[string]string `form:"metadata"`
+	Metadata Metadata `form:"metadata"`
 	// The source to share.
 	OriginalSource *string `form:"original_source"`
 	// Information about the owner of the payment instrument that may be used or required by particular source types.
@@ -169,7 +169,7 @@ func (p *SourceParams) AddExpand(f string) {
 // AddMetadata adds a new key-value pair to the Metadata.
 func (p *SourceParams) AddMetadata(key string, value string) {
 	if p.Metadata == nil {
-		p.Metadata = make(map[string]string)
+		p.Metadata = make(Metadata)
 	}
 
 	p.Metadata[key] = value
@@ -600,7 +600,7 @@ type Source struct {
 	// Has the value `true` if the object exists in live mode or the value `false` if the object exists in test mode.
 	Livemode bool `json:"livemode"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format.
-	Metadata   map[string]string `json:"metadata"`
+	Metadata   Metadata          `json:"metadata"`
 	Multibanco *SourceMultibanco `json:"multibanco"`
 	// String representing the object's type. Objects of the same type share the same value.
 	Object string `json:"object"`
@@ -216,6 +216,18 @@ type RawRequestBackend interface {
 
 // BackendConfig is used to configure a new Stripe backend.
 type BackendConfig struct {
+	// EnableRequestCoalescing collapses concurrent identical GET requests made
+	// through Call (same path, API key, Stripe-Account, headers and
+	// parameters) into a single HTTP request whose response is shared between
+	// all the callers. Each caller still gets its own decoded copy of the
+	// response.
+	//
+	// This value is a pointer to allow us to differentiate an unset versus
+	// empty value. Use stripe.Bool for an easy way to set this value.
+	//
+	// Defaults to false.
+	EnableRequestCoalescing *bool
+
 	// EnableTelemetry allows request metrics (request id and duration) to be sent
 	// to Stripe in subsequent requests via the `X-Stripe-Client-Telemetry` header.
 	//
@@ -279,6 +291,12 @@ type BackendImplementation struct {
 
 	enableTelemetry bool
 
+	// inflight tracks the GET requests currently in flight when request
+	// coalescing is enabled, and is nil otherwise.
+	//
+	// See also BackendConfig.EnableRequestCoalescing.
+	inflight *requestGroup
+
 	// networkRetriesSleep indicates whether the backend should use the normal
 	// sleep between retries.
 	//
@@ -344,6 +362,10 @@ func extractParams(params ParamsContainer) (*form.Values, *Params, error) {
 				}
 			}
 
+			if err := validateParamsMetadata(reflectValue.Elem()); err != nil {
+				return nil, nil, err
+			}
+
 			if !reflectValue.Elem().FieldByName("Expand").IsZero() {
 				if commonParams.Expand != nil {
 					return nil, nil, fmt.Errorf("You cannot specify both the (deprecated) .Params.Expand and .Expand in %s", reflectValue.Elem().Type().Name())
@@ -357,12 +379,55 @@ func extractParams(params ParamsContainer) (*form.Values, *Params, error) {
 	return formValues, commonParams, nil
 }
 
+// validateParamsMetadata validates the metadata of params, walking through
+// nested params like SubscriptionData or line items and the embedded Params,
+// so that metadata over the limits of the API fails before making a request.
+func validateParamsMetadata(v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return nil
+		}
+		return validateParamsMetadata(v.Elem())
+
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := validateParamsMetadata(v.Index(i)); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			// Skip unexported fields, which aren't encoded.
+			if t.Field(i).PkgPath != "" {
+				continue
+			}
+			field := v.Field(i)
+			if metadata, ok := field.Interface().(Metadata); ok {
+				if err := metadata.Validate(); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := validateParamsMetadata(field); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // Call is the Backend.Call implementation for invoking Stripe APIs.
 func (s *BackendImplementation) Call(method, path, key string, params ParamsContainer, v LastResponseSetter) error {
 	body, commonParams, err := extractParams(params)
 	if err != nil {
 		return err
 	}
+	if s.inflight != nil && method == http.MethodGet {
+		return s.callCoalesced(path, key, body, commonParams, v)
+	}
 	return s.CallRaw(method, path, key, body, commonParams, v)
 }
 
@@ -425,6 +490,20 @@ func (s *BackendImplementation) CallMultipart(method, path, key, boundary string
 	return nil
 }
 
+// CallMultipartStreaming is the MultipartStreamingBackend.CallMultipartStreaming
+// implementation for invoking Stripe APIs.
+func (s *BackendImplementation) CallMultipartStreaming(method, path, key string, body *MultipartBody, params *Params, v LastResponseSetter) error {
+	req, err := s.NewRequest(method, path, key, body.ContentType(), params)
+	if err != nil {
+		return err
+	}
+	if body.Size >= 0 {
+		req.ContentLength = body.Size
+	}
+
+	return s.do(req, body.Open, v)
+}
+
 // the stripe API only accepts GET / POST / DELETE
 func validateMethod(method string) error {
 	if method != http.MethodPost && method != http.MethodGet && method != http.MethodDelete {
@@ -486,7 +565,7 @@ func (s *BackendImplementation) RawRequest(method, path, key, content string, pa
 		return s.handleResponseBufferingErrors(res, err)
 	}
 
-	resp, result, requestDuration, err := s.requestWithRetriesAndTelemetry(req, bodyBuffer, handleResponse)
+	resp, result, requestDuration, err := s.requestWithRetriesAndTelemetry(req, bufferBody(bodyBuffer), handleResponse)
 	if err != nil {
 		return nil, err
 	}
@@ -633,7 +712,7 @@ func (s *BackendImplementation) maybeEnqueueTelemetryMetrics(requestID string, r
 	}
 }
 
-func resetBodyReader(body *bytes.Buffer, req *http.Request) {
+func resetBodyReader(body requestBody, req *http.Request) error {
 	// This might look a little strange, but we set the request's body
 	// outside of `NewRequest` so that we can get a fresh version every
 	// time.
@@ -655,12 +734,14 @@ func resetBodyReader(body *bytes.Buffer, req *http.Request) {
 	// every time we execute it, and this seems to empirically resolve the
 	// problem.
 	if body != nil {
-		// We can safely reuse the same buffer that we used to encode our body,
-		// but return a new reader to it everytime so that each read is from
-		// the beginning.
-		reader := bytes.NewReader(body.Bytes())
+		// Each body reads from the beginning, either of the buffer that we
+		// used to encode our body, or of a source which is rewound.
+		reader, err := body()
+		if err != nil {
+			return err
+		}
 
-		req.Body = nopReadCloser{reader}
+		req.Body = reader
 
 		// And also add the same thing to `Request.GetBody`, which allows
 		// `net/http` to get a new body in cases like a redirect. This is
@@ -669,11 +750,9 @@ func resetBodyReader(body *bytes.Buffer, req *http.Request) {
 		//
 		//     https://github.com/stripe/stripe-go/issues/710
 		//
-		req.GetBody = func() (io.ReadCloser, error) {
-			reader := bytes.NewReader(body.Bytes())
-			return nopReadCloser{reader}, nil
-		}
+		req.GetBody = body
 	}
+	return nil
 }
 
 // requestWithRetriesAndTelemetry uses s.HTTPClient to make an HTTP request,
@@ -685,7 +764,7 @@ func resetBodyReader(body *bytes.Buffer, req *http.Request) {
 // type.
 func (s *BackendImplementation) requestWithRetriesAndTelemetry(
 	req *http.Request,
-	body *bytes.Buffer,
+	body requestBody,
 	handleResponse func(*http.Response, error) (interface{}, error),
 ) (*http.Response, interface{}, *time.Duration, error) {
 	s.LeveledLogger.Infof("Requesting %v %v%v", req.Method, req.URL.Host, req.URL.Path)
@@ -695,8 +774,18 @@ func (s *BackendImplementation) requestWithRetriesAndTelemetry(
 	var requestDuration time.Duration
 	var result interface{}
 	for retry := 0; ; {
+		if resetErr := resetBodyReader(body, req); resetErr != nil {
+			if retry == 0 {
+				return nil, nil, nil, resetErr
+			}
+
+			// The body can't be sent again, so the error of the last
+			// attempt is returned.
+			s.LeveledLogger.Infof("Not retrying request: %v", resetErr)
+			break
+		}
+
 		start := time.Now()
-		resetBodyReader(body, req)
 
 		resp, err = s.HTTPClient.Do(req)
 
@@ -724,6 +813,11 @@ func (s *BackendImplementation) requestWithRetriesAndTelemetry(
 	}
 
 	if err != nil {
+		// Errors which aren't responses of the API, nor responses which
+		// couldn't be decoded, are network failures.
+		if _, ok := err.(*Error); !ok && (resp == nil || resp.StatusCode < 400) {
+			err = &ConnectionError{Err: err}
+		}
 		return nil, nil, nil, err
 	}
 
@@ -792,7 +886,7 @@ func (s *BackendImplementation) DoStreaming(req *http.Request, body *bytes.Buffe
 		return s.handleResponseBufferingErrors(res, err)
 	}
 
-	resp, result, requestDuration, err := s.requestWithRetriesAndTelemetry(req, body, handleResponse)
+	resp, result, requestDuration, err := s.requestWithRetriesAndTelemetry(req, bufferBody(body), handleResponse)
 	if err != nil {
 		return err
 	}
@@ -804,6 +898,11 @@ func (s *BackendImplementation) DoStreaming(req *http.Request, body *bytes.Buffe
 // the backend's HTTP client to execute the request and unmarshals the response
 // into v. It also handles unmarshaling errors returned by the API.
 func (s *BackendImplementation) Do(req *http.Request, body *bytes.Buffer, v LastResponseSetter) error {
+	return s.do(req, bufferBody(body), v)
+}
+
+// do is the implementation of Do, for bodies which may not be buffered.
+func (s *BackendImplementation) do(req *http.Request, body requestBody, v LastResponseSetter) error {
 	handleResponse := func(res *http.Response, err error) (interface{}, error) {
 		var resBody []byte
 		if err == nil {
@@ -879,6 +978,17 @@ func (s *BackendImplementation) ResponseToError(res *http.Response, resBody []by
 	case ErrorTypeInvalidRequest:
 		typedError = &InvalidRequestError{stripeErr: raw.Error}
 	}
+
+	// Some errors are better told apart by their status, in which case the
+	// error for their type is wrapped so that both can be matched.
+	switch {
+	case res.StatusCode == http.StatusUnauthorized:
+		typedError = &AuthenticationError{stripeErr: raw.Error, err: typedError}
+	case res.StatusCode == http.StatusForbidden:
+		typedError = &PermissionError{stripeErr: raw.Error, err: typedError}
+	case res.StatusCode == http.StatusTooManyRequests && raw.Error.Code != ErrorCodeLockTimeout:
+		typedError = &RateLimitError{stripeErr: raw.Error, err: typedError}
+	}
 	raw.Error.Err = typedError
 
 	raw.Error.SetLastResponse(newAPIResponse(res, resBody, nil))
@@ -1428,6 +1538,10 @@ type stripeClientUserAgent struct {
 	Uname           string   `json:"uname"`
 }
 
+// requestBody returns a reader of the body of a request from its beginning.
+// It's called for each attempt of the request.
+type requestBody func() (io.ReadCloser, error)
+
 // requestMetrics contains the id and duration of the last request sent
 type requestMetrics struct {
 	RequestDurationMS *int     `json:"request_duration_ms"`
@@ -1500,6 +1614,17 @@ var httpClient = &http.Client{
 // Private functions
 //
 
+// bufferBody returns the body of a request encoded in a buffer, or nil if
+// there's no buffer.
+func bufferBody(body *bytes.Buffer) requestBody {
+	if body == nil {
+		return nil
+	}
+	return func() (io.ReadCloser, error) {
+		return nopReadCloser{bytes.NewReader(body.Bytes())}, nil
+	}
+}
+
 // getUname tries to get a uname from the system, but not that hard. It tries
 // to execute `uname -a`, but swallows any errors in case that didn't work
 // (i.e. non-Unix non-Mac system or some other reason).
@@ -1576,6 +1701,11 @@ func newBackendImplementation(backendType SupportedBackend, config *BackendConfi
 		requestMetricsBuffer = make(chan requestMetrics, telemetryBufferSize)
 	}
 
+	var inflight *requestGroup
+	if config.EnableRequestCoalescing != nil && *config.EnableRequestCoalescing {
+		inflight = &requestGroup{}
+	}
+
 	return &BackendImplementation{
 		HTTPClient:           config.HTTPClient,
 		LeveledLogger:        config.LeveledLogger,
@@ -1583,6 +1713,7 @@ func newBackendImplementation(backendType SupportedBackend, config *BackendConfi
 		Type:                 backendType,
 		URL:                  *config.URL,
 		enableTelemetry:      enableTelemetry,
+		inflight:             inflight,
 		networkRetriesSleep:  true,
 		requestMetricsBuffer: requestMetricsBuffer,
 	}
@@ -1632,6 +1763,11 @@ func (u *UsageBackend) CallMultipart(method, path, key, boundary string, body *b
 	return u.B.CallMultipart(method, path, key, boundary, body, params, v)
 }
 
+func (u *UsageBackend) CallMultipartStreaming(method, path, key string, body *MultipartBody, params *Params, v LastResponseSetter) error {
+	params.GetParams().InternalSetUsage(u.Usage)
+	return CallMultipartStreaming(u.B, method, path, key, body, params, v)
+}
+
 func (u *UsageBackend) CallStreaming(method, path, key string, params ParamsContainer, v StreamingLastResponseSetter) error {
 	if r := reflect.ValueOf(params); r.Kind() == reflect.Ptr && !r.IsNil() {
 		params.GetParams().InternalSetUsage(u.Usage)
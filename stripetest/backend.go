@@ -0,0 +1,19 @@
+package stripetest
+
+import (
+	stripe "github.com/stripe/stripe-go/v81"
+)
+
+//
+// Public functions
+//
+
+// NewBackend returns a backend of the given type making its requests to url,
+// like that of an httptest.Server, without retrying them or logging.
+func NewBackend(backend stripe.SupportedBackend, url string) stripe.Backend {
+	return stripe.GetBackendWithConfig(backend, &stripe.BackendConfig{
+		LeveledLogger:     &stripe.LeveledLogger{Level: stripe.LevelNull},
+		MaxNetworkRetries: stripe.Int64(0),
+		URL:               stripe.String(url),
+	})
+}
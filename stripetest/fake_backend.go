@@ -0,0 +1,785 @@
+package stripetest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	stripe "github.com/stripe/stripe-go/v81"
+	"github.com/stripe/stripe-go/v81/form"
+)
+
+//
+// Public constants
+//
+
+// DefaultFakeListLimit is the number of objects returned in a page of a list
+// when the limit parameter isn't set.
+const DefaultFakeListLimit = 10
+
+//
+// Public types
+//
+
+// FakeBackend is an in-memory fake of the Stripe API implementing
+// stripe.Backend, so that code using the core resources can be unit tested
+// offline and without stubbing out every request by hand.
+//
+// It keeps state for customers, payment methods, payment intents (and the
+// charges they create), refunds, products, prices, subscriptions and
+// invoices, which can be created, retrieved, updated, listed and deleted
+// like on the real API. Lists are paginated the same way, so they can be
+// iterated over with the usual iterators. The events that the real API
+// would emit for those changes are recorded and delivered to subscribers.
+//
+// Test clocks are supported too, and advancing one renews the subscriptions
+// of its customers, ends their trials and retries their failed payments, so
+// that it can stand in for the API when running a Scenario.
+//
+// It doesn't aim for full fidelity: only the most common parameters and
+// state transitions are supported, and unknown parameters are ignored
+// rather than rejected. Payments always succeed, unless made with one of the
+// card numbers documented as declined in test mode, like 4000000000000002,
+// or test payment methods like `pm_card_chargeDeclined`.
+type FakeBackend struct {
+	backend     stripe.Backend
+	declines    map[string]string
+	idempotent  map[string]*fakeResponse
+	mu          sync.Mutex
+	nextID      map[string]int
+	now         func() time.Time
+	objects     map[string]fakeObject
+	order       map[string][]string
+	subscribers []func(*stripe.Event)
+}
+
+// NewFakeBackend returns a FakeBackend without any object.
+func NewFakeBackend() *FakeBackend {
+	f := &FakeBackend{
+		declines:   make(map[string]string),
+		idempotent: make(map[string]*fakeResponse),
+		nextID:     make(map[string]int),
+		now:        time.Now,
+		objects:    make(map[string]fakeObject),
+		order:      make(map[string][]string),
+	}
+	f.backend = stripe.GetBackendWithConfig(stripe.APIBackend, &stripe.BackendConfig{
+		HTTPClient:        &http.Client{Transport: fakeTransport{f}},
+		LeveledLogger:     &stripe.LeveledLogger{Level: stripe.LevelNull},
+		MaxNetworkRetries: stripe.Int64(0),
+		URL:               stripe.String(stripe.APIURL),
+	})
+	return f
+}
+
+// Call is the Backend.Call implementation.
+func (f *FakeBackend) Call(method, path, key string, params stripe.ParamsContainer, v stripe.LastResponseSetter) error {
+	return f.backend.Call(method, path, key, params, v)
+}
+
+// CallMultipart is the Backend.CallMultipart implementation. File uploads
+// aren't supported, so the fake responds with an error.
+func (f *FakeBackend) CallMultipart(method, path, key, boundary string, body *bytes.Buffer, params *stripe.Params, v stripe.LastResponseSetter) error {
+	return f.backend.CallMultipart(method, path, key, boundary, body, params, v)
+}
+
+// CallMultipartStreaming is the
+// stripe.MultipartStreamingBackend.CallMultipartStreaming implementation.
+func (f *FakeBackend) CallMultipartStreaming(method, path, key string, body *stripe.MultipartBody, params *stripe.Params, v stripe.LastResponseSetter) error {
+	return stripe.CallMultipartStreaming(f.backend, method, path, key, body, params, v)
+}
+
+// CallRaw is the Backend.CallRaw implementation.
+func (f *FakeBackend) CallRaw(method, path, key string, body *form.Values, params *stripe.Params, v stripe.LastResponseSetter) error {
+	return f.backend.CallRaw(method, path, key, body, params, v)
+}
+
+// CallStreaming is the Backend.CallStreaming implementation.
+func (f *FakeBackend) CallStreaming(method, path, key string, params stripe.ParamsContainer, v stripe.StreamingLastResponseSetter) error {
+	return f.backend.CallStreaming(method, path, key, params, v)
+}
+
+// Events returns the events emitted so far, oldest first.
+func (f *FakeBackend) Events() []*stripe.Event {
+	f.mu.Lock()
+	ids := f.order["event"]
+	objects := make([]fakeObject, len(ids))
+	for i, id := range ids {
+		objects[i] = f.objects[id]
+	}
+	data, err := json.Marshal(objects)
+	f.mu.Unlock()
+	if err != nil {
+		panic(err)
+	}
+
+	var events []*stripe.Event
+	if err := json.Unmarshal(data, &events); err != nil {
+		panic(err)
+	}
+	return events
+}
+
+// ServeHTTP serves requests to the fake. It's what the backend uses under the
+// hood, and can also be used to serve the fake with an httptest.Server for
+// code which doesn't take a stripe.Backend.
+func (f *FakeBackend) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeFakeResponse(w, errInvalidRequest("", "Invalid request body: "+err.Error()).response())
+		return
+	}
+
+	req := &fakeRequest{
+		form:           r.PostForm,
+		idempotencyKey: r.Header.Get("Idempotency-Key"),
+		path:           r.URL.Path,
+	}
+	if r.Method == http.MethodGet {
+		req.form = r.Form
+	}
+
+	resp, subscribers := f.serveLocked(r.Method, req)
+	writeFakeResponse(w, resp)
+
+	// Subscribers are called once the lock is released so that they can make
+	// requests themselves.
+	for _, event := range req.events {
+		for _, subscriber := range subscribers {
+			subscriber(event)
+		}
+	}
+}
+
+// SetMaxNetworkRetries is the Backend.SetMaxNetworkRetries implementation.
+// Requests to the fake never fail on the network, so it does nothing.
+func (f *FakeBackend) SetMaxNetworkRetries(maxNetworkRetries int64) {
+}
+
+// SetNow sets the function used by the fake to get the current time, which is
+// used for timestamps like `created` and for subscription periods.
+func (f *FakeBackend) SetNow(now func() time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = now
+}
+
+// Subscribe registers a function called with every event emitted by the fake
+// from then on, synchronously and once the request which caused it has been
+// served.
+func (f *FakeBackend) Subscribe(subscriber func(event *stripe.Event)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.subscribers = append(f.subscribers, subscriber)
+}
+
+//
+// Private types
+//
+
+// fakeError is an error returned by the fake, serialized like API errors.
+type fakeError struct {
+	Code          string     `json:"code,omitempty"`
+	DeclineCode   string     `json:"decline_code,omitempty"`
+	Message       string     `json:"message"`
+	Param         string     `json:"param,omitempty"`
+	PaymentIntent fakeObject `json:"payment_intent,omitempty"`
+	Type          string     `json:"type"`
+
+	status int
+}
+
+func (e *fakeError) response() *fakeResponse {
+	body, err := json.Marshal(map[string]*fakeError{"error": e})
+	if err != nil {
+		panic(err)
+	}
+	return &fakeResponse{body: body, status: e.status}
+}
+
+// fakeObject is an API resource, kept in the same shape as its JSON
+// representation.
+type fakeObject = map[string]interface{}
+
+// fakeRequest is a request being served by the fake.
+type fakeRequest struct {
+	events         []*stripe.Event
+	form           url.Values
+	idempotencyKey string
+	ids            []string
+	path           string
+	requestID      string
+}
+
+func (r *fakeRequest) has(key string) bool {
+	_, ok := r.form[key]
+	return ok
+}
+
+func (r *fakeRequest) boolean(key string) (bool, *fakeError) {
+	switch v := r.form.Get(key); v {
+	case "true":
+		return true, nil
+	case "false", "":
+		return false, nil
+	default:
+		return false, errInvalidParam(key, fmt.Sprintf("Invalid boolean: %s", v))
+	}
+}
+
+func (r *fakeRequest) expand() [][]string {
+	var paths [][]string
+	for key, values := range r.form {
+		if key == "expand" || strings.HasPrefix(key, "expand[") {
+			for _, v := range values {
+				paths = append(paths, strings.Split(v, "."))
+			}
+		}
+	}
+	return paths
+}
+
+// indexed returns the elements of an array parameter, like
+// `items[0][price]`, as forms of their own.
+func (r *fakeRequest) indexed(key string) []*fakeRequest {
+	prefix := key + "["
+	byIndex := make(map[int]url.Values)
+	for k, values := range r.form {
+		if !strings.HasPrefix(k, prefix) {
+			continue
+		}
+		end := strings.Index(k[len(prefix):], "]")
+		if end < 0 {
+			continue
+		}
+		i, err := strconv.Atoi(k[len(prefix) : len(prefix)+end])
+		if err != nil {
+			continue
+		}
+
+		// `[price][currency]` becomes `price[currency]`
+		rest := k[len(prefix)+end+1:]
+		if strings.HasPrefix(rest, "[") {
+			if j := strings.Index(rest, "]"); j > 0 {
+				rest = rest[1:j] + rest[j+1:]
+			}
+		}
+
+		if byIndex[i] == nil {
+			byIndex[i] = make(url.Values)
+		}
+		byIndex[i][rest] = values
+	}
+
+	indexes := make([]int, 0, len(byIndex))
+	for i := range byIndex {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+
+	elements := make([]*fakeRequest, len(indexes))
+	for j, i := range indexes {
+		elements[j] = &fakeRequest{form: byIndex[i]}
+	}
+	return elements
+}
+
+func (r *fakeRequest) integer(key string) (int64, *fakeError) {
+	v := r.form.Get(key)
+	if v == "" {
+		return 0, nil
+	}
+	i, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, &fakeError{
+			Code:    "parameter_invalid_integer",
+			Message: fmt.Sprintf("Invalid integer: %s", v),
+			Param:   key,
+			Type:    "invalid_request_error",
+			status:  http.StatusBadRequest,
+		}
+	}
+	return i, nil
+}
+
+// setStrings copies the given string parameters to the object. Empty strings
+// unset them, like on the API.
+func (r *fakeRequest) setStrings(obj fakeObject, keys ...string) {
+	for _, key := range keys {
+		if !r.has(key) {
+			continue
+		}
+		if v := r.form.Get(key); v != "" {
+			obj[key] = v
+		} else {
+			obj[key] = nil
+		}
+	}
+}
+
+// setMetadata updates the metadata of the object from the `metadata[key]`
+// parameters. Keys set to an empty string are removed, and an empty
+// `metadata` parameter removes all of them.
+func (r *fakeRequest) setMetadata(obj fakeObject) {
+	metadata, _ := obj["metadata"].(fakeObject)
+	if metadata == nil || (r.has("metadata") && r.form.Get("metadata") == "") {
+		metadata = fakeObject{}
+	}
+	for key, values := range r.form {
+		if !strings.HasPrefix(key, "metadata[") || !strings.HasSuffix(key, "]") {
+			continue
+		}
+		k := key[len("metadata[") : len(key)-1]
+		if v := values[len(values)-1]; v != "" {
+			metadata[k] = v
+		} else {
+			delete(metadata, k)
+		}
+	}
+	obj["metadata"] = metadata
+}
+
+// fakeResponse is a response of the fake, kept around to replay requests made
+// with the same idempotency key.
+type fakeResponse struct {
+	body      []byte
+	requestID string
+	status    int
+}
+
+// fakeRoute maps requests to the handler serving them.
+type fakeRoute struct {
+	handler func(f *FakeBackend, req *fakeRequest) (fakeObject, *fakeError)
+	method  string
+	pattern *regexp.Regexp
+}
+
+// fakeTransport serves the requests of the backend used by a FakeBackend
+// in-memory.
+type fakeTransport struct {
+	f *FakeBackend
+}
+
+func (t fakeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorder := httptest.NewRecorder()
+	t.f.ServeHTTP(recorder, req)
+	resp := recorder.Result()
+	resp.Request = req
+	return resp, nil
+}
+
+//
+// Private functions
+//
+
+func errInvalidParam(param, message string) *fakeError {
+	return &fakeError{
+		Code:    "parameter_invalid",
+		Message: message,
+		Param:   param,
+		Type:    "invalid_request_error",
+		status:  http.StatusBadRequest,
+	}
+}
+
+func errInvalidRequest(code, message string) *fakeError {
+	return &fakeError{
+		Code:    code,
+		Message: message,
+		Type:    "invalid_request_error",
+		status:  http.StatusBadRequest,
+	}
+}
+
+func errMissingParam(param string) *fakeError {
+	return &fakeError{
+		Code:    "parameter_missing",
+		Message: fmt.Sprintf("Missing required param: %s.", param),
+		Param:   param,
+		Type:    "invalid_request_error",
+		status:  http.StatusBadRequest,
+	}
+}
+
+func errResourceMissing(objectType, id, param string) *fakeError {
+	return &fakeError{
+		Code:    "resource_missing",
+		Message: fmt.Sprintf("No such %s: '%s'", objectType, id),
+		Param:   param,
+		Type:    "invalid_request_error",
+		status:  http.StatusNotFound,
+	}
+}
+
+// cloneObject returns a deep copy of the object, so that snapshots of it can
+// be taken for events and responses.
+func cloneObject(obj fakeObject) fakeObject {
+	if obj == nil {
+		return nil
+	}
+	clone := make(fakeObject, len(obj))
+	for k, v := range obj {
+		clone[k] = cloneValue(v)
+	}
+	return clone
+}
+
+func cloneValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case fakeObject:
+		return cloneObject(v)
+	case []interface{}:
+		clone := make([]interface{}, len(v))
+		for i, e := range v {
+			clone[i] = cloneValue(e)
+		}
+		return clone
+	default:
+		return v
+	}
+}
+
+// diffObjects returns the top-level attributes of before which differ in
+// after, like the `previous_attributes` of an update event.
+func diffObjects(before, after fakeObject) fakeObject {
+	previous := fakeObject{}
+	for k, v := range before {
+		if !reflect.DeepEqual(v, after[k]) {
+			previous[k] = v
+		}
+	}
+	for k := range after {
+		if _, ok := before[k]; !ok {
+			previous[k] = nil
+		}
+	}
+	return previous
+}
+
+func writeFakeResponse(w http.ResponseWriter, resp *fakeResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Request-Id", resp.requestID)
+	w.WriteHeader(resp.status)
+	w.Write(resp.body)
+}
+
+// addPeriod returns t moved forward by count recurring intervals.
+func addPeriod(t time.Time, interval string, count int64) time.Time {
+	n := int(count)
+	switch interval {
+	case "day":
+		return t.AddDate(0, 0, n)
+	case "week":
+		return t.AddDate(0, 0, 7*n)
+	case "year":
+		return t.AddDate(n, 0, 0)
+	default:
+		return t.AddDate(0, n, 0)
+	}
+}
+
+// subscriptionInterval returns the billing interval of a subscription, which
+// is the one of the recurring price of its first item. ok is false if the
+// subscription has no item or its price isn't recurring.
+func subscriptionInterval(sub fakeObject) (interval string, count int64, ok bool) {
+	items, _ := sub["items"].(fakeObject)
+	data, _ := items["data"].([]interface{})
+	if len(data) == 0 {
+		return "", 0, false
+	}
+	item, _ := data[0].(fakeObject)
+	price, _ := item["price"].(fakeObject)
+	recurring, _ := price["recurring"].(fakeObject)
+	interval, ok = recurring["interval"].(string)
+	if !ok {
+		return "", 0, false
+	}
+	count, ok = recurring["interval_count"].(int64)
+	return interval, count, ok
+}
+
+// emit records an event about the object and queues it for delivery to the
+// subscribers.
+func (f *FakeBackend) emit(req *fakeRequest, eventType stripe.EventType, obj fakeObject, previous fakeObject) {
+	data := fakeObject{"object": cloneObject(obj)}
+	if previous != nil {
+		data["previous_attributes"] = previous
+	}
+
+	event := fakeObject{
+		"id":               f.newID("evt"),
+		"object":           "event",
+		"api_version":      stripe.APIVersion,
+		"created":          f.timestamp(),
+		"data":             data,
+		"livemode":         false,
+		"pending_webhooks": int64(0),
+		"request":          fakeObject{"id": req.requestID, "idempotency_key": nil},
+		"type":             string(eventType),
+	}
+	if req.idempotencyKey != "" {
+		event["request"].(fakeObject)["idempotency_key"] = req.idempotencyKey
+	}
+	f.insert(event)
+
+	raw, err := json.Marshal(event)
+	if err != nil {
+		panic(err)
+	}
+	e := &stripe.Event{}
+	if err := json.Unmarshal(raw, e); err != nil {
+		panic(err)
+	}
+	req.events = append(req.events, e)
+}
+
+// emitUpdated emits an update event if the object differs from its snapshot
+// taken before the update.
+func (f *FakeBackend) emitUpdated(req *fakeRequest, eventType stripe.EventType, before, obj fakeObject) {
+	if previous := diffObjects(before, obj); len(previous) > 0 {
+		f.emit(req, eventType, obj, previous)
+	}
+}
+
+// expandObject replaces the IDs found at the given path of the object with the
+// objects they identify.
+func (f *FakeBackend) expandObject(obj fakeObject, path []string) {
+	if len(path) == 0 {
+		return
+	}
+
+	v, ok := obj[path[0]]
+	if !ok {
+		return
+	}
+	if id, ok := v.(string); ok {
+		expanded, ok := f.objects[id]
+		if !ok {
+			return
+		}
+		v = cloneObject(expanded)
+		obj[path[0]] = v
+	}
+
+	switch v := v.(type) {
+	case fakeObject:
+		f.expandObject(v, path[1:])
+	case []interface{}:
+		for _, e := range v {
+			if e, ok := e.(fakeObject); ok {
+				f.expandObject(e, path[1:])
+			}
+		}
+	}
+}
+
+// get returns the object with the given ID, or an error if there's no such
+// object of the given type.
+func (f *FakeBackend) get(objectType, id, param string) (fakeObject, *fakeError) {
+	obj, ok := f.objects[id]
+	if !ok || obj["object"] != objectType {
+		return nil, errResourceMissing(objectType, id, param)
+	}
+	return obj, nil
+}
+
+// customerNow returns the current time for the customer, which is the time
+// of its test clock if it has one.
+func (f *FakeBackend) customerNow(customer fakeObject) time.Time {
+	if id, ok := customer["test_clock"].(string); ok {
+		if clock, ok := f.objects[id]; ok {
+			return time.Unix(clock["frozen_time"].(int64), 0)
+		}
+	}
+	return f.now()
+}
+
+func (f *FakeBackend) insert(obj fakeObject) {
+	id := obj["id"].(string)
+	objectType := obj["object"].(string)
+	f.objects[id] = obj
+	f.order[objectType] = append(f.order[objectType], id)
+}
+
+// list returns a page of the objects of the given type matching the filter,
+// newest first, paginated like the API.
+func (f *FakeBackend) list(req *fakeRequest, objectType string, filter func(fakeObject) bool) (fakeObject, *fakeError) {
+	limit := int64(DefaultFakeListLimit)
+	if req.has("limit") {
+		var err *fakeError
+		limit, err = req.integer("limit")
+		if err != nil {
+			return nil, err
+		}
+		if limit < 1 || limit > 100 {
+			return nil, errInvalidParam("limit", "Invalid limit: must be between 1 and 100")
+		}
+	}
+
+	ids := f.order[objectType]
+	var matching []fakeObject
+	for i := len(ids) - 1; i >= 0; i-- {
+		obj := f.objects[ids[i]]
+		if filter == nil || filter(obj) {
+			matching = append(matching, obj)
+		}
+	}
+
+	position := func(param string) (int, *fakeError) {
+		id := req.form.Get(param)
+		for i, obj := range matching {
+			if obj["id"] == id {
+				return i, nil
+			}
+		}
+		return 0, errResourceMissing(objectType, id, param)
+	}
+
+	var page []fakeObject
+	var hasMore bool
+	switch {
+	case req.form.Get(stripe.StartingAfter) != "":
+		i, err := position(stripe.StartingAfter)
+		if err != nil {
+			return nil, err
+		}
+		page = matching[i+1:]
+		if int64(len(page)) > limit {
+			page, hasMore = page[:limit], true
+		}
+	case req.form.Get(stripe.EndingBefore) != "":
+		i, err := position(stripe.EndingBefore)
+		if err != nil {
+			return nil, err
+		}
+		page = matching[:i]
+		if int64(len(page)) > limit {
+			page, hasMore = page[int64(len(page))-limit:], true
+		}
+	default:
+		page = matching
+		if int64(len(page)) > limit {
+			page, hasMore = page[:limit], true
+		}
+	}
+
+	data := make([]interface{}, len(page))
+	for i, obj := range page {
+		data[i] = obj
+	}
+	return fakeObject{
+		"object":   "list",
+		"data":     data,
+		"has_more": hasMore,
+		"url":      req.path,
+	}, nil
+}
+
+func (f *FakeBackend) newID(prefix string) string {
+	f.nextID[prefix]++
+	return fmt.Sprintf("%s_fake%010d", prefix, f.nextID[prefix])
+}
+
+// remove deletes the object from the fake, so that it can no longer be
+// retrieved or listed.
+func (f *FakeBackend) remove(obj fakeObject) {
+	id := obj["id"].(string)
+	objectType := obj["object"].(string)
+	delete(f.objects, id)
+	ids := f.order[objectType]
+	for i := range ids {
+		if ids[i] == id {
+			f.order[objectType] = append(ids[:i:i], ids[i+1:]...)
+			break
+		}
+	}
+}
+
+// serve routes the request to its handler and renders the response.
+func (f *FakeBackend) serve(method string, req *fakeRequest) *fakeResponse {
+	var obj fakeObject
+	var fakeErr *fakeError
+	routed := false
+	for _, route := range fakeRoutes {
+		if route.method != method {
+			continue
+		}
+		if m := route.pattern.FindStringSubmatch(req.path); m != nil {
+			req.ids = m[1:]
+			obj, fakeErr = route.handler(f, req)
+			routed = true
+			break
+		}
+	}
+	if !routed {
+		fakeErr = &fakeError{
+			Message: fmt.Sprintf("Unrecognized request URL (%s: %s).", method, req.path),
+			Type:    "invalid_request_error",
+			status:  http.StatusNotFound,
+		}
+	}
+
+	var resp *fakeResponse
+	if fakeErr != nil {
+		resp = fakeErr.response()
+	} else {
+		if paths := req.expand(); len(paths) > 0 {
+			obj = cloneObject(obj)
+			for _, path := range paths {
+				f.expandObject(obj, path)
+			}
+		}
+		body, err := json.Marshal(obj)
+		if err != nil {
+			panic(err)
+		}
+		resp = &fakeResponse{body: body, status: http.StatusOK}
+	}
+	resp.requestID = req.requestID
+	return resp
+}
+
+// serveLocked serves the request under the lock of the fake, replaying the
+// response of a previous request with the same idempotency key, and returns
+// the subscribers to call with its events. A panic while serving is turned
+// into an API error, so that the fake stays usable by later requests.
+func (f *FakeBackend) serveLocked(method string, req *fakeRequest) (resp *fakeResponse, subscribers []func(event *stripe.Event)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	defer func() {
+		if r := recover(); r != nil {
+			req.events = nil
+			resp = (&fakeError{
+				Message: fmt.Sprintf("The fake failed to serve the request (%s: %s): %v", method, req.path, r),
+				Type:    "api_error",
+				status:  http.StatusInternalServerError,
+			}).response()
+			resp.requestID = req.requestID
+		}
+	}()
+
+	subscribers = f.subscribers
+	resp, ok := f.idempotent[req.idempotencyKey]
+	if ok && req.idempotencyKey != "" && method == http.MethodPost {
+		return resp, subscribers
+	}
+	req.requestID = f.newID("req")
+	resp = f.serve(method, req)
+	if req.idempotencyKey != "" && method == http.MethodPost {
+		f.idempotent[req.idempotencyKey] = resp
+	}
+	return resp, subscribers
+}
+
+func (f *FakeBackend) timestamp() int64 {
+	return f.now().Unix()
+}
@@ -0,0 +1,325 @@
+package stripetest
+
+import (
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+	stripe "github.com/stripe/stripe-go/v81"
+	"github.com/stripe/stripe-go/v81/client"
+)
+
+func TestFakeBackendCustomers(t *testing.T) {
+	f := NewFakeBackend()
+	sc := client.New("sk_test_123", &stripe.Backends{API: f, Uploads: f})
+
+	cus, err := sc.Customers.New(&stripe.CustomerParams{
+		Email:    stripe.String("jenny@example.com"),
+		Metadata: map[string]string{"order_id": "6735"},
+	})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, cus.ID)
+	assert.Equal(t, "jenny@example.com", cus.Email)
+	assert.Equal(t, "6735", cus.Metadata["order_id"])
+	assert.NotEmpty(t, cus.LastResponse.RequestID)
+
+	cus, err = sc.Customers.Update(cus.ID, &stripe.CustomerParams{
+		Name:     stripe.String("Jenny Rosen"),
+		Metadata: map[string]string{"order_id": ""},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "Jenny Rosen", cus.Name)
+	assert.Empty(t, cus.Metadata)
+
+	got, err := sc.Customers.Get(cus.ID, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "Jenny Rosen", got.Name)
+
+	deleted, err := sc.Customers.Del(cus.ID, nil)
+	assert.NoError(t, err)
+	assert.True(t, deleted.Deleted)
+
+	_, err = sc.Customers.Get(cus.ID, nil)
+	stripeErr, ok := err.(*stripe.Error)
+	assert.True(t, ok)
+	assert.Equal(t, 404, stripeErr.HTTPStatusCode)
+	assert.Equal(t, stripe.ErrorCodeResourceMissing, stripeErr.Code)
+
+	var types []stripe.EventType
+	for _, event := range f.Events() {
+		types = append(types, event.Type)
+	}
+	assert.Equal(t, []stripe.EventType{
+		stripe.EventTypeCustomerCreated,
+		stripe.EventTypeCustomerUpdated,
+		stripe.EventTypeCustomerDeleted,
+	}, types)
+}
+
+func TestFakeBackendListPagination(t *testing.T) {
+	f := NewFakeBackend()
+	sc := client.New("sk_test_123", &stripe.Backends{API: f, Uploads: f})
+
+	var ids []string
+	for i := 0; i < 7; i++ {
+		product, err := sc.Products.New(&stripe.ProductParams{Name: stripe.String("Widget")})
+		assert.NoError(t, err)
+		ids = append([]string{product.ID}, ids...)
+	}
+
+	params := &stripe.ProductListParams{}
+	params.Limit = stripe.Int64(3)
+	var listed []string
+	iter := sc.Products.List(params)
+	for iter.Next() {
+		listed = append(listed, iter.Product().ID)
+	}
+	assert.NoError(t, iter.Err())
+	assert.Equal(t, ids, listed)
+
+	params = &stripe.ProductListParams{}
+	params.Limit = stripe.Int64(2)
+	params.EndingBefore = stripe.String(ids[5])
+	listed = nil
+	iter = sc.Products.List(params)
+	for iter.Next() {
+		listed = append(listed, iter.Product().ID)
+	}
+	assert.NoError(t, iter.Err())
+	assert.Equal(t, []string{ids[4], ids[3], ids[2], ids[1], ids[0]}, listed)
+}
+
+func TestFakeBackendPayments(t *testing.T) {
+	f := NewFakeBackend()
+	sc := client.New("sk_test_123", &stripe.Backends{API: f, Uploads: f})
+
+	var received []stripe.EventType
+	f.Subscribe(func(event *stripe.Event) {
+		received = append(received, event.Type)
+	})
+
+	cus, err := sc.Customers.New(&stripe.CustomerParams{})
+	assert.NoError(t, err)
+
+	pi, err := sc.PaymentIntents.New(&stripe.PaymentIntentParams{
+		Amount:        stripe.Int64(2000),
+		Currency:      stripe.String(string(stripe.CurrencyUSD)),
+		Customer:      stripe.String(cus.ID),
+		PaymentMethod: stripe.String("pm_card_visa"),
+		Confirm:       stripe.Bool(true),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, stripe.PaymentIntentStatusSucceeded, pi.Status)
+	assert.Equal(t, int64(2000), pi.AmountReceived)
+	assert.NotNil(t, pi.LatestCharge)
+
+	refund, err := sc.Refunds.New(&stripe.RefundParams{
+		PaymentIntent: stripe.String(pi.ID),
+		Amount:        stripe.Int64(500),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(500), refund.Amount)
+
+	_, err = sc.Refunds.New(&stripe.RefundParams{
+		PaymentIntent: stripe.String(pi.ID),
+		Amount:        stripe.Int64(1600),
+	})
+	assert.Error(t, err)
+
+	chargeParams := &stripe.ChargeParams{}
+	chargeParams.AddExpand("payment_intent")
+	charge, err := sc.Charges.Get(pi.LatestCharge.ID, chargeParams)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(500), charge.AmountRefunded)
+	assert.False(t, charge.Refunded)
+	assert.Equal(t, pi.ID, charge.PaymentIntent.ID)
+	assert.Equal(t, int64(2000), charge.PaymentIntent.Amount)
+
+	_, err = sc.PaymentIntents.New(&stripe.PaymentIntentParams{
+		Amount:        stripe.Int64(2000),
+		Currency:      stripe.String(string(stripe.CurrencyUSD)),
+		PaymentMethod: stripe.String("pm_card_chargeDeclined"),
+		Confirm:       stripe.Bool(true),
+	})
+	stripeErr, ok := err.(*stripe.Error)
+	assert.True(t, ok)
+	assert.Equal(t, stripe.ErrorTypeCard, stripeErr.Type)
+	assert.Equal(t, stripe.DeclineCode("generic_decline"), stripeErr.DeclineCode)
+	assert.Equal(t, stripe.PaymentIntentStatusRequiresPaymentMethod, stripeErr.PaymentIntent.Status)
+
+	assert.Equal(t, []stripe.EventType{
+		stripe.EventTypeCustomerCreated,
+		stripe.EventTypePaymentIntentCreated,
+		stripe.EventTypeChargeSucceeded,
+		stripe.EventTypePaymentIntentSucceeded,
+		stripe.EventTypeRefundCreated,
+		stripe.EventTypeChargeRefunded,
+		stripe.EventTypePaymentIntentCreated,
+		stripe.EventTypePaymentIntentPaymentFailed,
+	}, received)
+}
+
+func TestFakeBackendManualCapture(t *testing.T) {
+	f := NewFakeBackend()
+	sc := client.New("sk_test_123", &stripe.Backends{API: f, Uploads: f})
+
+	pi, err := sc.PaymentIntents.New(&stripe.PaymentIntentParams{
+		Amount:        stripe.Int64(2000),
+		Currency:      stripe.String(string(stripe.CurrencyUSD)),
+		CaptureMethod: stripe.String(string(stripe.PaymentIntentCaptureMethodManual)),
+		PaymentMethod: stripe.String("pm_card_visa"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, stripe.PaymentIntentStatusRequiresConfirmation, pi.Status)
+
+	pi, err = sc.PaymentIntents.Confirm(pi.ID, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, stripe.PaymentIntentStatusRequiresCapture, pi.Status)
+	assert.Equal(t, int64(2000), pi.AmountCapturable)
+
+	pi, err = sc.PaymentIntents.Capture(pi.ID, &stripe.PaymentIntentCaptureParams{
+		AmountToCapture: stripe.Int64(1500),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, stripe.PaymentIntentStatusSucceeded, pi.Status)
+	assert.Equal(t, int64(1500), pi.AmountReceived)
+
+	_, err = sc.PaymentIntents.Cancel(pi.ID, nil)
+	assert.Error(t, err)
+}
+
+func TestFakeBackendSubscriptions(t *testing.T) {
+	f := NewFakeBackend()
+	now := time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC)
+	f.SetNow(func() time.Time { return now })
+	sc := client.New("sk_test_123", &stripe.Backends{API: f, Uploads: f})
+
+	cus, err := sc.Customers.New(&stripe.CustomerParams{})
+	assert.NoError(t, err)
+	price, err := sc.Prices.New(&stripe.PriceParams{
+		Currency:    stripe.String(string(stripe.CurrencyUSD)),
+		UnitAmount:  stripe.Int64(1000),
+		ProductData: &stripe.PriceProductDataParams{Name: stripe.String("Gold")},
+		Recurring: &stripe.PriceRecurringParams{
+			Interval: stripe.String(string(stripe.PriceRecurringIntervalMonth)),
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, stripe.PriceTypeRecurring, price.Type)
+
+	params := &stripe.SubscriptionParams{
+		Customer: stripe.String(cus.ID),
+		Items: []*stripe.SubscriptionItemsParams{
+			{Price: stripe.String(price.ID), Quantity: stripe.Int64(2)},
+		},
+	}
+	params.AddExpand("latest_invoice")
+	sub, err := sc.Subscriptions.New(params)
+	assert.NoError(t, err)
+	assert.Equal(t, stripe.SubscriptionStatusActive, sub.Status)
+	assert.Equal(t, now.Unix(), sub.CurrentPeriodStart)
+	assert.Equal(t, now.AddDate(0, 1, 0).Unix(), sub.CurrentPeriodEnd)
+	assert.Equal(t, 1, len(sub.Items.Data))
+	assert.Equal(t, price.ID, sub.Items.Data[0].Price.ID)
+	assert.Equal(t, stripe.InvoiceStatusPaid, sub.LatestInvoice.Status)
+	assert.Equal(t, int64(2000), sub.LatestInvoice.AmountPaid)
+
+	oneTime, err := sc.Prices.New(&stripe.PriceParams{
+		Currency:   stripe.String(string(stripe.CurrencyUSD)),
+		UnitAmount: stripe.Int64(500),
+		Product:    stripe.String(price.Product.ID),
+	})
+	assert.NoError(t, err)
+	_, err = sc.Subscriptions.Update(sub.ID, &stripe.SubscriptionParams{
+		Items: []*stripe.SubscriptionItemsParams{
+			{ID: stripe.String(sub.Items.Data[0].ID), Price: stripe.String(oneTime.ID)},
+		},
+	})
+	assert.Error(t, err)
+	_, err = sc.Subscriptions.Update(sub.ID, &stripe.SubscriptionParams{
+		Items: []*stripe.SubscriptionItemsParams{
+			{ID: stripe.String(sub.Items.Data[0].ID), Deleted: stripe.Bool(true)},
+		},
+	})
+	assert.Error(t, err)
+	sub, err = sc.Subscriptions.Get(sub.ID, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(sub.Items.Data))
+	assert.Equal(t, price.ID, sub.Items.Data[0].Price.ID)
+
+	sub, err = sc.Subscriptions.Cancel(sub.ID, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, stripe.SubscriptionStatusCanceled, sub.Status)
+
+	listParams := &stripe.SubscriptionListParams{Customer: stripe.String(cus.ID)}
+	iter := sc.Subscriptions.List(listParams)
+	assert.False(t, iter.Next())
+	assert.NoError(t, iter.Err())
+
+	listParams.Status = stripe.String("all")
+	iter = sc.Subscriptions.List(listParams)
+	assert.True(t, iter.Next())
+}
+
+func TestFakeBackendIdempotency(t *testing.T) {
+	f := NewFakeBackend()
+	sc := client.New("sk_test_123", &stripe.Backends{API: f, Uploads: f})
+
+	params := &stripe.CustomerParams{}
+	params.SetIdempotencyKey("key_123")
+	first, err := sc.Customers.New(params)
+	assert.NoError(t, err)
+	second, err := sc.Customers.New(params)
+	assert.NoError(t, err)
+	assert.Equal(t, first.ID, second.ID)
+	assert.Equal(t, 1, len(f.Events()))
+}
+
+func TestFakeBackendUnknownRoute(t *testing.T) {
+	f := NewFakeBackend()
+	sc := client.New("sk_test_123", &stripe.Backends{API: f, Uploads: f})
+
+	_, err := sc.Coupons.Get("co_123", nil)
+	stripeErr, ok := err.(*stripe.Error)
+	assert.True(t, ok)
+	assert.Equal(t, 404, stripeErr.HTTPStatusCode)
+}
+
+func TestFakeBackendPanic(t *testing.T) {
+	routes := fakeRoutes
+	defer func() { fakeRoutes = routes }()
+	fakeRoutes = append([]fakeRoute{
+		newFakeRoute("GET", "/v1/coupons/{id}", func(f *FakeBackend, req *fakeRequest) (fakeObject, *fakeError) {
+			panic("boom")
+		}),
+	}, routes...)
+
+	f := NewFakeBackend()
+	sc := client.New("sk_test_123", &stripe.Backends{API: f, Uploads: f})
+
+	_, err := sc.Coupons.Get("co_123", nil)
+	stripeErr, ok := err.(*stripe.Error)
+	assert.True(t, ok)
+	assert.Equal(t, 500, stripeErr.HTTPStatusCode)
+	assert.Equal(t, stripe.ErrorTypeAPI, stripeErr.Type)
+
+	// The fake is still usable.
+	_, err = sc.Customers.New(&stripe.CustomerParams{})
+	assert.NoError(t, err)
+}
+
+func TestSubscriptionInterval(t *testing.T) {
+	interval, count, ok := subscriptionInterval(fakeObject{"items": fakeObject{"data": []interface{}{
+		fakeObject{"price": fakeObject{"recurring": fakeObject{"interval": "week", "interval_count": int64(2)}}},
+	}}})
+	assert.True(t, ok)
+	assert.Equal(t, "week", interval)
+	assert.Equal(t, int64(2), count)
+
+	_, _, ok = subscriptionInterval(fakeObject{"items": fakeObject{"data": []interface{}{}}})
+	assert.False(t, ok)
+	_, _, ok = subscriptionInterval(fakeObject{"items": fakeObject{"data": []interface{}{
+		fakeObject{"price": fakeObject{"recurring": nil}},
+	}}})
+	assert.False(t, ok)
+}
@@ -0,0 +1,1763 @@
+package stripetest
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	stripe "github.com/stripe/stripe-go/v81"
+)
+
+//
+// Private variables
+//
+
+// fakeRoutes lists the endpoints supported by FakeBackend.
+var fakeRoutes = []fakeRoute{
+	newFakeRoute(http.MethodPost, "/v1/customers", (*FakeBackend).createCustomer),
+	newFakeRoute(http.MethodGet, "/v1/customers", (*FakeBackend).listCustomers),
+	newFakeRoute(http.MethodGet, "/v1/customers/{id}", getFakeObject("customer")),
+	newFakeRoute(http.MethodPost, "/v1/customers/{id}", (*FakeBackend).updateCustomer),
+	newFakeRoute(http.MethodDelete, "/v1/customers/{id}", deleteFakeObject("customer", stripe.EventTypeCustomerDeleted, nil)),
+	newFakeRoute(http.MethodGet, "/v1/customers/{id}/payment_methods", (*FakeBackend).listCustomerPaymentMethods),
+
+	newFakeRoute(http.MethodPost, "/v1/payment_methods", (*FakeBackend).createPaymentMethod),
+	newFakeRoute(http.MethodGet, "/v1/payment_methods", (*FakeBackend).listPaymentMethods),
+	newFakeRoute(http.MethodGet, "/v1/payment_methods/{id}", getFakeObject("payment_method")),
+	newFakeRoute(http.MethodPost, "/v1/payment_methods/{id}", (*FakeBackend).updatePaymentMethod),
+	newFakeRoute(http.MethodPost, "/v1/payment_methods/{id}/attach", (*FakeBackend).attachPaymentMethod),
+	newFakeRoute(http.MethodPost, "/v1/payment_methods/{id}/detach", (*FakeBackend).detachPaymentMethod),
+
+	newFakeRoute(http.MethodPost, "/v1/payment_intents", (*FakeBackend).createPaymentIntent),
+	newFakeRoute(http.MethodGet, "/v1/payment_intents", (*FakeBackend).listPaymentIntents),
+	newFakeRoute(http.MethodGet, "/v1/payment_intents/{id}", getFakeObject("payment_intent")),
+	newFakeRoute(http.MethodPost, "/v1/payment_intents/{id}", (*FakeBackend).updatePaymentIntent),
+	newFakeRoute(http.MethodPost, "/v1/payment_intents/{id}/cancel", (*FakeBackend).cancelPaymentIntent),
+	newFakeRoute(http.MethodPost, "/v1/payment_intents/{id}/capture", (*FakeBackend).capturePaymentIntent),
+	newFakeRoute(http.MethodPost, "/v1/payment_intents/{id}/confirm", (*FakeBackend).confirmPaymentIntent),
+
+	newFakeRoute(http.MethodGet, "/v1/charges", (*FakeBackend).listCharges),
+	newFakeRoute(http.MethodGet, "/v1/charges/{id}", getFakeObject("charge")),
+
+	newFakeRoute(http.MethodPost, "/v1/refunds", (*FakeBackend).createRefund),
+	newFakeRoute(http.MethodGet, "/v1/refunds", (*FakeBackend).listRefunds),
+	newFakeRoute(http.MethodGet, "/v1/refunds/{id}", getFakeObject("refund")),
+	newFakeRoute(http.MethodPost, "/v1/refunds/{id}", (*FakeBackend).updateRefund),
+
+	newFakeRoute(http.MethodPost, "/v1/products", (*FakeBackend).createProduct),
+	newFakeRoute(http.MethodGet, "/v1/products", (*FakeBackend).listProducts),
+	newFakeRoute(http.MethodGet, "/v1/products/{id}", getFakeObject("product")),
+	newFakeRoute(http.MethodPost, "/v1/products/{id}", (*FakeBackend).updateProduct),
+	newFakeRoute(http.MethodDelete, "/v1/products/{id}", deleteFakeObject("product", stripe.EventTypeProductDeleted, (*FakeBackend).checkProductDeletable)),
+
+	newFakeRoute(http.MethodPost, "/v1/prices", (*FakeBackend).createPrice),
+	newFakeRoute(http.MethodGet, "/v1/prices", (*FakeBackend).listPrices),
+	newFakeRoute(http.MethodGet, "/v1/prices/{id}", getFakeObject("price")),
+	newFakeRoute(http.MethodPost, "/v1/prices/{id}", (*FakeBackend).updatePrice),
+
+	newFakeRoute(http.MethodPost, "/v1/subscriptions", (*FakeBackend).createSubscription),
+	newFakeRoute(http.MethodGet, "/v1/subscriptions", (*FakeBackend).listSubscriptions),
+	newFakeRoute(http.MethodGet, "/v1/subscriptions/{id}", getFakeObject("subscription")),
+	newFakeRoute(http.MethodPost, "/v1/subscriptions/{id}", (*FakeBackend).updateSubscription),
+	newFakeRoute(http.MethodDelete, "/v1/subscriptions/{id}", (*FakeBackend).cancelSubscription),
+
+	newFakeRoute(http.MethodPost, "/v1/invoices", (*FakeBackend).createInvoice),
+	newFakeRoute(http.MethodGet, "/v1/invoices", (*FakeBackend).listInvoices),
+	newFakeRoute(http.MethodGet, "/v1/invoices/{id}", getFakeObject("invoice")),
+	newFakeRoute(http.MethodPost, "/v1/invoices/{id}", (*FakeBackend).updateInvoice),
+	newFakeRoute(http.MethodDelete, "/v1/invoices/{id}", deleteFakeObject("invoice", stripe.EventTypeInvoiceDeleted, (*FakeBackend).checkInvoiceDeletable)),
+	newFakeRoute(http.MethodPost, "/v1/invoices/{id}/finalize", (*FakeBackend).finalizeInvoice),
+	newFakeRoute(http.MethodPost, "/v1/invoices/{id}/mark_uncollectible", invoiceTransition("uncollectible", stripe.EventTypeInvoiceMarkedUncollectible)),
+	newFakeRoute(http.MethodPost, "/v1/invoices/{id}/pay", (*FakeBackend).payInvoice),
+	newFakeRoute(http.MethodPost, "/v1/invoices/{id}/void", invoiceTransition("void", stripe.EventTypeInvoiceVoided)),
+
+	newFakeRoute(http.MethodPost, "/v1/test_helpers/test_clocks", (*FakeBackend).createTestClock),
+	newFakeRoute(http.MethodGet, "/v1/test_helpers/test_clocks", (*FakeBackend).listTestClocks),
+	newFakeRoute(http.MethodGet, "/v1/test_helpers/test_clocks/{id}", getFakeObject("test_helpers.test_clock")),
+	newFakeRoute(http.MethodDelete, "/v1/test_helpers/test_clocks/{id}", (*FakeBackend).deleteTestClock),
+	newFakeRoute(http.MethodPost, "/v1/test_helpers/test_clocks/{id}/advance", (*FakeBackend).advanceTestClock),
+
+	newFakeRoute(http.MethodGet, "/v1/events", (*FakeBackend).listEvents),
+	newFakeRoute(http.MethodGet, "/v1/events/{id}", getFakeObject("event")),
+}
+
+// fakeInvoiceMaxAttempts is the number of times the payment of a subscription
+// invoice is attempted before the subscription is canceled.
+const fakeInvoiceMaxAttempts = 4
+
+// fakeInvoiceRetryDelay is the delay between the payment attempts of a
+// subscription invoice.
+const fakeInvoiceRetryDelay = 3 * 24 * time.Hour
+
+// fakeTrialWillEndNotice is how long before the end of a trial the
+// `customer.subscription.trial_will_end` event is emitted.
+const fakeTrialWillEndNotice = 3 * 24 * time.Hour
+
+// fakeTestPaymentMethods are the test payment methods which can be used in
+// place of a payment method ID, mapped to the card number they stand for.
+var fakeTestPaymentMethods = map[string]string{
+	"pm_card_amex":                            "378282246310005",
+	"pm_card_chargeDeclined":                  "4000000000000002",
+	"pm_card_chargeDeclinedInsufficientFunds": "4000000000009995",
+	"pm_card_mastercard":                      "5555555555554444",
+	"pm_card_visa":                            "4242424242424242",
+}
+
+// fakeDeclinedCards are the card numbers whose payments are declined, mapped
+// to their decline code.
+var fakeDeclinedCards = map[string]string{
+	"4000000000000002": "generic_decline",
+	"4000000000009995": "insufficient_funds",
+	"4000000000009987": "lost_card",
+	"4000000000009979": "stolen_card",
+}
+
+//
+// Private functions
+//
+
+func newFakeRoute(method, path string, handler func(f *FakeBackend, req *fakeRequest) (fakeObject, *fakeError)) fakeRoute {
+	pattern := "^" + strings.Replace(regexp.QuoteMeta(path), `\{id\}`, "([^/]+)", -1) + "$"
+	return fakeRoute{handler: handler, method: method, pattern: regexp.MustCompile(pattern)}
+}
+
+// deleteFakeObject returns a handler deleting objects of the given type,
+// after checking that they can be deleted if check isn't nil.
+func deleteFakeObject(objectType string, eventType stripe.EventType, check func(f *FakeBackend, obj fakeObject) *fakeError) func(f *FakeBackend, req *fakeRequest) (fakeObject, *fakeError) {
+	return func(f *FakeBackend, req *fakeRequest) (fakeObject, *fakeError) {
+		obj, err := f.get(objectType, req.ids[0], "id")
+		if err != nil {
+			return nil, err
+		}
+		if check != nil {
+			if err := check(f, obj); err != nil {
+				return nil, err
+			}
+		}
+
+		f.remove(obj)
+		f.emit(req, eventType, obj, nil)
+
+		return fakeObject{"id": req.ids[0], "object": objectType, "deleted": true}, nil
+	}
+}
+
+// getFakeObject returns a handler retrieving objects of the given type.
+func getFakeObject(objectType string) func(f *FakeBackend, req *fakeRequest) (fakeObject, *fakeError) {
+	return func(f *FakeBackend, req *fakeRequest) (fakeObject, *fakeError) {
+		return f.get(objectType, req.ids[0], "id")
+	}
+}
+
+// fieldFilter returns a list filter matching the objects for which the given
+// fields are equal to the parameters of the same name, when they're set.
+// Expandable fields match on the ID of the object they reference.
+func fieldFilter(req *fakeRequest, fields ...string) func(fakeObject) bool {
+	return func(obj fakeObject) bool {
+		for _, field := range fields {
+			if !req.has(field) {
+				continue
+			}
+			v := obj[field]
+			if expanded, ok := v.(fakeObject); ok {
+				v = expanded["id"]
+			}
+			if fmt.Sprint(v) != req.form.Get(field) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// fakeCard returns the `card` hash of a payment method with the given card
+// number.
+func fakeCard(number string, expMonth, expYear int64) fakeObject {
+	brand := "visa"
+	switch {
+	case strings.HasPrefix(number, "34"), strings.HasPrefix(number, "37"):
+		brand = "amex"
+	case strings.HasPrefix(number, "5"), strings.HasPrefix(number, "2"):
+		brand = "mastercard"
+	case strings.HasPrefix(number, "6"):
+		brand = "discover"
+	}
+
+	last4 := number
+	if len(last4) > 4 {
+		last4 = last4[len(last4)-4:]
+	}
+
+	return fakeObject{
+		"brand":     brand,
+		"country":   "US",
+		"exp_month": expMonth,
+		"exp_year":  expYear,
+		"funding":   "credit",
+		"last4":     last4,
+	}
+}
+
+//
+// Customers
+//
+
+func (f *FakeBackend) createCustomer(req *fakeRequest) (fakeObject, *fakeError) {
+	obj := fakeObject{
+		"id":             f.newID("cus"),
+		"object":         "customer",
+		"address":        nil,
+		"balance":        int64(0),
+		"created":        f.timestamp(),
+		"currency":       nil,
+		"default_source": nil,
+		"delinquent":     false,
+		"description":    nil,
+		"email":          nil,
+		"invoice_settings": fakeObject{
+			"custom_fields":          nil,
+			"default_payment_method": nil,
+			"footer":                 nil,
+		},
+		"livemode":   false,
+		"metadata":   fakeObject{},
+		"name":       nil,
+		"phone":      nil,
+		"test_clock": nil,
+	}
+	if req.has("test_clock") {
+		clock, err := f.get("test_helpers.test_clock", req.form.Get("test_clock"), "test_clock")
+		if err != nil {
+			return nil, err
+		}
+		obj["test_clock"] = clock["id"]
+	}
+	if err := f.setCustomerFields(req, obj); err != nil {
+		return nil, err
+	}
+
+	var pm fakeObject
+	if req.has("payment_method") {
+		var err *fakeError
+		if pm, err = f.paymentMethod(req.form.Get("payment_method"), "payment_method"); err != nil {
+			return nil, err
+		}
+	}
+
+	f.insert(obj)
+	f.emit(req, stripe.EventTypeCustomerCreated, obj, nil)
+
+	if pm != nil {
+		before := cloneObject(pm)
+		pm["customer"] = obj["id"]
+		f.emit(req, stripe.EventTypePaymentMethodAttached, pm, diffObjects(before, pm))
+	}
+	return obj, nil
+}
+
+func (f *FakeBackend) listCustomers(req *fakeRequest) (fakeObject, *fakeError) {
+	return f.list(req, "customer", fieldFilter(req, "email"))
+}
+
+func (f *FakeBackend) setCustomerFields(req *fakeRequest, obj fakeObject) *fakeError {
+	req.setStrings(obj, "description", "email", "name", "phone")
+	req.setMetadata(obj)
+
+	if req.has("balance") {
+		balance, err := req.integer("balance")
+		if err != nil {
+			return err
+		}
+		obj["balance"] = balance
+	}
+
+	const defaultPaymentMethod = "invoice_settings[default_payment_method]"
+	if req.has(defaultPaymentMethod) {
+		settings := obj["invoice_settings"].(fakeObject)
+		if id := req.form.Get(defaultPaymentMethod); id != "" {
+			pm, err := f.paymentMethod(id, defaultPaymentMethod)
+			if err != nil {
+				return err
+			}
+			if pm["customer"] == nil {
+				pm["customer"] = obj["id"]
+				f.emit(req, stripe.EventTypePaymentMethodAttached, pm, fakeObject{"customer": nil})
+			}
+			settings["default_payment_method"] = pm["id"]
+		} else {
+			settings["default_payment_method"] = nil
+		}
+	}
+	return nil
+}
+
+func (f *FakeBackend) updateCustomer(req *fakeRequest) (fakeObject, *fakeError) {
+	obj, err := f.get("customer", req.ids[0], "id")
+	if err != nil {
+		return nil, err
+	}
+	before := cloneObject(obj)
+	if err := f.setCustomerFields(req, obj); err != nil {
+		return nil, err
+	}
+	f.emitUpdated(req, stripe.EventTypeCustomerUpdated, before, obj)
+	return obj, nil
+}
+
+//
+// Payment methods
+//
+
+func (f *FakeBackend) attachPaymentMethod(req *fakeRequest) (fakeObject, *fakeError) {
+	pm, err := f.get("payment_method", req.ids[0], "id")
+	if err != nil {
+		return nil, err
+	}
+	if !req.has("customer") {
+		return nil, errMissingParam("customer")
+	}
+	customer, err := f.get("customer", req.form.Get("customer"), "customer")
+	if err != nil {
+		return nil, err
+	}
+
+	before := cloneObject(pm)
+	pm["customer"] = customer["id"]
+	f.emit(req, stripe.EventTypePaymentMethodAttached, pm, diffObjects(before, pm))
+	return pm, nil
+}
+
+func (f *FakeBackend) createPaymentMethod(req *fakeRequest) (fakeObject, *fakeError) {
+	if !req.has("type") {
+		return nil, errMissingParam("type")
+	}
+
+	obj := fakeObject{
+		"id":     f.newID("pm"),
+		"object": "payment_method",
+		"billing_details": fakeObject{
+			"address": nil,
+			"email":   nil,
+			"name":    nil,
+			"phone":   nil,
+		},
+		"created":  f.timestamp(),
+		"customer": nil,
+		"livemode": false,
+		"metadata": fakeObject{},
+		"type":     req.form.Get("type"),
+	}
+
+	if obj["type"] == "card" {
+		number := req.form.Get("card[number]")
+		if number == "" {
+			number = fakeTestPaymentMethods["pm_card_visa"]
+		}
+		expMonth, err := req.integer("card[exp_month]")
+		if err != nil {
+			return nil, err
+		}
+		expYear, err := req.integer("card[exp_year]")
+		if err != nil {
+			return nil, err
+		}
+		if expMonth == 0 {
+			expMonth = 12
+		}
+		if expYear == 0 {
+			expYear = int64(f.now().Year() + 5)
+		}
+		obj["card"] = fakeCard(number, expMonth, expYear)
+		if declineCode, ok := fakeDeclinedCards[number]; ok {
+			f.declines[obj["id"].(string)] = declineCode
+		}
+	}
+
+	f.setPaymentMethodFields(req, obj)
+	f.insert(obj)
+	return obj, nil
+}
+
+func (f *FakeBackend) detachPaymentMethod(req *fakeRequest) (fakeObject, *fakeError) {
+	pm, err := f.get("payment_method", req.ids[0], "id")
+	if err != nil {
+		return nil, err
+	}
+	if pm["customer"] == nil {
+		return nil, errInvalidRequest("payment_method_unexpected_state",
+			"The payment method you provided is not attached to a customer so detachment is impossible.")
+	}
+
+	before := cloneObject(pm)
+	pm["customer"] = nil
+	f.emit(req, stripe.EventTypePaymentMethodDetached, pm, diffObjects(before, pm))
+	return pm, nil
+}
+
+func (f *FakeBackend) listCustomerPaymentMethods(req *fakeRequest) (fakeObject, *fakeError) {
+	if _, err := f.get("customer", req.ids[0], "customer"); err != nil {
+		return nil, err
+	}
+	typeFilter := fieldFilter(req, "type")
+	return f.list(req, "payment_method", func(obj fakeObject) bool {
+		return obj["customer"] == req.ids[0] && typeFilter(obj)
+	})
+}
+
+func (f *FakeBackend) listPaymentMethods(req *fakeRequest) (fakeObject, *fakeError) {
+	return f.list(req, "payment_method", fieldFilter(req, "customer", "type"))
+}
+
+// paymentMethod returns the payment method with the given ID. Test payment
+// methods like `pm_card_visa` create a new payment method, like on the API.
+func (f *FakeBackend) paymentMethod(id, param string) (fakeObject, *fakeError) {
+	number, ok := fakeTestPaymentMethods[id]
+	if !ok {
+		return f.get("payment_method", id, param)
+	}
+
+	return f.createPaymentMethod(&fakeRequest{form: map[string][]string{
+		"card[number]": {number},
+		"type":         {"card"},
+	}})
+}
+
+func (f *FakeBackend) setPaymentMethodFields(req *fakeRequest, obj fakeObject) {
+	details := obj["billing_details"].(fakeObject)
+	for _, key := range []string{"email", "name", "phone"} {
+		param := "billing_details[" + key + "]"
+		if req.has(param) {
+			if v := req.form.Get(param); v != "" {
+				details[key] = v
+			} else {
+				details[key] = nil
+			}
+		}
+	}
+	req.setMetadata(obj)
+}
+
+func (f *FakeBackend) updatePaymentMethod(req *fakeRequest) (fakeObject, *fakeError) {
+	pm, err := f.get("payment_method", req.ids[0], "id")
+	if err != nil {
+		return nil, err
+	}
+	before := cloneObject(pm)
+	f.setPaymentMethodFields(req, pm)
+	f.emitUpdated(req, stripe.EventTypePaymentMethodUpdated, before, pm)
+	return pm, nil
+}
+
+//
+// Payment intents and charges
+//
+
+func (f *FakeBackend) cancelPaymentIntent(req *fakeRequest) (fakeObject, *fakeError) {
+	pi, err := f.get("payment_intent", req.ids[0], "id")
+	if err != nil {
+		return nil, err
+	}
+	switch pi["status"] {
+	case "succeeded", "canceled":
+		return nil, errInvalidRequest("payment_intent_unexpected_state", fmt.Sprintf(
+			"You cannot cancel this PaymentIntent because it has a status of %s.", pi["status"]))
+	}
+
+	if pi["status"] == "requires_capture" {
+		if charge, ok := f.objects[fmt.Sprint(pi["latest_charge"])]; ok {
+			charge["amount_refunded"] = charge["amount"]
+			charge["refunded"] = true
+		}
+		pi["amount_capturable"] = int64(0)
+	}
+
+	pi["cancellation_reason"] = nil
+	req.setStrings(pi, "cancellation_reason")
+	pi["canceled_at"] = f.timestamp()
+	pi["status"] = "canceled"
+	f.emit(req, stripe.EventTypePaymentIntentCanceled, pi, nil)
+	return pi, nil
+}
+
+func (f *FakeBackend) capturePaymentIntent(req *fakeRequest) (fakeObject, *fakeError) {
+	pi, err := f.get("payment_intent", req.ids[0], "id")
+	if err != nil {
+		return nil, err
+	}
+	if pi["status"] != "requires_capture" {
+		return nil, errInvalidRequest("payment_intent_unexpected_state", fmt.Sprintf(
+			"This PaymentIntent could not be captured because it has a status of %s. Only a PaymentIntent with one of the following statuses may be captured: requires_capture.", pi["status"]))
+	}
+
+	capturable := pi["amount_capturable"].(int64)
+	amount := capturable
+	if req.has("amount_to_capture") {
+		if amount, err = req.integer("amount_to_capture"); err != nil {
+			return nil, err
+		}
+		if amount <= 0 || amount > capturable {
+			return nil, errInvalidParam("amount_to_capture", fmt.Sprintf(
+				"The amount to capture must be between 1 and the capturable amount of %d.", capturable))
+		}
+	}
+
+	charge := f.objects[pi["latest_charge"].(string)]
+	charge["amount_captured"] = amount
+	charge["amount_refunded"] = capturable - amount
+	charge["captured"] = true
+
+	pi["amount_capturable"] = int64(0)
+	pi["amount_received"] = amount
+	pi["status"] = "succeeded"
+
+	f.emit(req, stripe.EventTypeChargeCaptured, charge, nil)
+	f.emit(req, stripe.EventTypePaymentIntentSucceeded, pi, nil)
+	return pi, nil
+}
+
+func (f *FakeBackend) confirmPaymentIntent(req *fakeRequest) (fakeObject, *fakeError) {
+	pi, err := f.get("payment_intent", req.ids[0], "id")
+	if err != nil {
+		return nil, err
+	}
+	if req.has("payment_method") {
+		pm, err := f.paymentMethod(req.form.Get("payment_method"), "payment_method")
+		if err != nil {
+			return nil, err
+		}
+		pi["payment_method"] = pm["id"]
+	}
+	return f.confirm(req, pi)
+}
+
+// confirm attempts the payment of a payment intent, creating a charge if it
+// succeeds.
+func (f *FakeBackend) confirm(req *fakeRequest, pi fakeObject) (fakeObject, *fakeError) {
+	switch pi["status"] {
+	case "requires_payment_method", "requires_confirmation":
+	default:
+		return nil, errInvalidRequest("payment_intent_unexpected_state", fmt.Sprintf(
+			"This PaymentIntent's status is %s, but must be one of requires_payment_method or requires_confirmation to be confirmed.", pi["status"]))
+	}
+	if pi["payment_method"] == nil {
+		return nil, errInvalidRequest("payment_intent_unexpected_state",
+			"You cannot confirm this PaymentIntent because it's missing a payment method.")
+	}
+
+	pmID := pi["payment_method"].(string)
+	if declineCode, ok := f.declines[pmID]; ok {
+		pi["last_payment_error"] = fakeObject{
+			"code":           "card_declined",
+			"decline_code":   declineCode,
+			"message":        "Your card was declined.",
+			"payment_method": cloneObject(f.objects[pmID]),
+			"type":           "card_error",
+		}
+		pi["payment_method"] = nil
+		pi["status"] = "requires_payment_method"
+		f.emit(req, stripe.EventTypePaymentIntentPaymentFailed, pi, nil)
+
+		return nil, &fakeError{
+			Code:          "card_declined",
+			DeclineCode:   declineCode,
+			Message:       "Your card was declined.",
+			PaymentIntent: cloneObject(pi),
+			Type:          "card_error",
+			status:        http.StatusPaymentRequired,
+		}
+	}
+
+	amount := pi["amount"].(int64)
+	automatic := pi["capture_method"] != "manual"
+	charge := fakeObject{
+		"id":              f.newID("ch"),
+		"object":          "charge",
+		"amount":          amount,
+		"amount_captured": int64(0),
+		"amount_refunded": int64(0),
+		"captured":        automatic,
+		"created":         f.timestamp(),
+		"currency":        pi["currency"],
+		"customer":        pi["customer"],
+		"description":     pi["description"],
+		"livemode":        false,
+		"metadata":        cloneObject(pi["metadata"].(fakeObject)),
+		"paid":            true,
+		"payment_intent":  pi["id"],
+		"payment_method":  pmID,
+		"refunded":        false,
+		"status":          "succeeded",
+	}
+	if automatic {
+		charge["amount_captured"] = amount
+	}
+	f.insert(charge)
+
+	pi["last_payment_error"] = nil
+	pi["latest_charge"] = charge["id"]
+	if automatic {
+		pi["amount_received"] = amount
+		pi["status"] = "succeeded"
+	} else {
+		pi["amount_capturable"] = amount
+		pi["status"] = "requires_capture"
+	}
+
+	f.emit(req, stripe.EventTypeChargeSucceeded, charge, nil)
+	if automatic {
+		f.emit(req, stripe.EventTypePaymentIntentSucceeded, pi, nil)
+	} else {
+		f.emit(req, stripe.EventTypePaymentIntentAmountCapturableUpdated, pi, nil)
+	}
+	return pi, nil
+}
+
+func (f *FakeBackend) createPaymentIntent(req *fakeRequest) (fakeObject, *fakeError) {
+	for _, param := range []string{"amount", "currency"} {
+		if !req.has(param) {
+			return nil, errMissingParam(param)
+		}
+	}
+	amount, err := req.integer("amount")
+	if err != nil {
+		return nil, err
+	}
+	if amount <= 0 {
+		return nil, errInvalidParam("amount", "Amount must be at least 1.")
+	}
+
+	id := f.newID("pi")
+	pi := fakeObject{
+		"id":                   id,
+		"object":               "payment_intent",
+		"amount":               amount,
+		"amount_capturable":    int64(0),
+		"amount_received":      int64(0),
+		"canceled_at":          nil,
+		"cancellation_reason":  nil,
+		"capture_method":       "automatic",
+		"client_secret":        id + "_secret_fake",
+		"confirmation_method":  "automatic",
+		"created":              f.timestamp(),
+		"currency":             strings.ToLower(req.form.Get("currency")),
+		"customer":             nil,
+		"description":          nil,
+		"last_payment_error":   nil,
+		"latest_charge":        nil,
+		"livemode":             false,
+		"metadata":             fakeObject{},
+		"payment_method":       nil,
+		"payment_method_types": []interface{}{"card"},
+		"receipt_email":        nil,
+		"status":               "requires_payment_method",
+	}
+	req.setStrings(pi, "capture_method")
+	if err := f.setPaymentIntentFields(req, pi); err != nil {
+		return nil, err
+	}
+
+	confirm, err := req.boolean("confirm")
+	if err != nil {
+		return nil, err
+	}
+
+	f.insert(pi)
+	f.emit(req, stripe.EventTypePaymentIntentCreated, pi, nil)
+
+	if confirm {
+		return f.confirm(req, pi)
+	}
+	return pi, nil
+}
+
+func (f *FakeBackend) listCharges(req *fakeRequest) (fakeObject, *fakeError) {
+	return f.list(req, "charge", fieldFilter(req, "customer", "payment_intent"))
+}
+
+func (f *FakeBackend) listPaymentIntents(req *fakeRequest) (fakeObject, *fakeError) {
+	return f.list(req, "payment_intent", fieldFilter(req, "customer"))
+}
+
+func (f *FakeBackend) setPaymentIntentFields(req *fakeRequest, pi fakeObject) *fakeError {
+	req.setStrings(pi, "description", "receipt_email")
+	req.setMetadata(pi)
+
+	if req.has("amount") {
+		amount, err := req.integer("amount")
+		if err != nil {
+			return err
+		}
+		pi["amount"] = amount
+	}
+	if req.has("currency") {
+		pi["currency"] = strings.ToLower(req.form.Get("currency"))
+	}
+	if req.has("customer") {
+		customer, err := f.get("customer", req.form.Get("customer"), "customer")
+		if err != nil {
+			return err
+		}
+		pi["customer"] = customer["id"]
+	}
+	if req.has("payment_method") {
+		pm, err := f.paymentMethod(req.form.Get("payment_method"), "payment_method")
+		if err != nil {
+			return err
+		}
+		pi["payment_method"] = pm["id"]
+		pi["status"] = "requires_confirmation"
+	}
+	return nil
+}
+
+func (f *FakeBackend) updatePaymentIntent(req *fakeRequest) (fakeObject, *fakeError) {
+	pi, err := f.get("payment_intent", req.ids[0], "id")
+	if err != nil {
+		return nil, err
+	}
+	switch pi["status"] {
+	case "requires_payment_method", "requires_confirmation":
+	default:
+		return nil, errInvalidRequest("payment_intent_unexpected_state", fmt.Sprintf(
+			"You cannot update this PaymentIntent because it has a status of %s.", pi["status"]))
+	}
+	if err := f.setPaymentIntentFields(req, pi); err != nil {
+		return nil, err
+	}
+	return pi, nil
+}
+
+//
+// Refunds
+//
+
+func (f *FakeBackend) createRefund(req *fakeRequest) (fakeObject, *fakeError) {
+	var charge fakeObject
+	switch {
+	case req.has("charge"):
+		var err *fakeError
+		if charge, err = f.get("charge", req.form.Get("charge"), "charge"); err != nil {
+			return nil, err
+		}
+	case req.has("payment_intent"):
+		pi, err := f.get("payment_intent", req.form.Get("payment_intent"), "payment_intent")
+		if err != nil {
+			return nil, err
+		}
+		if pi["status"] != "succeeded" {
+			return nil, errInvalidRequest("payment_intent_unexpected_state",
+				"This PaymentIntent does not have a successful charge to refund.")
+		}
+		charge = f.objects[pi["latest_charge"].(string)]
+	default:
+		return nil, errInvalidRequest("parameter_missing",
+			"One of the following params should be provided for this request: payment_intent or charge.")
+	}
+
+	refundable := charge["amount_captured"].(int64) - charge["amount_refunded"].(int64)
+	if refundable <= 0 {
+		return nil, errInvalidRequest("charge_already_refunded",
+			fmt.Sprintf("Charge %s has already been refunded.", charge["id"]))
+	}
+	amount := refundable
+	if req.has("amount") {
+		var err *fakeError
+		if amount, err = req.integer("amount"); err != nil {
+			return nil, err
+		}
+		if amount <= 0 || amount > refundable {
+			err := errInvalidParam("amount", fmt.Sprintf(
+				"Refund amount (%d) is greater than unrefunded amount on charge (%d)", amount, refundable))
+			err.Code = "amount_too_large"
+			return nil, err
+		}
+	}
+
+	refund := fakeObject{
+		"id":             f.newID("re"),
+		"object":         "refund",
+		"amount":         amount,
+		"charge":         charge["id"],
+		"created":        f.timestamp(),
+		"currency":       charge["currency"],
+		"metadata":       fakeObject{},
+		"payment_intent": charge["payment_intent"],
+		"reason":         nil,
+		"status":         "succeeded",
+	}
+	req.setStrings(refund, "reason")
+	req.setMetadata(refund)
+	f.insert(refund)
+
+	before := cloneObject(charge)
+	charge["amount_refunded"] = charge["amount_refunded"].(int64) + amount
+	charge["refunded"] = charge["amount_refunded"] == charge["amount"]
+
+	f.emit(req, stripe.EventTypeRefundCreated, refund, nil)
+	f.emit(req, stripe.EventTypeChargeRefunded, charge, diffObjects(before, charge))
+	return refund, nil
+}
+
+func (f *FakeBackend) listRefunds(req *fakeRequest) (fakeObject, *fakeError) {
+	return f.list(req, "refund", fieldFilter(req, "charge", "payment_intent"))
+}
+
+func (f *FakeBackend) updateRefund(req *fakeRequest) (fakeObject, *fakeError) {
+	refund, err := f.get("refund", req.ids[0], "id")
+	if err != nil {
+		return nil, err
+	}
+	before := cloneObject(refund)
+	req.setMetadata(refund)
+	f.emitUpdated(req, stripe.EventTypeRefundUpdated, before, refund)
+	return refund, nil
+}
+
+//
+// Products and prices
+//
+
+func (f *FakeBackend) checkProductDeletable(product fakeObject) *fakeError {
+	for _, id := range f.order["price"] {
+		if f.objects[id]["product"] == product["id"] {
+			return errInvalidRequest("", "This product cannot be deleted because it has one or more user-created prices.")
+		}
+	}
+	return nil
+}
+
+func (f *FakeBackend) createPrice(req *fakeRequest) (fakeObject, *fakeError) {
+	if !req.has("currency") {
+		return nil, errMissingParam("currency")
+	}
+	if !req.has("unit_amount") {
+		return nil, errMissingParam("unit_amount")
+	}
+	unitAmount, err := req.integer("unit_amount")
+	if err != nil {
+		return nil, err
+	}
+
+	var product fakeObject
+	switch {
+	case req.has("product"):
+		if product, err = f.get("product", req.form.Get("product"), "product"); err != nil {
+			return nil, err
+		}
+	case req.has("product_data[name]"):
+		productReq := &fakeRequest{form: map[string][]string{
+			"name": {req.form.Get("product_data[name]")},
+		}, requestID: req.requestID}
+		if product, err = f.createProduct(productReq); err != nil {
+			return nil, err
+		}
+		req.events = append(req.events, productReq.events...)
+	default:
+		return nil, errMissingParam("product")
+	}
+
+	price := fakeObject{
+		"id":                  f.newID("price"),
+		"object":              "price",
+		"active":              true,
+		"billing_scheme":      "per_unit",
+		"created":             f.timestamp(),
+		"currency":            strings.ToLower(req.form.Get("currency")),
+		"livemode":            false,
+		"lookup_key":          nil,
+		"metadata":            fakeObject{},
+		"nickname":            nil,
+		"product":             product["id"],
+		"recurring":           nil,
+		"tax_behavior":        "unspecified",
+		"type":                "one_time",
+		"unit_amount":         unitAmount,
+		"unit_amount_decimal": fmt.Sprint(unitAmount),
+	}
+
+	if req.has("recurring[interval]") {
+		interval := req.form.Get("recurring[interval]")
+		switch interval {
+		case "day", "week", "month", "year":
+		default:
+			return nil, errInvalidParam("recurring[interval]",
+				"Invalid recurring[interval]: must be one of day, week, month, or year")
+		}
+		count := int64(1)
+		if req.has("recurring[interval_count]") {
+			if count, err = req.integer("recurring[interval_count]"); err != nil {
+				return nil, err
+			}
+		}
+		price["recurring"] = fakeObject{
+			"interval":       interval,
+			"interval_count": count,
+			"usage_type":     "licensed",
+		}
+		price["type"] = "recurring"
+	}
+
+	if err := f.setPriceFields(req, price); err != nil {
+		return nil, err
+	}
+	f.insert(price)
+	f.emit(req, stripe.EventTypePriceCreated, price, nil)
+	return price, nil
+}
+
+func (f *FakeBackend) createProduct(req *fakeRequest) (fakeObject, *fakeError) {
+	if !req.has("name") {
+		return nil, errMissingParam("name")
+	}
+
+	id := req.form.Get("id")
+	if id == "" {
+		id = f.newID("prod")
+	} else if _, ok := f.objects[id]; ok {
+		return nil, errInvalidRequest("resource_already_exists", "Product already exists.")
+	}
+
+	now := f.timestamp()
+	product := fakeObject{
+		"id":            id,
+		"object":        "product",
+		"active":        true,
+		"created":       now,
+		"default_price": nil,
+		"description":   nil,
+		"images":        []interface{}{},
+		"livemode":      false,
+		"metadata":      fakeObject{},
+		"name":          nil,
+		"updated":       now,
+		"url":           nil,
+	}
+	if err := f.setProductFields(req, product); err != nil {
+		return nil, err
+	}
+	f.insert(product)
+	f.emit(req, stripe.EventTypeProductCreated, product, nil)
+	return product, nil
+}
+
+func (f *FakeBackend) listPrices(req *fakeRequest) (fakeObject, *fakeError) {
+	return f.list(req, "price", fieldFilter(req, "active", "currency", "product", "type"))
+}
+
+func (f *FakeBackend) listProducts(req *fakeRequest) (fakeObject, *fakeError) {
+	return f.list(req, "product", fieldFilter(req, "active"))
+}
+
+func (f *FakeBackend) setPriceFields(req *fakeRequest, price fakeObject) *fakeError {
+	req.setStrings(price, "lookup_key", "nickname", "tax_behavior")
+	req.setMetadata(price)
+	if req.has("active") {
+		active, err := req.boolean("active")
+		if err != nil {
+			return err
+		}
+		price["active"] = active
+	}
+	return nil
+}
+
+func (f *FakeBackend) setProductFields(req *fakeRequest, product fakeObject) *fakeError {
+	req.setStrings(product, "description", "name", "url")
+	req.setMetadata(product)
+	if req.has("active") {
+		active, err := req.boolean("active")
+		if err != nil {
+			return err
+		}
+		product["active"] = active
+	}
+	if req.has("default_price") {
+		price, err := f.get("price", req.form.Get("default_price"), "default_price")
+		if err != nil {
+			return err
+		}
+		product["default_price"] = price["id"]
+	}
+	return nil
+}
+
+func (f *FakeBackend) updatePrice(req *fakeRequest) (fakeObject, *fakeError) {
+	price, err := f.get("price", req.ids[0], "id")
+	if err != nil {
+		return nil, err
+	}
+	before := cloneObject(price)
+	if err := f.setPriceFields(req, price); err != nil {
+		return nil, err
+	}
+	f.emitUpdated(req, stripe.EventTypePriceUpdated, before, price)
+	return price, nil
+}
+
+func (f *FakeBackend) updateProduct(req *fakeRequest) (fakeObject, *fakeError) {
+	product, err := f.get("product", req.ids[0], "id")
+	if err != nil {
+		return nil, err
+	}
+	before := cloneObject(product)
+	if err := f.setProductFields(req, product); err != nil {
+		return nil, err
+	}
+	product["updated"] = f.timestamp()
+	f.emitUpdated(req, stripe.EventTypeProductUpdated, before, product)
+	return product, nil
+}
+
+//
+// Subscriptions
+//
+
+func (f *FakeBackend) cancelSubscription(req *fakeRequest) (fakeObject, *fakeError) {
+	sub, err := f.get("subscription", req.ids[0], "id")
+	if err != nil {
+		return nil, err
+	}
+	if sub["status"] == "canceled" {
+		return nil, errInvalidRequest("resource_missing",
+			fmt.Sprintf("No such subscription: '%s'", sub["id"]))
+	}
+
+	now := f.timestamp()
+	sub["canceled_at"] = now
+	sub["ended_at"] = now
+	sub["status"] = "canceled"
+	f.emit(req, stripe.EventTypeCustomerSubscriptionDeleted, sub, nil)
+	return sub, nil
+}
+
+func (f *FakeBackend) createSubscription(req *fakeRequest) (fakeObject, *fakeError) {
+	if !req.has("customer") {
+		return nil, errMissingParam("customer")
+	}
+	customer, err := f.get("customer", req.form.Get("customer"), "customer")
+	if err != nil {
+		return nil, err
+	}
+	itemReqs := req.indexed("items")
+	if len(itemReqs) == 0 {
+		return nil, errMissingParam("items")
+	}
+
+	id := f.newID("sub")
+	sub := fakeObject{
+		"id":                     id,
+		"object":                 "subscription",
+		"cancel_at_period_end":   false,
+		"canceled_at":            nil,
+		"created":                f.customerNow(customer).Unix(),
+		"currency":               nil,
+		"customer":               customer["id"],
+		"default_payment_method": nil,
+		"description":            nil,
+		"ended_at":               nil,
+		"items": fakeObject{
+			"object":   "list",
+			"data":     []interface{}{},
+			"has_more": false,
+			"url":      "/v1/subscription_items?subscription=" + id,
+		},
+		"latest_invoice": nil,
+		"livemode":       false,
+		"metadata":       fakeObject{},
+		"start_date":     f.customerNow(customer).Unix(),
+		"status":         "active",
+		"trial_end":      nil,
+		"trial_start":    nil,
+	}
+
+	for i, itemReq := range itemReqs {
+		if err := f.addSubscriptionItem(sub, itemReq, fmt.Sprintf("items[%d]", i)); err != nil {
+			return nil, err
+		}
+	}
+	if err := f.setSubscriptionFields(req, sub); err != nil {
+		return nil, err
+	}
+
+	// The first period starts now, and either lasts for the trial or for the
+	// interval of the subscription's prices.
+	now := f.customerNow(customer)
+	interval, intervalCount, _ := subscriptionInterval(sub)
+	end := addPeriod(now, interval, intervalCount)
+	trialDays, err := req.integer("trial_period_days")
+	if err != nil {
+		return nil, err
+	}
+	if trialDays > 0 {
+		end = now.Add(time.Duration(trialDays) * 24 * time.Hour)
+		sub["status"] = "trialing"
+		sub["trial_start"] = now.Unix()
+		sub["trial_end"] = end.Unix()
+	}
+	sub["current_period_start"] = now.Unix()
+	sub["current_period_end"] = end.Unix()
+
+	f.insert(sub)
+	invoice := f.newSubscriptionInvoice(req, sub, "subscription_create", trialDays > 0)
+	sub["latest_invoice"] = invoice["id"]
+	if invoice["status"] == "open" && !f.collectInvoice(req, sub, invoice, now) {
+		sub["status"] = "incomplete"
+	}
+	f.emit(req, stripe.EventTypeCustomerSubscriptionCreated, sub, nil)
+	return sub, nil
+}
+
+// addSubscriptionItem adds an item for the price given by the parameters to
+// the subscription.
+func (f *FakeBackend) addSubscriptionItem(sub fakeObject, req *fakeRequest, param string) *fakeError {
+	if !req.has("price") {
+		return errMissingParam(param + "[price]")
+	}
+	price, err := f.recurringPrice(req, param)
+	if err != nil {
+		return err
+	}
+
+	quantity := int64(1)
+	if req.has("quantity") {
+		if quantity, err = req.integer("quantity"); err != nil {
+			return err
+		}
+	}
+
+	item := fakeObject{
+		"id":           f.newID("si"),
+		"object":       "subscription_item",
+		"created":      f.timestamp(),
+		"metadata":     fakeObject{},
+		"price":        cloneObject(price),
+		"quantity":     quantity,
+		"subscription": sub["id"],
+	}
+	req.setMetadata(item)
+
+	items := sub["items"].(fakeObject)
+	items["data"] = append(items["data"].([]interface{}), item)
+	if sub["currency"] == nil {
+		sub["currency"] = price["currency"]
+	}
+	return nil
+}
+
+// recurringPrice returns the price given by the parameters of a subscription
+// item, which must be recurring.
+func (f *FakeBackend) recurringPrice(req *fakeRequest, param string) (fakeObject, *fakeError) {
+	price, err := f.get("price", req.form.Get("price"), param+"[price]")
+	if err != nil {
+		return nil, err
+	}
+	if price["type"] != "recurring" {
+		return nil, errInvalidParam(param+"[price]",
+			"The price specified is set to `type=one_time` but this field only accepts prices with `type=recurring`.")
+	}
+	return price, nil
+}
+
+func (f *FakeBackend) listSubscriptions(req *fakeRequest) (fakeObject, *fakeError) {
+	customerFilter := fieldFilter(req, "customer")
+	status := req.form.Get("status")
+	price := req.form.Get("price")
+	return f.list(req, "subscription", func(sub fakeObject) bool {
+		if !customerFilter(sub) {
+			return false
+		}
+		switch status {
+		case "":
+			if sub["status"] == "canceled" {
+				return false
+			}
+		case "all":
+		default:
+			if sub["status"] != status {
+				return false
+			}
+		}
+		if price != "" {
+			found := false
+			for _, item := range sub["items"].(fakeObject)["data"].([]interface{}) {
+				if item.(fakeObject)["price"].(fakeObject)["id"] == price {
+					found = true
+				}
+			}
+			return found
+		}
+		return true
+	})
+}
+
+// newSubscriptionInvoice creates the finalized invoice for the current period
+// of a subscription.
+func (f *FakeBackend) newSubscriptionInvoice(req *fakeRequest, sub fakeObject, billingReason string, trial bool) fakeObject {
+	invoice := f.newInvoice(sub["customer"].(string))
+	invoice["billing_reason"] = billingReason
+	invoice["created"] = sub["current_period_start"]
+	invoice["currency"] = sub["currency"]
+	invoice["period_end"] = sub["current_period_start"]
+	invoice["period_start"] = sub["current_period_start"]
+	invoice["subscription"] = sub["id"]
+
+	var total int64
+	lines := invoice["lines"].(fakeObject)
+	for _, item := range sub["items"].(fakeObject)["data"].([]interface{}) {
+		item := item.(fakeObject)
+		price := item["price"].(fakeObject)
+		quantity := item["quantity"].(int64)
+		amount := price["unit_amount"].(int64) * quantity
+		if trial {
+			amount = 0
+		}
+		total += amount
+
+		lines["data"] = append(lines["data"].([]interface{}), fakeObject{
+			"id":                f.newID("il"),
+			"object":            "line_item",
+			"amount":            amount,
+			"currency":          price["currency"],
+			"description":       nil,
+			"invoice":           invoice["id"],
+			"livemode":          false,
+			"metadata":          fakeObject{},
+			"period":            fakeObject{"start": sub["current_period_start"], "end": sub["current_period_end"]},
+			"price":             cloneObject(price),
+			"proration":         false,
+			"quantity":          quantity,
+			"subscription":      sub["id"],
+			"subscription_item": item["id"],
+			"type":              "subscription",
+		})
+	}
+	setInvoiceTotal(invoice, total)
+	f.insert(invoice)
+	f.emit(req, stripe.EventTypeInvoiceCreated, invoice, nil)
+	f.finalize(req, invoice)
+	return invoice
+}
+
+func (f *FakeBackend) setSubscriptionFields(req *fakeRequest, sub fakeObject) *fakeError {
+	req.setStrings(sub, "description")
+	req.setMetadata(sub)
+	if req.has("cancel_at_period_end") {
+		cancel, err := req.boolean("cancel_at_period_end")
+		if err != nil {
+			return err
+		}
+		sub["cancel_at_period_end"] = cancel
+	}
+	if req.has("default_payment_method") {
+		if id := req.form.Get("default_payment_method"); id != "" {
+			pm, err := f.get("payment_method", id, "default_payment_method")
+			if err != nil {
+				return err
+			}
+			sub["default_payment_method"] = pm["id"]
+		} else {
+			sub["default_payment_method"] = nil
+		}
+	}
+	return nil
+}
+
+func (f *FakeBackend) updateSubscription(req *fakeRequest) (fakeObject, *fakeError) {
+	sub, err := f.get("subscription", req.ids[0], "id")
+	if err != nil {
+		return nil, err
+	}
+	if sub["status"] == "canceled" {
+		return nil, errInvalidRequest("",
+			"A canceled subscription can only update its cancellation_details and metadata.")
+	}
+
+	// The update is made on a copy so that the subscription is left as it
+	// was when a parameter is invalid.
+	updated := cloneObject(sub)
+	items := updated["items"].(fakeObject)
+	for i, itemReq := range req.indexed("items") {
+		param := fmt.Sprintf("items[%d]", i)
+		if !itemReq.has("id") {
+			if err := f.addSubscriptionItem(updated, itemReq, param); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		data := items["data"].([]interface{})
+		index := -1
+		for j, item := range data {
+			if item.(fakeObject)["id"] == itemReq.form.Get("id") {
+				index = j
+			}
+		}
+		if index < 0 {
+			return nil, errResourceMissing("subscription_item", itemReq.form.Get("id"), param+"[id]")
+		}
+		item := data[index].(fakeObject)
+
+		deleted, err := itemReq.boolean("deleted")
+		if err != nil {
+			return nil, err
+		}
+		if deleted {
+			items["data"] = append(data[:index:index], data[index+1:]...)
+			continue
+		}
+		if itemReq.has("quantity") {
+			if item["quantity"], err = itemReq.integer("quantity"); err != nil {
+				return nil, err
+			}
+		}
+		if itemReq.has("price") {
+			price, err := f.recurringPrice(itemReq, param)
+			if err != nil {
+				return nil, err
+			}
+			item["price"] = cloneObject(price)
+		}
+		itemReq.setMetadata(item)
+	}
+	if len(items["data"].([]interface{})) == 0 {
+		return nil, errInvalidParam("items", "A subscription must have at least one active plan.")
+	}
+
+	if err := f.setSubscriptionFields(req, updated); err != nil {
+		return nil, err
+	}
+	before := cloneObject(sub)
+	for k, v := range updated {
+		sub[k] = v
+	}
+	f.emitUpdated(req, stripe.EventTypeCustomerSubscriptionUpdated, before, sub)
+	return sub, nil
+}
+
+//
+// Invoices
+//
+
+func (f *FakeBackend) checkInvoiceDeletable(invoice fakeObject) *fakeError {
+	if invoice["status"] != "draft" {
+		return errInvalidRequest("invoice_not_editable", "You can only delete draft invoices.")
+	}
+	return nil
+}
+
+func (f *FakeBackend) createInvoice(req *fakeRequest) (fakeObject, *fakeError) {
+	if !req.has("customer") {
+		return nil, errMissingParam("customer")
+	}
+	customer, err := f.get("customer", req.form.Get("customer"), "customer")
+	if err != nil {
+		return nil, err
+	}
+
+	invoice := f.newInvoice(customer["id"].(string))
+	if req.has("currency") {
+		invoice["currency"] = strings.ToLower(req.form.Get("currency"))
+	}
+	if err := f.setInvoiceFields(req, invoice); err != nil {
+		return nil, err
+	}
+	f.insert(invoice)
+	f.emit(req, stripe.EventTypeInvoiceCreated, invoice, nil)
+	return invoice, nil
+}
+
+// finalize moves a draft invoice to open, or straight to paid if there's
+// nothing to pay.
+func (f *FakeBackend) finalize(req *fakeRequest, invoice fakeObject) {
+	f.nextID["invoice_number"]++
+	invoice["number"] = fmt.Sprintf("FAKE-%04d", f.nextID["invoice_number"])
+	invoice["status"] = "open"
+	invoice["status_transitions"].(fakeObject)["finalized_at"] = f.timestamp()
+	f.emit(req, stripe.EventTypeInvoiceFinalized, invoice, nil)
+
+	if invoice["amount_due"].(int64) == 0 {
+		f.pay(req, invoice)
+	}
+}
+
+func (f *FakeBackend) finalizeInvoice(req *fakeRequest) (fakeObject, *fakeError) {
+	invoice, err := f.get("invoice", req.ids[0], "id")
+	if err != nil {
+		return nil, err
+	}
+	if invoice["status"] != "draft" {
+		return nil, errInvalidRequest("invoice_unexpected_state", "This invoice is already finalized.")
+	}
+	f.finalize(req, invoice)
+	return invoice, nil
+}
+
+// invoiceTransition returns a handler moving open invoices to the given
+// status.
+func invoiceTransition(status string, eventType stripe.EventType) func(f *FakeBackend, req *fakeRequest) (fakeObject, *fakeError) {
+	return func(f *FakeBackend, req *fakeRequest) (fakeObject, *fakeError) {
+		invoice, err := f.get("invoice", req.ids[0], "id")
+		if err != nil {
+			return nil, err
+		}
+		if invoice["status"] != "open" {
+			return nil, errInvalidRequest("invoice_unexpected_state", fmt.Sprintf(
+				"This invoice has a status of %s, but must be open.", invoice["status"]))
+		}
+		invoice["status"] = status
+		transition := "voided_at"
+		if status == "uncollectible" {
+			transition = "marked_uncollectible_at"
+		}
+		invoice["status_transitions"].(fakeObject)[transition] = f.timestamp()
+		f.emit(req, eventType, invoice, nil)
+		return invoice, nil
+	}
+}
+
+func (f *FakeBackend) listEvents(req *fakeRequest) (fakeObject, *fakeError) {
+	var types []string
+	if req.has("type") {
+		types = append(types, req.form.Get("type"))
+	}
+	// Elements of `types[]` have no key of their own.
+	for _, typeReq := range req.indexed("types") {
+		types = append(types, typeReq.form.Get(""))
+	}
+	return f.list(req, "event", func(event fakeObject) bool {
+		if len(types) == 0 {
+			return true
+		}
+		for _, t := range types {
+			if matchEventType(t, event["type"].(string)) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func (f *FakeBackend) listInvoices(req *fakeRequest) (fakeObject, *fakeError) {
+	return f.list(req, "invoice", fieldFilter(req, "customer", "status", "subscription"))
+}
+
+// matchEventType matches an event type against a filter, which can end with a
+// `*` wildcard like `customer.*`.
+func matchEventType(filter, eventType string) bool {
+	if strings.HasSuffix(filter, "*") {
+		return strings.HasPrefix(eventType, strings.TrimSuffix(filter, "*"))
+	}
+	return filter == eventType
+}
+
+func (f *FakeBackend) newInvoice(customer string) fakeObject {
+	now := f.timestamp()
+	invoice := fakeObject{
+		"id":                   f.newID("in"),
+		"object":               "invoice",
+		"amount_due":           int64(0),
+		"amount_paid":          int64(0),
+		"amount_remaining":     int64(0),
+		"attempt_count":        int64(0),
+		"attempted":            false,
+		"auto_advance":         false,
+		"billing_reason":       "manual",
+		"collection_method":    "charge_automatically",
+		"created":              now,
+		"currency":             "usd",
+		"customer":             customer,
+		"description":          nil,
+		"livemode":             false,
+		"next_payment_attempt": nil,
+		"metadata":             fakeObject{},
+		"number":               nil,
+		"paid":                 false,
+		"period_end":           now,
+		"period_start":         now,
+		"status":               "draft",
+		"status_transitions": fakeObject{
+			"finalized_at":            nil,
+			"marked_uncollectible_at": nil,
+			"paid_at":                 nil,
+			"voided_at":               nil,
+		},
+		"subscription": nil,
+		"subtotal":     int64(0),
+		"total":        int64(0),
+	}
+	invoice["lines"] = fakeObject{
+		"object":   "list",
+		"data":     []interface{}{},
+		"has_more": false,
+		"url":      "/v1/invoices/" + invoice["id"].(string) + "/lines",
+	}
+	return invoice
+}
+
+// pay marks an open invoice as paid.
+func (f *FakeBackend) pay(req *fakeRequest, invoice fakeObject) {
+	invoice["amount_paid"] = invoice["amount_due"]
+	invoice["amount_remaining"] = int64(0)
+	invoice["next_payment_attempt"] = nil
+	invoice["paid"] = true
+	invoice["status"] = "paid"
+	invoice["status_transitions"].(fakeObject)["paid_at"] = f.timestamp()
+	f.emit(req, stripe.EventTypeInvoicePaid, invoice, nil)
+	f.emit(req, stripe.EventTypeInvoicePaymentSucceeded, invoice, nil)
+}
+
+// collectInvoice attempts to pay an open invoice of a subscription with its
+// default payment method, or else the customer's. When the payment fails,
+// another attempt is scheduled until the retries are exhausted, like with the
+// default retry settings of the API. It returns whether the invoice was paid.
+func (f *FakeBackend) collectInvoice(req *fakeRequest, sub fakeObject, invoice fakeObject, at time.Time) bool {
+	pmID, _ := sub["default_payment_method"].(string)
+	if pmID == "" {
+		if customer, ok := f.objects[sub["customer"].(string)]; ok {
+			pmID, _ = customer["invoice_settings"].(fakeObject)["default_payment_method"].(string)
+		}
+	}
+
+	attempts := invoice["attempt_count"].(int64) + 1
+	invoice["attempt_count"] = attempts
+	invoice["attempted"] = true
+	if _, declined := f.declines[pmID]; declined {
+		if attempts < fakeInvoiceMaxAttempts {
+			invoice["next_payment_attempt"] = at.Add(fakeInvoiceRetryDelay).Unix()
+		} else {
+			invoice["next_payment_attempt"] = nil
+		}
+		f.emit(req, stripe.EventTypeInvoicePaymentFailed, invoice, nil)
+		return false
+	}
+
+	f.pay(req, invoice)
+	return true
+}
+
+func (f *FakeBackend) payInvoice(req *fakeRequest) (fakeObject, *fakeError) {
+	invoice, err := f.get("invoice", req.ids[0], "id")
+	if err != nil {
+		return nil, err
+	}
+	if invoice["status"] != "open" {
+		return nil, errInvalidRequest("invoice_unexpected_state", fmt.Sprintf(
+			"This invoice has a status of %s, but must be open to be paid.", invoice["status"]))
+	}
+	f.pay(req, invoice)
+	return invoice, nil
+}
+
+func setInvoiceTotal(invoice fakeObject, total int64) {
+	invoice["amount_due"] = total
+	invoice["amount_remaining"] = total
+	invoice["subtotal"] = total
+	invoice["total"] = total
+}
+
+func (f *FakeBackend) setInvoiceFields(req *fakeRequest, invoice fakeObject) *fakeError {
+	req.setMetadata(invoice)
+	if invoice["status"] != "draft" {
+		return nil
+	}
+	req.setStrings(invoice, "collection_method", "description")
+	if req.has("auto_advance") {
+		autoAdvance, err := req.boolean("auto_advance")
+		if err != nil {
+			return err
+		}
+		invoice["auto_advance"] = autoAdvance
+	}
+	return nil
+}
+
+func (f *FakeBackend) updateInvoice(req *fakeRequest) (fakeObject, *fakeError) {
+	invoice, err := f.get("invoice", req.ids[0], "id")
+	if err != nil {
+		return nil, err
+	}
+	before := cloneObject(invoice)
+	if err := f.setInvoiceFields(req, invoice); err != nil {
+		return nil, err
+	}
+	f.emitUpdated(req, stripe.EventTypeInvoiceUpdated, before, invoice)
+	return invoice, nil
+}
+
+//
+// Test clocks
+//
+
+func (f *FakeBackend) advanceTestClock(req *fakeRequest) (fakeObject, *fakeError) {
+	clock, err := f.get("test_helpers.test_clock", req.ids[0], "id")
+	if err != nil {
+		return nil, err
+	}
+	if !req.has("frozen_time") {
+		return nil, errMissingParam("frozen_time")
+	}
+	frozenTime, err := req.integer("frozen_time")
+	if err != nil {
+		return nil, err
+	}
+	if frozenTime <= clock["frozen_time"].(int64) {
+		return nil, errInvalidParam("frozen_time",
+			"The frozen time must be after the current frozen time of the test clock.")
+	}
+
+	clock["status"] = "advancing"
+	f.emit(req, stripe.EventTypeTestHelpersTestClockAdvancing, clock, nil)
+
+	// Subscriptions are advanced in the order they were created, and each of
+	// them catches up with the new time before the next one.
+	from := time.Unix(clock["frozen_time"].(int64), 0)
+	until := time.Unix(frozenTime, 0)
+	for _, id := range append([]string(nil), f.order["subscription"]...) {
+		sub := f.objects[id]
+		customer, ok := f.objects[sub["customer"].(string)]
+		if !ok || customer["test_clock"] != clock["id"] {
+			continue
+		}
+		f.advanceSubscription(req, sub, from, until)
+	}
+
+	// The API advances clocks asynchronously, so the response still shows the
+	// clock as advancing, even though the fake is already done with it.
+	clock["frozen_time"] = frozenTime
+	resp := cloneObject(clock)
+	clock["status"] = "ready"
+	f.emit(req, stripe.EventTypeTestHelpersTestClockReady, clock, nil)
+	return resp, nil
+}
+
+// advanceSubscription moves a subscription of a customer on a test clock
+// through the renewals, trial end, payment retries and cancellation happening
+// between from and until.
+func (f *FakeBackend) advanceSubscription(req *fakeRequest, sub fakeObject, from, until time.Time) {
+	if trialEnd, ok := sub["trial_end"].(int64); ok && sub["status"] == "trialing" {
+		notice := time.Unix(trialEnd, 0).Add(-fakeTrialWillEndNotice)
+		if notice.After(from) && !notice.After(until) {
+			f.emit(req, stripe.EventTypeCustomerSubscriptionTrialWillEnd, sub, nil)
+		}
+	}
+
+	for sub["status"] != "canceled" && sub["status"] != "incomplete" {
+		periodEnd := sub["current_period_end"].(int64)
+
+		// Failed payments are retried before the next renewal.
+		if sub["status"] == "past_due" {
+			invoice := f.objects[sub["latest_invoice"].(string)]
+			next, ok := invoice["next_payment_attempt"].(int64)
+			if ok && invoice["status"] == "open" && next <= until.Unix() && next <= periodEnd {
+				before := cloneObject(sub)
+				if f.collectInvoice(req, sub, invoice, time.Unix(next, 0)) {
+					sub["status"] = "active"
+					f.emitUpdated(req, stripe.EventTypeCustomerSubscriptionUpdated, before, sub)
+				} else if invoice["next_payment_attempt"] == nil {
+					sub["canceled_at"] = next
+					sub["ended_at"] = next
+					sub["status"] = "canceled"
+					f.emit(req, stripe.EventTypeCustomerSubscriptionDeleted, sub, nil)
+				}
+				continue
+			}
+		}
+
+		if periodEnd > until.Unix() {
+			return
+		}
+		if sub["cancel_at_period_end"] == true {
+			sub["canceled_at"] = periodEnd
+			sub["ended_at"] = periodEnd
+			sub["status"] = "canceled"
+			f.emit(req, stripe.EventTypeCustomerSubscriptionDeleted, sub, nil)
+			return
+		}
+
+		// Subscriptions without a recurring price to renew with are left as
+		// they are.
+		interval, intervalCount, ok := subscriptionInterval(sub)
+		if !ok {
+			return
+		}
+
+		before := cloneObject(sub)
+		start := time.Unix(periodEnd, 0)
+		sub["current_period_start"] = periodEnd
+		sub["current_period_end"] = addPeriod(start, interval, intervalCount).Unix()
+		if sub["status"] == "trialing" {
+			sub["status"] = "active"
+		}
+
+		invoice := f.newSubscriptionInvoice(req, sub, "subscription_cycle", false)
+		sub["latest_invoice"] = invoice["id"]
+		if invoice["status"] == "open" && !f.collectInvoice(req, sub, invoice, start) {
+			sub["status"] = "past_due"
+		}
+		f.emitUpdated(req, stripe.EventTypeCustomerSubscriptionUpdated, before, sub)
+	}
+}
+
+func (f *FakeBackend) createTestClock(req *fakeRequest) (fakeObject, *fakeError) {
+	if !req.has("frozen_time") {
+		return nil, errMissingParam("frozen_time")
+	}
+	frozenTime, err := req.integer("frozen_time")
+	if err != nil {
+		return nil, err
+	}
+
+	clock := fakeObject{
+		"id":             f.newID("clock"),
+		"object":         "test_helpers.test_clock",
+		"created":        f.timestamp(),
+		"deletes_after":  f.now().AddDate(0, 0, 30).Unix(),
+		"frozen_time":    frozenTime,
+		"livemode":       false,
+		"name":           nil,
+		"status":         "ready",
+		"status_details": fakeObject{},
+	}
+	req.setStrings(clock, "name")
+	f.insert(clock)
+	f.emit(req, stripe.EventTypeTestHelpersTestClockCreated, clock, nil)
+	return clock, nil
+}
+
+// deleteTestClock deletes a test clock along with its customers and their
+// subscriptions and invoices, like on the API.
+func (f *FakeBackend) deleteTestClock(req *fakeRequest) (fakeObject, *fakeError) {
+	clock, err := f.get("test_helpers.test_clock", req.ids[0], "id")
+	if err != nil {
+		return nil, err
+	}
+
+	customers := make(map[interface{}]bool)
+	var removed []fakeObject
+	for _, id := range f.order["customer"] {
+		if customer := f.objects[id]; customer["test_clock"] == clock["id"] {
+			customers[id] = true
+			removed = append(removed, customer)
+		}
+	}
+	for _, objectType := range []string{"subscription", "invoice"} {
+		for _, id := range f.order[objectType] {
+			if obj := f.objects[id]; customers[obj["customer"]] {
+				removed = append(removed, obj)
+			}
+		}
+	}
+	for _, obj := range removed {
+		f.remove(obj)
+	}
+
+	f.remove(clock)
+	f.emit(req, stripe.EventTypeTestHelpersTestClockDeleted, clock, nil)
+	return fakeObject{"id": clock["id"], "object": "test_helpers.test_clock", "deleted": true}, nil
+}
+
+func (f *FakeBackend) listTestClocks(req *fakeRequest) (fakeObject, *fakeError) {
+	return f.list(req, "test_helpers.test_clock", nil)
+}
@@ -0,0 +1,384 @@
+package stripetest
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	stripe "github.com/stripe/stripe-go/v81"
+)
+
+//
+// Public types
+//
+
+// Fault describes a failure injected by a FaultInjector into the requests it
+// matches.
+//
+// A fault can combine latency with one of: an error response, a dropped
+// connection, or a truncated response body. Faults with only latency let the
+// request through once the latency has elapsed.
+type Fault struct {
+	// DropConnection fails the request as if the connection was reset
+	// before any response was received.
+	DropConnection bool
+
+	// Error is the error that the API responds with, like a card error or a
+	// lock timeout. Its HTTPStatusCode is used as the status of the response,
+	// and defaults to the usual status for its type.
+	Error *stripe.Error
+
+	// Header is added to the headers of the response, like
+	// `Stripe-Should-Retry`.
+	Header http.Header
+
+	// Latency is added before the request is made or failed.
+	Latency time.Duration
+
+	// Method is the HTTP method of the requests the fault applies to.
+	//
+	// Defaults to any method.
+	Method string
+
+	// Path is a regular expression matched against the path of requests, like
+	// `^/v1/payment_intents/[^/]+/confirm$`.
+	//
+	// Defaults to any path.
+	Path string
+
+	// Probability is the probability that the fault is injected into a
+	// matching request, between 0 and 1.
+	//
+	// Defaults to 1, so that every matching request fails.
+	Probability float64
+
+	// Times is the maximum number of times the fault is injected, after which
+	// requests go through. It can be used to check that a request succeeds
+	// once retried.
+	//
+	// Defaults to no limit.
+	Times int
+
+	// TruncateBodyAt lets the request through but cuts the body of the
+	// response after the given number of bytes, as if the connection was
+	// lost while reading it.
+	TruncateBodyAt int
+}
+
+// FaultInjector is an http.RoundTripper which injects faults into requests,
+// so that the handling of failures, including automatic retries, can be
+// tested without a server misbehaving on purpose.
+type FaultInjector struct {
+	faults    []*injectedFault
+	mu        sync.Mutex
+	rand      *rand.Rand
+	transport http.RoundTripper
+}
+
+// FaultInjectorConfig is used to configure a FaultInjector.
+type FaultInjectorConfig struct {
+	// Faults are the faults to inject. For each request, the first fault
+	// matching it and drawn according to its probability is injected. They're
+	// copied by NewFaultInjector, so that they can be reused by several
+	// injectors and aren't changed by injecting them.
+	Faults []*Fault
+
+	// Rand is the source of randomness used to draw faults. Setting it with a
+	// fixed seed makes tests using probabilities deterministic.
+	//
+	// Defaults to a source seeded with the current time.
+	Rand *rand.Rand
+
+	// Transport is used to make the requests that faults let through.
+	//
+	// Defaults to http.DefaultTransport.
+	Transport http.RoundTripper
+}
+
+//
+// Public functions
+//
+
+// CardDeclinedFault returns a fault declining a card payment with the given
+// decline code, like stripe.DeclineCodeInsufficientFunds.
+func CardDeclinedFault(declineCode stripe.DeclineCode) *Fault {
+	return &Fault{Error: &stripe.Error{
+		Code:        stripe.ErrorCodeCardDeclined,
+		DeclineCode: declineCode,
+		Msg:         "Your card was declined.",
+		Type:        stripe.ErrorTypeCard,
+	}}
+}
+
+// ConnectionResetFault returns a fault dropping the connection.
+func ConnectionResetFault() *Fault {
+	return &Fault{DropConnection: true}
+}
+
+// LockTimeoutFault returns a fault responding like the API does when an
+// object is being modified concurrently by another request.
+func LockTimeoutFault() *Fault {
+	return &Fault{Error: &stripe.Error{
+		Code:           stripe.ErrorCodeLockTimeout,
+		HTTPStatusCode: http.StatusTooManyRequests,
+		Msg:            "This object cannot be accessed right now because another API request or Stripe process is currently accessing it.",
+		Type:           stripe.ErrorTypeInvalidRequest,
+	}}
+}
+
+// RateLimitFault returns a fault responding like the API does when too many
+// requests are made.
+func RateLimitFault() *Fault {
+	return &Fault{Error: &stripe.Error{
+		Code:           stripe.ErrorCodeRateLimit,
+		HTTPStatusCode: http.StatusTooManyRequests,
+		Msg:            "Request rate limit exceeded.",
+		Type:           stripe.ErrorTypeInvalidRequest,
+	}}
+}
+
+// ServerErrorFault returns a fault responding with an internal server error,
+// along with a `Stripe-Should-Retry` header telling whether the request can
+// be retried.
+func ServerErrorFault(shouldRetry bool) *Fault {
+	return &Fault{
+		Error: &stripe.Error{
+			HTTPStatusCode: http.StatusInternalServerError,
+			Msg:            "An unknown error occurred.",
+			Type:           stripe.ErrorTypeAPI,
+		},
+		Header: http.Header{"Stripe-Should-Retry": {fmt.Sprint(shouldRetry)}},
+	}
+}
+
+// NewFaultInjector returns a FaultInjector. It returns an error if the path
+// pattern of a fault is invalid.
+func NewFaultInjector(config *FaultInjectorConfig) (*FaultInjector, error) {
+	i := &FaultInjector{}
+	if config != nil {
+		i.rand = config.Rand
+		i.transport = config.Transport
+
+		for _, fault := range config.Faults {
+			injected := &injectedFault{Fault: *fault, config: fault}
+			injected.Header = fault.Header.Clone()
+			if fault.Path != "" {
+				pattern, err := regexp.Compile(fault.Path)
+				if err != nil {
+					return nil, fmt.Errorf("stripetest: invalid fault path %q: %v", fault.Path, err)
+				}
+				injected.pattern = pattern
+			}
+			i.faults = append(i.faults, injected)
+		}
+	}
+	if i.rand == nil {
+		i.rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	if i.transport == nil {
+		i.transport = http.DefaultTransport
+	}
+
+	return i, nil
+}
+
+// Backend returns a backend making its requests through the fault injector.
+// The configuration is used as is, apart from its HTTP client, so that
+// MaxNetworkRetries can be set to exercise automatic retries.
+func (i *FaultInjector) Backend(config *stripe.BackendConfig) stripe.Backend {
+	var cfg stripe.BackendConfig
+	if config != nil {
+		cfg = *config
+	}
+	cfg.HTTPClient = &http.Client{Transport: i}
+	if cfg.LeveledLogger == nil {
+		cfg.LeveledLogger = &stripe.LeveledLogger{Level: stripe.LevelNull}
+	}
+	return stripe.GetBackendWithConfig(stripe.APIBackend, &cfg)
+}
+
+// Count returns the number of times the fault was injected, given the
+// fault it was configured with.
+func (i *FaultInjector) Count(fault *Fault) int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	count := 0
+	for _, injected := range i.faults {
+		if injected.config == fault {
+			count += injected.count
+		}
+	}
+	return count
+}
+
+// RoundTrip is the http.RoundTripper implementation.
+func (i *FaultInjector) RoundTrip(req *http.Request) (*http.Response, error) {
+	fault := i.draw(req)
+	if fault == nil {
+		return i.transport.RoundTrip(req)
+	}
+
+	if fault.Latency > 0 {
+		timer := time.NewTimer(fault.Latency)
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		}
+	}
+
+	switch {
+	case fault.DropConnection:
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}
+
+	case fault.Error != nil:
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return fault.errorResponse(req), nil
+
+	case fault.TruncateBodyAt > 0:
+		resp, err := i.transport.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range fault.Header {
+			resp.Header[k] = v
+		}
+		resp.Body = &truncatedBody{body: resp.Body, remaining: fault.TruncateBodyAt}
+		return resp, nil
+
+	default:
+		resp, err := i.transport.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range fault.Header {
+			resp.Header[k] = v
+		}
+		return resp, nil
+	}
+}
+
+//
+// Private types
+//
+
+// injectedFault is the copy of a configured fault made by a FaultInjector,
+// along with the state of its injection.
+type injectedFault struct {
+	Fault
+
+	// config is the fault the copy was made from, to find the copy in Count.
+	config *Fault
+
+	count   int
+	pattern *regexp.Regexp
+}
+
+// truncatedBody is a response body which fails with an unexpected EOF after a
+// number of bytes.
+type truncatedBody struct {
+	body      io.ReadCloser
+	remaining int
+}
+
+func (b *truncatedBody) Close() error {
+	return b.body.Close()
+}
+
+func (b *truncatedBody) Read(p []byte) (int, error) {
+	if b.remaining <= 0 {
+		return 0, io.ErrUnexpectedEOF
+	}
+	if len(p) > b.remaining {
+		p = p[:b.remaining]
+	}
+	n, err := b.body.Read(p)
+	b.remaining -= n
+	return n, err
+}
+
+//
+// Private functions
+//
+
+// draw returns the fault to inject into the request, if any.
+func (i *FaultInjector) draw(req *http.Request) *Fault {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	for _, fault := range i.faults {
+		if fault.Method != "" && !strings.EqualFold(fault.Method, req.Method) {
+			continue
+		}
+		if fault.pattern != nil && !fault.pattern.MatchString(req.URL.Path) {
+			continue
+		}
+		if fault.Times > 0 && fault.count >= fault.Times {
+			continue
+		}
+		if fault.Probability > 0 && i.rand.Float64() >= fault.Probability {
+			continue
+		}
+		fault.count++
+		return &fault.Fault
+	}
+	return nil
+}
+
+// errorResponse renders the error of the fault like the API would.
+func (f *Fault) errorResponse(req *http.Request) *http.Response {
+	e := &fakeError{
+		Code:        string(f.Error.Code),
+		DeclineCode: string(f.Error.DeclineCode),
+		Message:     f.Error.Msg,
+		Param:       f.Error.Param,
+		Type:        string(f.Error.Type),
+		status:      f.Error.HTTPStatusCode,
+	}
+	if e.status == 0 {
+		switch f.Error.Type {
+		case stripe.ErrorTypeCard:
+			e.status = http.StatusPaymentRequired
+		case stripe.ErrorTypeAPI:
+			e.status = http.StatusInternalServerError
+		case stripe.ErrorTypeIdempotency:
+			e.status = http.StatusConflict
+		default:
+			e.status = http.StatusBadRequest
+		}
+	}
+	body := e.response().body
+
+	header := http.Header{"Content-Type": {"application/json"}}
+	for k, v := range f.Header {
+		header[k] = v
+	}
+
+	return &http.Response{
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Header:        header,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Request:       req,
+		Status:        fmt.Sprintf("%d %s", e.status, http.StatusText(e.status)),
+		StatusCode:    e.status,
+	}
+}
@@ -0,0 +1,176 @@
+package stripetest
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+	stripe "github.com/stripe/stripe-go/v81"
+	"github.com/stripe/stripe-go/v81/customer"
+	"github.com/stripe/stripe-go/v81/paymentintent"
+)
+
+func TestFaultInjectorErrors(t *testing.T) {
+	ts, requests := newFaultTestServer()
+	defer ts.Close()
+
+	declined := CardDeclinedFault(stripe.DeclineCodeInsufficientFunds)
+	declined.Path = `^/v1/payment_intents/[^/]+/confirm$`
+
+	injector, err := NewFaultInjector(&FaultInjectorConfig{
+		Faults: []*Fault{declined},
+	})
+	assert.NoError(t, err)
+	c := paymentintent.Client{B: injector.Backend(&stripe.BackendConfig{URL: stripe.String(ts.URL)}), Key: "sk_test_123"}
+
+	_, err = c.Get("pi_123", nil)
+	assert.NoError(t, err)
+
+	_, err = c.Confirm("pi_123", nil)
+	stripeErr, ok := err.(*stripe.Error)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusPaymentRequired, stripeErr.HTTPStatusCode)
+	assert.Equal(t, stripe.ErrorTypeCard, stripeErr.Type)
+	assert.Equal(t, stripe.DeclineCodeInsufficientFunds, stripeErr.DeclineCode)
+	_, ok = stripeErr.Err.(*stripe.CardError)
+	assert.True(t, ok)
+
+	assert.Equal(t, 1, injector.Count(declined))
+	assert.Equal(t, int32(1), atomic.LoadInt32(requests))
+}
+
+func TestFaultInjectorRetries(t *testing.T) {
+	testCases := []struct {
+		name  string
+		fault *Fault
+	}{
+		{"LockTimeout", LockTimeoutFault()},
+		{"ServerError", ServerErrorFault(true)},
+		{"ConnectionReset", ConnectionResetFault()},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ts, requests := newFaultTestServer()
+			defer ts.Close()
+
+			tc.fault.Times = 1
+			injector, err := NewFaultInjector(&FaultInjectorConfig{Faults: []*Fault{tc.fault}})
+			assert.NoError(t, err)
+			c := customer.Client{B: injector.Backend(&stripe.BackendConfig{
+				MaxNetworkRetries: stripe.Int64(1),
+				URL:               stripe.String(ts.URL),
+			}), Key: "sk_test_123"}
+
+			cus, err := c.Get("cus_123", nil)
+			assert.NoError(t, err)
+			assert.Equal(t, "cus_123", cus.ID)
+			assert.Equal(t, 1, injector.Count(tc.fault))
+			assert.Equal(t, int32(1), atomic.LoadInt32(requests))
+		})
+	}
+}
+
+func TestFaultInjectorNoRetry(t *testing.T) {
+	ts, requests := newFaultTestServer()
+	defer ts.Close()
+
+	fault := ServerErrorFault(false)
+	injector, err := NewFaultInjector(&FaultInjectorConfig{Faults: []*Fault{fault}})
+	assert.NoError(t, err)
+	c := customer.Client{B: injector.Backend(&stripe.BackendConfig{
+		MaxNetworkRetries: stripe.Int64(2),
+		URL:               stripe.String(ts.URL),
+	}), Key: "sk_test_123"}
+
+	_, err = c.Get("cus_123", nil)
+	assert.Error(t, err)
+	assert.Equal(t, 1, injector.Count(fault))
+	assert.Equal(t, int32(0), atomic.LoadInt32(requests))
+}
+
+func TestFaultInjectorTruncatedBody(t *testing.T) {
+	ts, _ := newFaultTestServer()
+	defer ts.Close()
+
+	injector, err := NewFaultInjector(&FaultInjectorConfig{
+		Faults: []*Fault{{TruncateBodyAt: 10}},
+	})
+	assert.NoError(t, err)
+	c := customer.Client{B: injector.Backend(&stripe.BackendConfig{URL: stripe.String(ts.URL)}), Key: "sk_test_123"}
+
+	_, err = c.Get("cus_123", nil)
+	assert.Error(t, err)
+}
+
+func TestFaultInjectorLatencyAndProbability(t *testing.T) {
+	ts, requests := newFaultTestServer()
+	defer ts.Close()
+
+	slow := &Fault{Latency: time.Second, Method: http.MethodGet, Probability: 0.5}
+	injector, err := NewFaultInjector(&FaultInjectorConfig{
+		Faults: []*Fault{slow},
+		Rand:   rand.New(rand.NewSource(1)),
+	})
+	assert.NoError(t, err)
+	c := customer.Client{B: injector.Backend(&stripe.BackendConfig{URL: stripe.String(ts.URL)}), Key: "sk_test_123"}
+
+	for i := 0; i < 20; i++ {
+		params := &stripe.CustomerParams{}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		params.Context = ctx
+		c.Get("cus_123", params)
+		cancel()
+	}
+
+	count := injector.Count(slow)
+	assert.True(t, count > 0 && count < 20)
+	assert.Equal(t, int32(20-count), atomic.LoadInt32(requests))
+}
+
+func TestFaultInjectorFaultsCopied(t *testing.T) {
+	ts, requests := newFaultTestServer()
+	defer ts.Close()
+
+	fault := ConnectionResetFault()
+	fault.Times = 1
+	config := &FaultInjectorConfig{Faults: []*Fault{fault}}
+
+	// Both injectors inject the fault once, and changing it afterwards
+	// doesn't change them.
+	for n := 1; n <= 2; n++ {
+		injector, err := NewFaultInjector(config)
+		assert.NoError(t, err)
+		fault.Times = 0
+		c := customer.Client{B: injector.Backend(&stripe.BackendConfig{
+			MaxNetworkRetries: stripe.Int64(1),
+			URL:               stripe.String(ts.URL),
+		}), Key: "sk_test_123"}
+
+		_, err = c.Get("cus_123", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, injector.Count(fault))
+		assert.Equal(t, int32(n), atomic.LoadInt32(requests))
+		fault.Times = 1
+	}
+	assert.Equal(t, &Fault{DropConnection: true, Times: 1}, fault)
+}
+
+func TestNewFaultInjectorInvalidPath(t *testing.T) {
+	_, err := NewFaultInjector(&FaultInjectorConfig{Faults: []*Fault{{Path: "("}}})
+	assert.Error(t, err)
+}
+
+func newFaultTestServer() (*httptest.Server, *int32) {
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write([]byte(`{"id":"cus_123","object":"customer"}`))
+	}))
+	return ts, &requests
+}
@@ -0,0 +1,389 @@
+package fixtures
+
+import (
+	"strings"
+	"time"
+
+	stripe "github.com/stripe/stripe-go/v81"
+)
+
+//
+// Public functions
+//
+
+// Invoice builds an invoice. Its totals and amounts due, paid and remaining
+// are computed from its line items and status, and its line items get the
+// currency of the invoice when they don't have one.
+//
+// When the template has no line items and references an expanded
+// subscription, there's a line item for each item of the subscription over
+// its current period. Otherwise there's a single line item of DefaultAmount.
+//
+// Invoices default to a paid invoice in USD.
+func Invoice(template *stripe.Invoice) *stripe.Invoice {
+	in := &stripe.Invoice{}
+	clone(template, in)
+
+	if in.ID == "" {
+		in.ID = NewID("in")
+	}
+	in.Object = "invoice"
+	if in.CollectionMethod == "" {
+		in.CollectionMethod = stripe.InvoiceCollectionMethodChargeAutomatically
+	}
+	if in.Created == 0 {
+		in.Created = timestamp()
+	}
+	if in.Customer == nil {
+		in.Customer = &stripe.Customer{ID: NewID("cus")}
+	}
+	if in.Metadata == nil {
+		in.Metadata = stripe.Metadata{}
+	}
+	if in.PeriodEnd == 0 {
+		in.PeriodEnd = in.Created
+	}
+	if in.PeriodStart == 0 {
+		in.PeriodStart = in.Created
+	}
+	if in.Status == "" {
+		in.Status = stripe.InvoiceStatusPaid
+	}
+
+	var lines []*stripe.InvoiceLineItem
+	if in.Lines != nil {
+		lines = in.Lines.Data
+	}
+	if sub := in.Subscription; sub.IsExpanded() {
+		if in.BillingReason == "" {
+			in.BillingReason = stripe.InvoiceBillingReasonSubscriptionCycle
+		}
+		if in.Currency == "" {
+			in.Currency = sub.Currency
+		}
+		if lines == nil && sub.Items != nil {
+			for _, item := range sub.Items.Data {
+				quantity := item.Quantity
+				if quantity == 0 {
+					quantity = 1
+				}
+				var amount int64
+				if item.Price != nil {
+					amount = item.Price.UnitAmount * quantity
+				}
+				lines = append(lines, &stripe.InvoiceLineItem{
+					Amount:           amount,
+					Period:           &stripe.Period{End: sub.CurrentPeriodEnd, Start: sub.CurrentPeriodStart},
+					Price:            item.Price,
+					Quantity:         quantity,
+					Subscription:     &stripe.Subscription{ID: sub.ID},
+					SubscriptionItem: &stripe.SubscriptionItem{ID: item.ID},
+					Type:             stripe.InvoiceLineItemTypeSubscription,
+				})
+			}
+		}
+		in.Subscription = &stripe.Subscription{ID: sub.ID}
+	}
+	if in.BillingReason == "" {
+		in.BillingReason = stripe.InvoiceBillingReasonManual
+	}
+	if in.Currency == "" {
+		in.Currency = stripe.CurrencyUSD
+	}
+	if lines == nil {
+		lines = []*stripe.InvoiceLineItem{{
+			Amount:      DefaultAmount,
+			InvoiceItem: &stripe.InvoiceItem{ID: NewID("ii")},
+		}}
+	}
+
+	var total int64
+	for _, line := range lines {
+		if line.ID == "" {
+			line.ID = NewID("il")
+		}
+		line.Object = "line_item"
+		if line.AmountExcludingTax == 0 {
+			line.AmountExcludingTax = line.Amount
+		}
+		if line.Currency == "" {
+			line.Currency = in.Currency
+		}
+		line.Invoice = in.ID
+		if line.Metadata == nil {
+			line.Metadata = stripe.Metadata{}
+		}
+		if line.Period == nil {
+			line.Period = &stripe.Period{End: in.PeriodEnd, Start: in.PeriodStart}
+		}
+		if line.Quantity == 0 {
+			line.Quantity = 1
+		}
+		if line.Type == "" {
+			line.Type = stripe.InvoiceLineItemTypeInvoiceItem
+		}
+		total += line.Amount
+	}
+	in.Lines = &stripe.InvoiceLineItemList{
+		Data: lines,
+		ListMeta: stripe.ListMeta{
+			URL: "/v1/invoices/" + in.ID + "/lines",
+		},
+	}
+
+	in.Subtotal = total
+	in.SubtotalExcludingTax = total
+	in.Total = total
+	in.TotalExcludingTax = total
+	in.AmountDue = total
+
+	if in.StatusTransitions == nil {
+		in.StatusTransitions = &stripe.InvoiceStatusTransitions{}
+	}
+	transitions := in.StatusTransitions
+	if in.Status != stripe.InvoiceStatusDraft {
+		if in.Number == "" {
+			in.Number = strings.ToUpper(strings.TrimPrefix(in.ID, "in_"))
+		}
+		if transitions.FinalizedAt == 0 {
+			transitions.FinalizedAt = in.Created
+		}
+	}
+	switch in.Status {
+	case stripe.InvoiceStatusPaid:
+		in.AmountPaid = in.AmountDue
+		in.Paid = true
+		if transitions.PaidAt == 0 {
+			transitions.PaidAt = in.Created
+		}
+		if in.AmountDue > 0 && in.CollectionMethod == stripe.InvoiceCollectionMethodChargeAutomatically && in.AttemptCount == 0 {
+			in.AttemptCount = 1
+		}
+	case stripe.InvoiceStatusUncollectible:
+		if transitions.MarkedUncollectibleAt == 0 {
+			transitions.MarkedUncollectibleAt = in.Created
+		}
+	case stripe.InvoiceStatusVoid:
+		if transitions.VoidedAt == 0 {
+			transitions.VoidedAt = in.Created
+		}
+	}
+	in.Attempted = in.AttemptCount > 0
+	in.AmountRemaining = in.AmountDue - in.AmountPaid
+
+	return in
+}
+
+// Price builds a price of a product. The price is recurring when the template
+// has Recurring set, and bills monthly unless its interval is set.
+//
+// Prices default to a one-time price of DefaultAmount in USD.
+func Price(template *stripe.Price) *stripe.Price {
+	p := &stripe.Price{}
+	clone(template, p)
+
+	if p.ID == "" {
+		p.ID = NewID("price")
+	}
+	p.Object = "price"
+	p.Active = true
+	if p.BillingScheme == "" {
+		p.BillingScheme = stripe.PriceBillingSchemePerUnit
+	}
+	if p.Created == 0 {
+		p.Created = timestamp()
+	}
+	if p.Currency == "" {
+		p.Currency = stripe.CurrencyUSD
+	}
+	if p.Metadata == nil {
+		p.Metadata = stripe.Metadata{}
+	}
+	if p.Product == nil {
+		p.Product = &stripe.Product{ID: NewID("prod")}
+	}
+	if p.TaxBehavior == "" {
+		p.TaxBehavior = stripe.PriceTaxBehaviorUnspecified
+	}
+	if p.UnitAmount == 0 {
+		p.UnitAmount = DefaultAmount
+	}
+	if p.UnitAmountDecimal.IsZero() {
+		p.UnitAmountDecimal = stripe.NewDecimal(p.UnitAmount, 0)
+	}
+
+	if p.Recurring != nil {
+		p.Type = stripe.PriceTypeRecurring
+		if p.Recurring.Interval == "" {
+			p.Recurring.Interval = stripe.PriceRecurringIntervalMonth
+		}
+		if p.Recurring.IntervalCount == 0 {
+			p.Recurring.IntervalCount = 1
+		}
+		if p.Recurring.UsageType == "" {
+			p.Recurring.UsageType = stripe.PriceRecurringUsageTypeLicensed
+		}
+	} else {
+		p.Type = stripe.PriceTypeOneTime
+	}
+
+	return p
+}
+
+// Product builds a product.
+func Product(template *stripe.Product) *stripe.Product {
+	p := &stripe.Product{}
+	clone(template, p)
+
+	if p.ID == "" {
+		p.ID = NewID("prod")
+	}
+	p.Object = "product"
+	p.Active = true
+	if p.Created == 0 {
+		p.Created = timestamp()
+	}
+	if p.Images == nil {
+		p.Images = []string{}
+	}
+	if p.Metadata == nil {
+		p.Metadata = stripe.Metadata{}
+	}
+	if p.Name == "" {
+		p.Name = "Gold"
+	}
+	if p.Type == "" {
+		p.Type = stripe.ProductTypeService
+	}
+	if p.Updated == 0 {
+		p.Updated = p.Created
+	}
+
+	return p
+}
+
+// Subscription builds a subscription. Its items get recurring prices when they
+// don't have one, and its currency and current period follow the price of its
+// first item. Trialing subscriptions are on trial for their current period.
+//
+// Subscriptions default to an active subscription with a single item for a
+// monthly price of DefaultAmount in USD.
+func Subscription(template *stripe.Subscription) *stripe.Subscription {
+	s := &stripe.Subscription{}
+	clone(template, s)
+
+	if s.ID == "" {
+		s.ID = NewID("sub")
+	}
+	s.Object = "subscription"
+	if s.CollectionMethod == "" {
+		s.CollectionMethod = stripe.SubscriptionCollectionMethodChargeAutomatically
+	}
+	if s.Created == 0 {
+		s.Created = timestamp()
+	}
+	if s.Customer == nil {
+		s.Customer = &stripe.Customer{ID: NewID("cus")}
+	}
+	if s.Metadata == nil {
+		s.Metadata = stripe.Metadata{}
+	}
+	if s.StartDate == 0 {
+		s.StartDate = s.Created
+	}
+	if s.Status == "" {
+		s.Status = stripe.SubscriptionStatusActive
+	}
+
+	var items []*stripe.SubscriptionItem
+	if s.Items != nil {
+		items = s.Items.Data
+	}
+	if len(items) == 0 {
+		items = []*stripe.SubscriptionItem{{}}
+	}
+	for _, item := range items {
+		if item.ID == "" {
+			item.ID = NewID("si")
+		}
+		item.Object = "subscription_item"
+		if item.Created == 0 {
+			item.Created = s.Created
+		}
+		if item.Metadata == nil {
+			item.Metadata = stripe.Metadata{}
+		}
+		if item.Price == nil {
+			item.Price = Price(&stripe.Price{
+				Currency:  s.Currency,
+				Recurring: &stripe.PriceRecurring{},
+			})
+		}
+		if item.Quantity == 0 {
+			item.Quantity = 1
+		}
+		item.Subscription = s.ID
+	}
+	s.Items = &stripe.SubscriptionItemList{
+		Data: items,
+		ListMeta: stripe.ListMeta{
+			URL: "/v1/subscription_items?subscription=" + s.ID,
+		},
+	}
+
+	price := items[0].Price
+	if s.Currency == "" {
+		s.Currency = price.Currency
+	}
+	if s.CurrentPeriodStart == 0 {
+		s.CurrentPeriodStart = s.StartDate
+	}
+	if s.CurrentPeriodEnd == 0 {
+		start := time.Unix(s.CurrentPeriodStart, 0)
+		interval, count := stripe.PriceRecurringIntervalMonth, int64(1)
+		if price.Recurring != nil {
+			interval, count = price.Recurring.Interval, price.Recurring.IntervalCount
+		}
+		s.CurrentPeriodEnd = addInterval(start, interval, count).Unix()
+	}
+	if s.BillingCycleAnchor == 0 {
+		s.BillingCycleAnchor = s.CurrentPeriodEnd
+	}
+	if s.Status == stripe.SubscriptionStatusTrialing {
+		if s.TrialStart == 0 {
+			s.TrialStart = s.CurrentPeriodStart
+		}
+		if s.TrialEnd == 0 {
+			s.TrialEnd = s.CurrentPeriodEnd
+		}
+	}
+	if s.Status == stripe.SubscriptionStatusCanceled {
+		if s.CanceledAt == 0 {
+			s.CanceledAt = s.CurrentPeriodStart
+		}
+		if s.EndedAt == 0 {
+			s.EndedAt = s.CanceledAt
+		}
+	}
+
+	return s
+}
+
+//
+// Private functions
+//
+
+// addInterval returns t moved forward by count recurring intervals.
+func addInterval(t time.Time, interval stripe.PriceRecurringInterval, count int64) time.Time {
+	n := int(count)
+	switch interval {
+	case stripe.PriceRecurringIntervalDay:
+		return t.AddDate(0, 0, n)
+	case stripe.PriceRecurringIntervalWeek:
+		return t.AddDate(0, 0, 7*n)
+	case stripe.PriceRecurringIntervalYear:
+		return t.AddDate(n, 0, 0)
+	default:
+		return t.AddDate(0, n, 0)
+	}
+}
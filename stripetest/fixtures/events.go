@@ -0,0 +1,122 @@
+package fixtures
+
+import (
+	"encoding/json"
+	"fmt"
+
+	stripe "github.com/stripe/stripe-go/v81"
+	"github.com/stripe/stripe-go/v81/webhook"
+)
+
+//
+// Public functions
+//
+
+// Event wraps an object in an event of the given type, rendered for the API
+// version of the library so that it can be passed to webhook.ConstructEvent.
+// The object is rendered with JSON, and available both as the raw data and
+// the decoded map of the event, like in events decoded from a webhook.
+//
+// Prefer the builders for specific event types, like InvoicePaidEvent, which
+// make sure that the object matches the type of the event.
+func Event(eventType stripe.EventType, object interface{}) *stripe.Event {
+	return UpdatedEvent(eventType, object, nil)
+}
+
+// SignedPayload renders an event and signs it with the webhook secret, like
+// webhook.GenerateTestSignedPayload does, so that it can be sent to a webhook
+// handler with the header in `Stripe-Signature`.
+func SignedPayload(event *stripe.Event, secret string) *webhook.SignedPayload {
+	return webhook.GenerateTestSignedPayload(&webhook.UnsignedPayload{
+		Payload: JSON(event),
+		Secret:  secret,
+	})
+}
+
+// UpdatedEvent wraps an object in an event of the given type, along with the
+// previous values of the attributes which changed, like for
+// `customer.subscription.updated` events.
+func UpdatedEvent(eventType stripe.EventType, object interface{}, previousAttributes map[string]interface{}) *stripe.Event {
+	e := &stripe.Event{
+		APIVersion: stripe.APIVersion,
+		Created:    timestamp(),
+		Data: &stripe.EventData{
+			PreviousAttributes: previousAttributes,
+			Raw:                JSON(object),
+		},
+		ID:      NewID("evt"),
+		Object:  "event",
+		Request: &stripe.EventRequest{},
+		Type:    eventType,
+	}
+
+	// Round-trip the event so that its data is decoded like in events
+	// received from the API.
+	decoded := &stripe.Event{}
+	if err := json.Unmarshal(JSON(e), decoded); err != nil {
+		panic(fmt.Sprintf("fixtures: can't decode %s event: %v", eventType, err))
+	}
+	return decoded
+}
+
+// ChargeFailedEvent returns a `charge.failed` event for the charge.
+func ChargeFailedEvent(c *stripe.Charge) *stripe.Event {
+	return Event(stripe.EventTypeChargeFailed, c)
+}
+
+// ChargeRefundedEvent returns a `charge.refunded` event for the charge.
+func ChargeRefundedEvent(c *stripe.Charge) *stripe.Event {
+	return Event(stripe.EventTypeChargeRefunded, c)
+}
+
+// ChargeSucceededEvent returns a `charge.succeeded` event for the charge.
+func ChargeSucceededEvent(c *stripe.Charge) *stripe.Event {
+	return Event(stripe.EventTypeChargeSucceeded, c)
+}
+
+// CustomerCreatedEvent returns a `customer.created` event for the customer.
+func CustomerCreatedEvent(c *stripe.Customer) *stripe.Event {
+	return Event(stripe.EventTypeCustomerCreated, c)
+}
+
+// CustomerSubscriptionCreatedEvent returns a `customer.subscription.created`
+// event for the subscription.
+func CustomerSubscriptionCreatedEvent(s *stripe.Subscription) *stripe.Event {
+	return Event(stripe.EventTypeCustomerSubscriptionCreated, s)
+}
+
+// CustomerSubscriptionDeletedEvent returns a `customer.subscription.deleted`
+// event for the subscription.
+func CustomerSubscriptionDeletedEvent(s *stripe.Subscription) *stripe.Event {
+	return Event(stripe.EventTypeCustomerSubscriptionDeleted, s)
+}
+
+// CustomerSubscriptionUpdatedEvent returns a `customer.subscription.updated`
+// event for the subscription, with the previous values of the attributes
+// which changed.
+func CustomerSubscriptionUpdatedEvent(s *stripe.Subscription, previousAttributes map[string]interface{}) *stripe.Event {
+	return UpdatedEvent(stripe.EventTypeCustomerSubscriptionUpdated, s, previousAttributes)
+}
+
+// InvoicePaidEvent returns an `invoice.paid` event for the invoice.
+func InvoicePaidEvent(in *stripe.Invoice) *stripe.Event {
+	return Event(stripe.EventTypeInvoicePaid, in)
+}
+
+// InvoicePaymentFailedEvent returns an `invoice.payment_failed` event for the
+// invoice.
+func InvoicePaymentFailedEvent(in *stripe.Invoice) *stripe.Event {
+	return Event(stripe.EventTypeInvoicePaymentFailed, in)
+}
+
+// PaymentIntentPaymentFailedEvent returns a `payment_intent.payment_failed`
+// event for the payment intent.
+func PaymentIntentPaymentFailedEvent(pi *stripe.PaymentIntent) *stripe.Event {
+	return Event(stripe.EventTypePaymentIntentPaymentFailed, pi)
+}
+
+// PaymentIntentSucceededEvent returns a `payment_intent.succeeded` event for
+// the payment intent.
+func PaymentIntentSucceededEvent(pi *stripe.PaymentIntent) *stripe.Event {
+	return Event(stripe.EventTypePaymentIntentSucceeded, pi)
+}
@@ -0,0 +1,183 @@
+package fixtures
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+	stripe "github.com/stripe/stripe-go/v81"
+	"github.com/stripe/stripe-go/v81/webhook"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	testCases := []struct {
+		name    string
+		object  interface{}
+		decoded interface{}
+	}{
+		{"Charge", Charge(nil), &stripe.Charge{}},
+		{"Customer", Customer(nil), &stripe.Customer{}},
+		{"Invoice", Invoice(nil), &stripe.Invoice{}},
+		{"PaymentIntent", PaymentIntent(nil), &stripe.PaymentIntent{}},
+		{"PaymentMethod", PaymentMethod(nil), &stripe.PaymentMethod{}},
+		{"Price", Price(nil), &stripe.Price{}},
+		{"Product", Product(nil), &stripe.Product{}},
+		{"Refund", Refund(nil), &stripe.Refund{}},
+		{"Subscription", Subscription(nil), &stripe.Subscription{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data := JSON(tc.object)
+			assert.NoError(t, json.Unmarshal(data, tc.decoded))
+			assert.Equal(t, tc.object, tc.decoded)
+			assert.JSONEq(t, string(data), string(JSON(tc.decoded)))
+		})
+	}
+}
+
+func TestJSONUnexpandedReferences(t *testing.T) {
+	c := Charge(&stripe.Charge{Customer: &stripe.Customer{ID: "cus_123"}})
+
+	var raw map[string]interface{}
+	assert.NoError(t, json.Unmarshal(JSON(c), &raw))
+	assert.Equal(t, "cus_123", raw["customer"])
+	assert.Equal(t, "charge", raw["object"])
+	assert.IsType(t, map[string]interface{}{}, raw["payment_method_details"])
+}
+
+func TestChargeFromPaymentIntent(t *testing.T) {
+	pi := PaymentIntent(&stripe.PaymentIntent{
+		Amount:   1500,
+		Currency: stripe.CurrencyEUR,
+		Customer: &stripe.Customer{ID: "cus_123"},
+		Status:   stripe.PaymentIntentStatusRequiresCapture,
+	})
+	assert.Equal(t, int64(1500), pi.AmountCapturable)
+	assert.Equal(t, int64(0), pi.AmountReceived)
+	assert.NotNil(t, pi.LatestCharge)
+
+	c := Charge(&stripe.Charge{PaymentIntent: pi})
+	assert.Equal(t, pi.LatestCharge.ID, c.ID)
+	assert.Equal(t, int64(1500), c.Amount)
+	assert.Equal(t, stripe.CurrencyEUR, c.Currency)
+	assert.Equal(t, "cus_123", c.Customer.ID)
+	assert.Equal(t, pi.PaymentMethod.ID, c.PaymentMethod)
+	assert.Equal(t, pi.ID, c.PaymentIntent.ID)
+	assert.False(t, c.PaymentIntent.IsExpanded())
+	assert.True(t, c.Paid)
+	assert.False(t, c.Captured)
+
+	// The template isn't modified.
+	assert.True(t, pi.IsExpanded())
+}
+
+func TestRefundFromCharge(t *testing.T) {
+	c := Charge(&stripe.Charge{Amount: 3000, AmountRefunded: 1000, Currency: stripe.CurrencyGBP})
+	assert.False(t, c.Refunded)
+
+	r := Refund(&stripe.Refund{Charge: c})
+	assert.Equal(t, int64(2000), r.Amount)
+	assert.Equal(t, stripe.CurrencyGBP, r.Currency)
+	assert.Equal(t, c.ID, r.Charge.ID)
+	assert.Equal(t, stripe.RefundStatusSucceeded, r.Status)
+}
+
+func TestInvoiceTotals(t *testing.T) {
+	in := Invoice(&stripe.Invoice{
+		Currency: stripe.CurrencyEUR,
+		Lines: &stripe.InvoiceLineItemList{Data: []*stripe.InvoiceLineItem{
+			{Amount: 1000},
+			{Amount: 250, Quantity: 2},
+		}},
+		Status: stripe.InvoiceStatusOpen,
+	})
+	assert.Equal(t, int64(1250), in.Total)
+	assert.Equal(t, int64(1250), in.AmountDue)
+	assert.Equal(t, int64(0), in.AmountPaid)
+	assert.Equal(t, int64(1250), in.AmountRemaining)
+	assert.False(t, in.Paid)
+	assert.NotEmpty(t, in.Number)
+	for _, line := range in.Lines.Data {
+		assert.Equal(t, stripe.CurrencyEUR, line.Currency)
+		assert.Equal(t, in.ID, line.Invoice)
+	}
+
+	paid := Invoice(nil)
+	assert.Equal(t, stripe.InvoiceStatusPaid, paid.Status)
+	assert.Equal(t, DefaultAmount, paid.AmountPaid)
+	assert.Equal(t, int64(0), paid.AmountRemaining)
+	assert.True(t, paid.Paid)
+	assert.Equal(t, int64(1), paid.AttemptCount)
+}
+
+func TestInvoiceFromSubscription(t *testing.T) {
+	now := time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC)
+	defer func() { Now = time.Now }()
+	Now = func() time.Time { return now }
+
+	price := Price(&stripe.Price{
+		Currency:   stripe.CurrencyEUR,
+		Recurring:  &stripe.PriceRecurring{},
+		UnitAmount: 500,
+	})
+	sub := Subscription(&stripe.Subscription{
+		Items: &stripe.SubscriptionItemList{Data: []*stripe.SubscriptionItem{
+			{Price: price, Quantity: 3},
+		}},
+	})
+	assert.Equal(t, stripe.CurrencyEUR, sub.Currency)
+	assert.Equal(t, now.Unix(), sub.CurrentPeriodStart)
+	assert.Equal(t, now.AddDate(0, 1, 0).Unix(), sub.CurrentPeriodEnd)
+	assert.Equal(t, sub.ID, sub.Items.Data[0].Subscription)
+
+	in := Invoice(&stripe.Invoice{Customer: sub.Customer, Subscription: sub})
+	assert.Equal(t, stripe.InvoiceBillingReasonSubscriptionCycle, in.BillingReason)
+	assert.Equal(t, stripe.CurrencyEUR, in.Currency)
+	assert.Equal(t, int64(1500), in.Total)
+	assert.Equal(t, sub.ID, in.Subscription.ID)
+	assert.Equal(t, 1, len(in.Lines.Data))
+	line := in.Lines.Data[0]
+	assert.Equal(t, stripe.InvoiceLineItemTypeSubscription, line.Type)
+	assert.Equal(t, sub.Items.Data[0].ID, line.SubscriptionItem.ID)
+	assert.Equal(t, sub.CurrentPeriodEnd, line.Period.End)
+}
+
+func TestSubscriptionTrialing(t *testing.T) {
+	sub := Subscription(&stripe.Subscription{Status: stripe.SubscriptionStatusTrialing})
+	assert.Equal(t, sub.CurrentPeriodStart, sub.TrialStart)
+	assert.Equal(t, sub.CurrentPeriodEnd, sub.TrialEnd)
+	assert.Equal(t, stripe.PriceTypeRecurring, sub.Items.Data[0].Price.Type)
+}
+
+func TestEvent(t *testing.T) {
+	in := Invoice(nil)
+	e := InvoicePaidEvent(in)
+	assert.Equal(t, stripe.EventTypeInvoicePaid, e.Type)
+	assert.Equal(t, stripe.APIVersion, e.APIVersion)
+	assert.Equal(t, in.ID, e.GetObjectValue("id"))
+	assert.Equal(t, in.Customer.ID, e.GetObjectValue("customer"))
+
+	var decoded stripe.Invoice
+	assert.NoError(t, json.Unmarshal(e.Data.Raw, &decoded))
+	assert.Equal(t, in, &decoded)
+
+	sub := Subscription(nil)
+	e = CustomerSubscriptionUpdatedEvent(sub, map[string]interface{}{"status": "trialing"})
+	assert.Equal(t, "trialing", e.GetPreviousValue("status"))
+}
+
+func TestSignedPayload(t *testing.T) {
+	pi := PaymentIntent(nil)
+	payload := SignedPayload(PaymentIntentSucceededEvent(pi), "whsec_123")
+
+	e, err := webhook.ConstructEvent(payload.Payload, payload.Header, "whsec_123")
+	assert.NoError(t, err)
+	assert.Equal(t, stripe.EventTypePaymentIntentSucceeded, e.Type)
+	assert.Equal(t, pi.ID, e.GetObjectValue("id"))
+
+	var decoded stripe.PaymentIntent
+	assert.NoError(t, json.Unmarshal(e.Data.Raw, &decoded))
+	assert.Equal(t, pi, &decoded)
+}
@@ -0,0 +1,318 @@
+package fixtures
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	stripe "github.com/stripe/stripe-go/v81"
+)
+
+//
+// Public functions
+//
+
+// Charge builds a charge. When the template references an expanded payment
+// intent, the amount, currency, customer and payment method of the charge are
+// taken from it, the charge gets the ID of its latest charge, and it is only
+// captured if the payment intent succeeded.
+//
+// Charges default to a successful card payment of DefaultAmount in USD.
+func Charge(template *stripe.Charge) *stripe.Charge {
+	c := &stripe.Charge{}
+	clone(template, c)
+
+	captured := true
+	if pi := c.PaymentIntent; pi.IsExpanded() {
+		if c.ID == "" && pi.LatestCharge != nil {
+			c.ID = pi.LatestCharge.ID
+		}
+		if c.Amount == 0 {
+			c.Amount = pi.Amount
+		}
+		if c.Currency == "" {
+			c.Currency = pi.Currency
+		}
+		if c.Customer == nil && pi.Customer != nil {
+			c.Customer = &stripe.Customer{ID: pi.Customer.ID}
+		}
+		if c.PaymentMethod == "" && pi.PaymentMethod != nil {
+			c.PaymentMethod = pi.PaymentMethod.ID
+		}
+		captured = pi.Status == stripe.PaymentIntentStatusSucceeded
+		c.PaymentIntent = &stripe.PaymentIntent{ID: pi.ID}
+	}
+
+	if c.ID == "" {
+		c.ID = NewID("ch")
+	}
+	c.Object = "charge"
+	if c.Amount == 0 {
+		c.Amount = DefaultAmount
+	}
+	if c.Created == 0 {
+		c.Created = timestamp()
+	}
+	if c.Currency == "" {
+		c.Currency = stripe.CurrencyUSD
+	}
+	if c.Metadata == nil {
+		c.Metadata = stripe.Metadata{}
+	}
+	if c.PaymentMethod == "" {
+		c.PaymentMethod = NewID("pm")
+	}
+	if c.PaymentMethodDetails == nil {
+		c.PaymentMethodDetails = &stripe.ChargePaymentMethodDetails{
+			Card: &stripe.ChargePaymentMethodDetailsCard{
+				Brand:    stripe.PaymentMethodCardBrandVisa,
+				Country:  "US",
+				ExpMonth: 12,
+				ExpYear:  int64(Now().Year() + 1),
+				Funding:  stripe.CardFundingCredit,
+				Last4:    "4242",
+			},
+			Type: stripe.ChargePaymentMethodDetailsTypeCard,
+		}
+	}
+	if c.Status == "" {
+		c.Status = stripe.ChargeStatusSucceeded
+	}
+
+	switch c.Status {
+	case stripe.ChargeStatusSucceeded:
+		c.Paid = true
+		if c.Outcome == nil {
+			c.Outcome = &stripe.ChargeOutcome{
+				NetworkStatus: "approved_by_network",
+				RiskLevel:     "normal",
+				SellerMessage: "Payment complete.",
+				Type:          "authorized",
+			}
+		}
+		if captured || c.AmountCaptured > 0 {
+			c.Captured = true
+			if c.AmountCaptured == 0 {
+				c.AmountCaptured = c.Amount
+			}
+			if c.BalanceTransaction == nil {
+				c.BalanceTransaction = &stripe.BalanceTransaction{ID: NewID("txn")}
+			}
+		}
+	case stripe.ChargeStatusFailed:
+		if c.FailureCode == "" {
+			c.FailureCode = string(stripe.ErrorCodeCardDeclined)
+		}
+		if c.FailureMessage == "" {
+			c.FailureMessage = "Your card was declined."
+		}
+		if c.Outcome == nil {
+			c.Outcome = &stripe.ChargeOutcome{
+				NetworkStatus: "declined_by_network",
+				Reason:        "generic_decline",
+				RiskLevel:     "normal",
+				SellerMessage: "The bank did not return any further details with this decline.",
+				Type:          "issuer_declined",
+			}
+		}
+	}
+	c.Refunded = c.AmountRefunded > 0 && c.AmountRefunded >= c.Amount
+
+	return c
+}
+
+// Customer builds a customer.
+func Customer(template *stripe.Customer) *stripe.Customer {
+	c := &stripe.Customer{}
+	clone(template, c)
+
+	if c.ID == "" {
+		c.ID = NewID("cus")
+	}
+	c.Object = "customer"
+	if c.Created == 0 {
+		c.Created = timestamp()
+	}
+	if c.Email == "" {
+		c.Email = "jenny.rosen@example.com"
+	}
+	if c.InvoiceSettings == nil {
+		c.InvoiceSettings = &stripe.CustomerInvoiceSettings{}
+	}
+	if c.Metadata == nil {
+		c.Metadata = stripe.Metadata{}
+	}
+	if c.Name == "" {
+		c.Name = "Jenny Rosen"
+	}
+
+	return c
+}
+
+// PaymentIntent builds a payment intent. Payment intents which succeeded or
+// require capture reference their latest charge, which can be built with
+// Charge by passing it the payment intent.
+//
+// Payment intents default to a successful card payment of DefaultAmount in
+// USD.
+func PaymentIntent(template *stripe.PaymentIntent) *stripe.PaymentIntent {
+	pi := &stripe.PaymentIntent{}
+	clone(template, pi)
+
+	if pi.ID == "" {
+		pi.ID = NewID("pi")
+	}
+	pi.Object = "payment_intent"
+	if pi.Amount == 0 {
+		pi.Amount = DefaultAmount
+	}
+	if pi.CaptureMethod == "" {
+		pi.CaptureMethod = stripe.PaymentIntentCaptureMethodAutomatic
+	}
+	if pi.ClientSecret == "" {
+		pi.ClientSecret = pi.ID + "_secret_fixture"
+	}
+	if pi.ConfirmationMethod == "" {
+		pi.ConfirmationMethod = stripe.PaymentIntentConfirmationMethodAutomatic
+	}
+	if pi.Created == 0 {
+		pi.Created = timestamp()
+	}
+	if pi.Currency == "" {
+		pi.Currency = stripe.CurrencyUSD
+	}
+	if pi.Metadata == nil {
+		pi.Metadata = stripe.Metadata{}
+	}
+	if pi.PaymentMethodTypes == nil {
+		pi.PaymentMethodTypes = []string{string(stripe.PaymentMethodTypeCard)}
+	}
+	if pi.Status == "" {
+		pi.Status = stripe.PaymentIntentStatusSucceeded
+	}
+
+	switch pi.Status {
+	case stripe.PaymentIntentStatusSucceeded, stripe.PaymentIntentStatusRequiresCapture:
+		if pi.PaymentMethod == nil {
+			pi.PaymentMethod = &stripe.PaymentMethod{ID: NewID("pm")}
+		}
+		if pi.LatestCharge == nil {
+			pi.LatestCharge = &stripe.Charge{ID: NewID("ch")}
+		}
+		if pi.Status == stripe.PaymentIntentStatusSucceeded {
+			if pi.AmountReceived == 0 {
+				pi.AmountReceived = pi.Amount
+			}
+		} else if pi.AmountCapturable == 0 {
+			pi.AmountCapturable = pi.Amount
+		}
+	case stripe.PaymentIntentStatusProcessing, stripe.PaymentIntentStatusRequiresConfirmation:
+		if pi.PaymentMethod == nil {
+			pi.PaymentMethod = &stripe.PaymentMethod{ID: NewID("pm")}
+		}
+	}
+
+	return pi
+}
+
+// PaymentMethod builds a payment method, which defaults to a Visa card.
+func PaymentMethod(template *stripe.PaymentMethod) *stripe.PaymentMethod {
+	pm := &stripe.PaymentMethod{}
+	clone(template, pm)
+
+	if pm.ID == "" {
+		pm.ID = NewID("pm")
+	}
+	pm.Object = "payment_method"
+	if pm.BillingDetails == nil {
+		pm.BillingDetails = &stripe.PaymentMethodBillingDetails{}
+	}
+	if pm.Created == 0 {
+		pm.Created = timestamp()
+	}
+	if pm.Metadata == nil {
+		pm.Metadata = stripe.Metadata{}
+	}
+	if pm.Type == "" {
+		pm.Type = stripe.PaymentMethodTypeCard
+	}
+	if pm.Type == stripe.PaymentMethodTypeCard && pm.Card == nil {
+		pm.Card = &stripe.PaymentMethodCard{
+			Brand:    stripe.PaymentMethodCardBrandVisa,
+			Country:  "US",
+			ExpMonth: 12,
+			ExpYear:  int64(Now().Year() + 1),
+			Funding:  stripe.CardFundingCredit,
+			Last4:    "4242",
+		}
+	}
+
+	return pm
+}
+
+// Refund builds a refund. When the template references an expanded charge,
+// the refund defaults to the amount of the charge which isn't refunded yet,
+// in its currency, and references its payment intent.
+//
+// Refunds default to a successful refund of DefaultAmount in USD.
+func Refund(template *stripe.Refund) *stripe.Refund {
+	r := &stripe.Refund{}
+	clone(template, r)
+
+	if c := r.Charge; c.IsExpanded() {
+		if r.Amount == 0 {
+			r.Amount = c.Amount - c.AmountRefunded
+		}
+		if r.Currency == "" {
+			r.Currency = c.Currency
+		}
+		if r.PaymentIntent == nil && c.PaymentIntent != nil {
+			r.PaymentIntent = &stripe.PaymentIntent{ID: c.PaymentIntent.ID}
+		}
+		r.Charge = &stripe.Charge{ID: c.ID}
+	}
+
+	if r.ID == "" {
+		r.ID = NewID("re")
+	}
+	r.Object = "refund"
+	if r.Amount == 0 {
+		r.Amount = DefaultAmount
+	}
+	if r.Created == 0 {
+		r.Created = timestamp()
+	}
+	if r.Currency == "" {
+		r.Currency = stripe.CurrencyUSD
+	}
+	if r.Metadata == nil {
+		r.Metadata = stripe.Metadata{}
+	}
+	if r.Status == "" {
+		r.Status = stripe.RefundStatusSucceeded
+	}
+	if r.Status == stripe.RefundStatusSucceeded && r.BalanceTransaction == nil {
+		r.BalanceTransaction = &stripe.BalanceTransaction{ID: NewID("txn")}
+	}
+
+	return r
+}
+
+//
+// Private functions
+//
+
+// clone copies the template into v, so that the nested objects of the
+// template aren't modified when filling in v.
+func clone(template interface{}, v interface{}) {
+	if reflect.ValueOf(template).IsNil() {
+		return
+	}
+	data, err := json.Marshal(template)
+	if err != nil {
+		panic(fmt.Sprintf("fixtures: can't copy %T: %v", template, err))
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		panic(fmt.Sprintf("fixtures: can't copy %T: %v", template, err))
+	}
+}
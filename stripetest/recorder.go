@@ -0,0 +1,426 @@
+// Package stripetest provides helpers for testing code that uses the Stripe
+// API without needing stripe-mock or a connection to Stripe.
+package stripetest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	stripe "github.com/stripe/stripe-go/v81"
+)
+
+//
+// Public constants
+//
+
+// Mode is the mode in which a Recorder runs.
+type Mode string
+
+// List of values that Mode can take.
+const (
+	// ModeRecord proxies requests to the configured URL and records them
+	// along with their responses.
+	ModeRecord Mode = "record"
+
+	// ModeReplay serves requests from the recorded interactions, without
+	// making any network request.
+	ModeReplay Mode = "replay"
+)
+
+// Redacted is what secrets found in recorded requests and responses are
+// replaced with.
+const Redacted = "REDACTED"
+
+//
+// Public types
+//
+
+// Cassette is the content of a fixture file: the interactions recorded, in
+// the order in which they happened.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a request recorded along with its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a request made to the Stripe API.
+type RecordedRequest struct {
+	// Body is the body of the request. Form encoded bodies are normalized so
+	// that the order of their parameters doesn't matter, and multipart bodies,
+	// like those of file uploads, so that their random boundary doesn't
+	// either. Parts which aren't valid UTF-8, like binary files, are recorded
+	// as their SHA-256 digest.
+	Body string `json:"body,omitempty"`
+
+	Method string `json:"method"`
+
+	// Path is the path of the request, including its normalized query string
+	// if it has one.
+	Path string `json:"path"`
+}
+
+// RecordedResponse is the response to a RecordedRequest.
+type RecordedResponse struct {
+	Body       string      `json:"body"`
+	Header     http.Header `json:"header,omitempty"`
+	StatusCode int         `json:"status_code"`
+}
+
+// Recorder is an http.RoundTripper which records the requests made to the
+// Stripe API to a fixture file and replays them later on, so that flows run
+// against Stripe once can then be tested deterministically and offline.
+//
+// API keys, webhook secrets and client secrets are scrubbed from the recorded
+// interactions, and the Authorization header isn't recorded at all.
+type Recorder struct {
+	config   RecorderConfig
+	cassette *Cassette
+	mu       sync.Mutex
+	used     []bool
+}
+
+// RecorderConfig is used to configure a Recorder.
+type RecorderConfig struct {
+	// Mode is the mode in which the recorder runs.
+	//
+	// Defaults to ModeReplay.
+	Mode Mode
+
+	// Path is the path of the fixture file which interactions are recorded to
+	// or replayed from.
+	Path string
+
+	// Scrub is called with the body of every request and response before it's
+	// recorded, in addition to the default scrubbing of secrets, and returns
+	// the body to record. It can be used to remove other sensitive data from
+	// fixtures.
+	Scrub func(body string) string
+
+	// Transport is used to make requests in record mode.
+	//
+	// Defaults to http.DefaultTransport.
+	Transport http.RoundTripper
+
+	// URL is the base URL requests are proxied to in record mode.
+	//
+	// Defaults to stripe.APIURL.
+	URL string
+}
+
+// NewRecorder returns a Recorder. In replay mode, the interactions are loaded
+// from the fixture file, which must exist.
+func NewRecorder(config *RecorderConfig) (*Recorder, error) {
+	r := &Recorder{cassette: &Cassette{}}
+	if config != nil {
+		r.config = *config
+	}
+	if r.config.Mode == "" {
+		r.config.Mode = ModeReplay
+	}
+	if r.config.Transport == nil {
+		r.config.Transport = http.DefaultTransport
+	}
+	if r.config.URL == "" {
+		r.config.URL = stripe.APIURL
+	}
+
+	switch r.config.Mode {
+	case ModeRecord:
+	case ModeReplay:
+		data, err := ioutil.ReadFile(r.config.Path)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, r.cassette); err != nil {
+			return nil, fmt.Errorf("stripetest: invalid fixture file %s: %v", r.config.Path, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	default:
+		return nil, fmt.Errorf("stripetest: unknown mode %q", r.config.Mode)
+	}
+
+	return r, nil
+}
+
+// Backend returns a backend making its requests through the recorder.
+func (r *Recorder) Backend() stripe.Backend {
+	return stripe.GetBackendWithConfig(stripe.APIBackend, &stripe.BackendConfig{
+		HTTPClient:        &http.Client{Transport: r},
+		LeveledLogger:     &stripe.LeveledLogger{Level: stripe.LevelNull},
+		MaxNetworkRetries: stripe.Int64(0),
+		URL:               stripe.String(r.config.URL),
+	})
+}
+
+// Cassette returns the interactions recorded or loaded so far.
+func (r *Recorder) Cassette() *Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cassette := &Cassette{Interactions: make([]*Interaction, len(r.cassette.Interactions))}
+	copy(cassette.Interactions, r.cassette.Interactions)
+	return cassette
+}
+
+// RoundTrip is the http.RoundTripper implementation.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded, body, err := r.recordRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.config.Mode == ModeReplay {
+		return r.replay(req, recorded)
+	}
+
+	// The request's body was consumed to record it, so it needs to be reset
+	// before it's sent.
+	req = req.Clone(req.Context())
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	resp, err := r.config.Transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	header := resp.Header.Clone()
+	header.Del("Content-Length")
+	header.Del("Set-Cookie")
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, &Interaction{
+		Request: *recorded,
+		Response: RecordedResponse{
+			Body:       r.scrub(string(respBody)),
+			Header:     header,
+			StatusCode: resp.StatusCode,
+		},
+	})
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+// Save writes the recorded interactions to the fixture file, creating its
+// directory if needed. It does nothing in replay mode.
+func (r *Recorder) Save() error {
+	if r.config.Mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.config.Path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.config.Path, append(data, '\n'), 0644)
+}
+
+// Unused returns the recorded interactions which haven't been replayed. It
+// can be used at the end of a test to check that the code under test made
+// all the requests it was expected to.
+func (r *Recorder) Unused() []*Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var unused []*Interaction
+	for i, used := range r.used {
+		if !used {
+			unused = append(unused, r.cassette.Interactions[i])
+		}
+	}
+	return unused
+}
+
+//
+// Private constants
+//
+
+// multipartBoundary is the boundary of recorded multipart bodies.
+const multipartBoundary = "stripetest-boundary"
+
+//
+// Private variables
+//
+
+// secretPatterns match the secrets scrubbed from recorded interactions along
+// with the replacement for each of them.
+var secretPatterns = []struct {
+	pattern     *regexp.Regexp
+	replacement string
+}{
+	{regexp.MustCompile(`\b(sk|rk|ek)_(live|test)_[0-9a-zA-Z]+`), "${1}_${2}_" + Redacted},
+	{regexp.MustCompile(`\bwhsec_[0-9a-zA-Z]+`), "whsec_" + Redacted},
+	{regexp.MustCompile(`_secret_[0-9a-zA-Z]+`), "_secret_" + Redacted},
+}
+
+//
+// Private functions
+//
+
+// normalizeForm sorts the parameters of a form encoded string so that their
+// order doesn't matter when matching requests. Indexed parameters, like
+// `expand[0]`, keep their relative order since it's part of their key.
+func normalizeForm(s string) string {
+	if s == "" {
+		return ""
+	}
+	values, err := url.ParseQuery(s)
+	if err != nil {
+		return s
+	}
+	return values.Encode()
+}
+
+// normalizeMultipart rewrites a multipart body with a fixed boundary, so that
+// the random boundary of each request doesn't matter when matching requests.
+// Parts which aren't valid UTF-8 are replaced with their SHA-256 digest, so
+// that they survive being recorded to JSON. The body is returned as is if it
+// can't be parsed.
+func normalizeMultipart(body []byte, contentType string) string {
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return string(body)
+	}
+
+	normalized := &bytes.Buffer{}
+	writer := multipart.NewWriter(normalized)
+	if err := writer.SetBoundary(multipartBoundary); err != nil {
+		return string(body)
+	}
+
+	reader := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+	for {
+		part, err := reader.NextRawPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return string(body)
+		}
+		contents, err := ioutil.ReadAll(part)
+		if err != nil {
+			return string(body)
+		}
+		if !utf8.Valid(contents) {
+			contents = []byte(fmt.Sprintf("sha256:%x", sha256.Sum256(contents)))
+		}
+
+		w, err := writer.CreatePart(part.Header)
+		if err != nil {
+			return string(body)
+		}
+		w.Write(contents)
+	}
+	if err := writer.Close(); err != nil {
+		return string(body)
+	}
+	return normalized.String()
+}
+
+// recordRequest returns the request as it's recorded, along with its raw
+// body.
+func (r *Recorder) recordRequest(req *http.Request) (*RecordedRequest, []byte, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	recordedBody := string(body)
+	contentType := req.Header.Get("Content-Type")
+	switch {
+	case strings.HasPrefix(contentType, "application/x-www-form-urlencoded"):
+		recordedBody = normalizeForm(recordedBody)
+	case strings.HasPrefix(contentType, "multipart/form-data"):
+		recordedBody = normalizeMultipart(body, contentType)
+	}
+
+	path := req.URL.Path
+	if query := normalizeForm(req.URL.RawQuery); query != "" {
+		path += "?" + query
+	}
+
+	return &RecordedRequest{
+		Body:   r.scrub(recordedBody),
+		Method: req.Method,
+		Path:   r.scrub(path),
+	}, body, nil
+}
+
+// replay returns the response of the first recorded interaction matching the
+// request which hasn't been replayed yet.
+func (r *Recorder) replay(req *http.Request, recorded *RecordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.used[i] || interaction.Request != *recorded {
+			continue
+		}
+		r.used[i] = true
+
+		header := interaction.Response.Header.Clone()
+		if header == nil {
+			header = make(http.Header)
+		}
+		return &http.Response{
+			Body:          ioutil.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Header:        header,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Request:       req,
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("stripetest: no recorded interaction in %s matches %s %s (body: %q)",
+		r.config.Path, recorded.Method, recorded.Path, recorded.Body)
+}
+
+func (r *Recorder) scrub(s string) string {
+	for _, p := range secretPatterns {
+		s = p.pattern.ReplaceAllString(s, p.replacement)
+	}
+	if r.config.Scrub != nil {
+		s = r.config.Scrub(s)
+	}
+	return s
+}
@@ -0,0 +1,154 @@
+package stripetest
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	assert "github.com/stretchr/testify/require"
+	stripe "github.com/stripe/stripe-go/v81"
+	"github.com/stripe/stripe-go/v81/customer"
+	"github.com/stripe/stripe-go/v81/file"
+)
+
+func TestRecorder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "stripetest")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "fixtures", "customer.json")
+
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		r.ParseForm()
+		w.Header().Set("Request-Id", fmt.Sprintf("req_%d", requests))
+		w.Header().Set("Set-Cookie", "session=secret")
+		fmt.Fprintf(w, `{"id":"cus_123","object":"customer","email":%q,"description":"key sk_live_abc123"}`,
+			r.Form.Get("email"))
+	}))
+
+	// Record
+	{
+		recorder, err := NewRecorder(&RecorderConfig{
+			Mode: ModeRecord,
+			Path: path,
+			URL:  ts.URL,
+		})
+		assert.NoError(t, err)
+		c := customer.Client{B: recorder.Backend(), Key: "sk_test_secret123"}
+
+		params := &stripe.CustomerParams{
+			Email: stripe.String("jenny@example.com"),
+			Name:  stripe.String("Jenny Rosen"),
+		}
+		cus, err := c.New(params)
+		assert.NoError(t, err)
+		assert.Equal(t, "jenny@example.com", cus.Email)
+		assert.Equal(t, "key sk_live_abc123", cus.Description)
+
+		_, err = c.Get("cus_123", nil)
+		assert.NoError(t, err)
+
+		assert.NoError(t, recorder.Save())
+	}
+	ts.Close()
+
+	data, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "sk_test_secret123")
+	assert.NotContains(t, string(data), "sk_live_abc123")
+	assert.NotContains(t, string(data), "session=secret")
+	assert.Contains(t, string(data), "sk_live_"+Redacted)
+
+	// Replay, with the server gone and the parameters in another order.
+	recorder, err := NewRecorder(&RecorderConfig{Path: path})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(recorder.Cassette().Interactions))
+	c := customer.Client{B: recorder.Backend(), Key: "sk_test_other"}
+
+	params := &stripe.CustomerParams{
+		Name:  stripe.String("Jenny Rosen"),
+		Email: stripe.String("jenny@example.com"),
+	}
+	cus, err := c.New(params)
+	assert.NoError(t, err)
+	assert.Equal(t, "cus_123", cus.ID)
+	assert.Equal(t, "jenny@example.com", cus.Email)
+	assert.Equal(t, "req_1", cus.LastResponse.RequestID)
+	assert.Equal(t, 1, len(recorder.Unused()))
+
+	_, err = c.Get("cus_123", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(recorder.Unused()))
+
+	// Each interaction is only replayed once, and unknown requests fail.
+	_, err = c.Get("cus_123", nil)
+	assert.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "no recorded interaction"))
+}
+
+func TestRecorderFileUpload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "stripetest")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "file.json")
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseMultipartForm(1<<20))
+		fmt.Fprintf(w, `{"id":"file_123","object":"file","purpose":%q}`, r.FormValue("purpose"))
+	}))
+	newParams := func(contents string) *stripe.FileParams {
+		return &stripe.FileParams{
+			FileReader: strings.NewReader(contents),
+			Filename:   stripe.String("evidence.png"),
+			Purpose:    stripe.String(string(stripe.FilePurposeDisputeEvidence)),
+		}
+	}
+	png := "\x89PNG\r\n\x1a\n\xff"
+
+	// Record
+	{
+		recorder, err := NewRecorder(&RecorderConfig{Mode: ModeRecord, Path: path, URL: ts.URL})
+		assert.NoError(t, err)
+		c := file.Client{BUploads: recorder.Backend(), Key: "sk_test_123"}
+		_, err = c.New(newParams(png))
+		assert.NoError(t, err)
+		assert.NoError(t, recorder.Save())
+	}
+	ts.Close()
+
+	// Replay, with another random boundary.
+	recorder, err := NewRecorder(&RecorderConfig{Path: path})
+	assert.NoError(t, err)
+	assert.Contains(t, recorder.Cassette().Interactions[0].Request.Body, "sha256:")
+	c := file.Client{BUploads: recorder.Backend(), Key: "sk_test_123"}
+
+	f, err := c.New(newParams(png))
+	assert.NoError(t, err)
+	assert.Equal(t, "file_123", f.ID)
+	assert.Equal(t, stripe.FilePurposeDisputeEvidence, f.Purpose)
+	assert.Equal(t, 0, len(recorder.Unused()))
+
+	// Uploads of other contents don't match.
+	_, err = c.New(newParams("other contents"))
+	assert.Error(t, err)
+}
+
+func TestRecorderMissingFixture(t *testing.T) {
+	_, err := NewRecorder(&RecorderConfig{Path: "testdata/missing.json"})
+	assert.Error(t, err)
+
+	_, err = NewRecorder(&RecorderConfig{Mode: "bogus"})
+	assert.Error(t, err)
+}
+
+func TestNormalizeForm(t *testing.T) {
+	assert.Equal(t, "", normalizeForm(""))
+	assert.Equal(t, "a=1&b=2&expand%5B0%5D=x&expand%5B1%5D=y",
+		normalizeForm("expand[0]=x&b=2&expand[1]=y&a=1"))
+}
@@ -0,0 +1,331 @@
+package stripetest
+
+import (
+	"fmt"
+	"time"
+
+	stripe "github.com/stripe/stripe-go/v81"
+	"github.com/stripe/stripe-go/v81/customer"
+	"github.com/stripe/stripe-go/v81/event"
+	"github.com/stripe/stripe-go/v81/subscription"
+	"github.com/stripe/stripe-go/v81/testhelpers/testclock"
+)
+
+//
+// Public constants
+//
+
+// DefaultScenarioPollInterval is how often a Scenario checks whether its test
+// clock is done advancing when ScenarioConfig.PollInterval isn't set.
+const DefaultScenarioPollInterval = time.Second
+
+// DefaultScenarioTimeout is how long a Scenario waits for its test clock to
+// be done advancing when ScenarioConfig.Timeout isn't set.
+const DefaultScenarioTimeout = 2 * time.Minute
+
+//
+// Public types
+//
+
+// Scenario runs a lifecycle scenario, like a subscription going through a
+// trial and then failing to renew, on a test clock. It creates the clock,
+// attaches the customers it creates to it, advances it step by step while
+// waiting for it to be ready, and collects the events produced by each step
+// so that they can be checked.
+//
+// Only events about the scenario's customers, the objects belonging to them
+// and the clock itself are collected, so other activity on the account
+// doesn't interfere.
+type Scenario struct {
+	// Clock is the test clock of the scenario, as of the last step.
+	Clock *stripe.TestHelpersTestClock
+
+	// Steps are the steps run so far, in order.
+	Steps []*ScenarioStep
+
+	config      ScenarioConfig
+	customers   map[string]bool
+	eventCursor string
+}
+
+// ScenarioConfig is used to configure a Scenario.
+type ScenarioConfig struct {
+	// Backend is the backend used to make requests, like one pointing to
+	// stripe-mock.
+	//
+	// Defaults to the API backend.
+	Backend stripe.Backend
+
+	// FrozenTime is the time at which the clock starts.
+	//
+	// Defaults to the current time.
+	FrozenTime time.Time
+
+	// Key is the API key used to make requests.
+	//
+	// Defaults to stripe.Key.
+	Key string
+
+	// Name is the name of the test clock.
+	Name string
+
+	// PollInterval is how often the scenario checks whether its test clock is
+	// done advancing.
+	//
+	// Defaults to DefaultScenarioPollInterval.
+	PollInterval time.Duration
+
+	// Timeout is how long the scenario waits for its test clock to be done
+	// advancing before failing.
+	//
+	// Defaults to DefaultScenarioTimeout.
+	Timeout time.Duration
+}
+
+// ScenarioStep is a step of a Scenario.
+type ScenarioStep struct {
+	// Events are the events produced by the step, oldest first. They include
+	// the events produced by requests made since the previous step, like
+	// creating a subscription before advancing the clock.
+	Events []*stripe.Event
+
+	// FrozenTime is the time of the clock at the end of the step.
+	FrozenTime time.Time
+
+	// Name describes the step.
+	Name string
+}
+
+// EventTypes returns the types of the events of the step, in order.
+func (s *ScenarioStep) EventTypes() []stripe.EventType {
+	types := make([]stripe.EventType, len(s.Events))
+	for i, e := range s.Events {
+		types[i] = e.Type
+	}
+	return types
+}
+
+// EventsOfType returns the events of the step of the given type, in order.
+func (s *ScenarioStep) EventsOfType(eventType stripe.EventType) []*stripe.Event {
+	var events []*stripe.Event
+	for _, e := range s.Events {
+		if e.Type == eventType {
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+// NewScenario creates the test clock of a scenario.
+func NewScenario(config *ScenarioConfig) (*Scenario, error) {
+	s := &Scenario{customers: make(map[string]bool)}
+	if config != nil {
+		s.config = *config
+	}
+	if s.config.Backend == nil {
+		s.config.Backend = stripe.GetBackend(stripe.APIBackend)
+	}
+	if s.config.Key == "" {
+		s.config.Key = stripe.Key
+	}
+	if s.config.FrozenTime.IsZero() {
+		s.config.FrozenTime = time.Now()
+	}
+	if s.config.PollInterval <= 0 {
+		s.config.PollInterval = DefaultScenarioPollInterval
+	}
+	if s.config.Timeout <= 0 {
+		s.config.Timeout = DefaultScenarioTimeout
+	}
+
+	// Events are collected from the most recent one at the start of the
+	// scenario onwards.
+	params := &stripe.EventListParams{}
+	params.Limit = stripe.Int64(1)
+	params.Single = true
+	iter := s.eventClient().List(params)
+	if iter.Next() {
+		s.eventCursor = iter.Event().ID
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+
+	clockParams := &stripe.TestHelpersTestClockParams{
+		FrozenTime: stripe.Int64(s.config.FrozenTime.Unix()),
+	}
+	if s.config.Name != "" {
+		clockParams.Name = stripe.String(s.config.Name)
+	}
+	clock, err := s.clockClient().New(clockParams)
+	if err != nil {
+		return nil, err
+	}
+	s.Clock = clock
+
+	return s, nil
+}
+
+// Advance advances the clock by d and waits for it to be ready. It returns the
+// step, which is also added to Steps.
+func (s *Scenario) Advance(name string, d time.Duration) (*ScenarioStep, error) {
+	return s.AdvanceTo(name, time.Unix(s.Clock.FrozenTime, 0).Add(d))
+}
+
+// AdvanceTo advances the clock to t and waits for it to be ready. It returns
+// the step, which is also added to Steps.
+func (s *Scenario) AdvanceTo(name string, t time.Time) (*ScenarioStep, error) {
+	clock, err := s.clockClient().Advance(s.Clock.ID, &stripe.TestHelpersTestClockAdvanceParams{
+		FrozenTime: stripe.Int64(t.Unix()),
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.Clock = clock
+
+	deadline := time.Now().Add(s.config.Timeout)
+	for s.Clock.Status != stripe.TestHelpersTestClockStatusReady {
+		if s.Clock.Status == stripe.TestHelpersTestClockStatusInternalFailure {
+			return nil, fmt.Errorf("stripetest: test clock %s failed to advance to %v", s.Clock.ID, t)
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("stripetest: timed out waiting for test clock %s to advance to %v", s.Clock.ID, t)
+		}
+		time.Sleep(s.config.PollInterval)
+
+		if s.Clock, err = s.clockClient().Get(s.Clock.ID, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.step(name)
+}
+
+// Close deletes the test clock, along with the objects attached to it.
+func (s *Scenario) Close() error {
+	_, err := s.clockClient().Del(s.Clock.ID, nil)
+	return err
+}
+
+// Do runs fn as a step of the scenario, like updating a customer's payment
+// method to one that fails. It returns the step, which is also added to
+// Steps.
+func (s *Scenario) Do(name string, fn func() error) (*ScenarioStep, error) {
+	if err := fn(); err != nil {
+		return nil, err
+	}
+	return s.step(name)
+}
+
+// Events returns the events of all the steps so far, oldest first.
+func (s *Scenario) Events() []*stripe.Event {
+	var events []*stripe.Event
+	for _, step := range s.Steps {
+		events = append(events, step.Events...)
+	}
+	return events
+}
+
+// NewCustomer creates a customer attached to the test clock. Subscriptions
+// and invoices of the customer then follow the clock.
+func (s *Scenario) NewCustomer(params *stripe.CustomerParams) (*stripe.Customer, error) {
+	if params == nil {
+		params = &stripe.CustomerParams{}
+	}
+	params.TestClock = stripe.String(s.Clock.ID)
+
+	c := customer.Client{B: s.config.Backend, Key: s.config.Key}
+	cus, err := c.New(params)
+	if err != nil {
+		return nil, err
+	}
+	s.customers[cus.ID] = true
+	return cus, nil
+}
+
+// NewSubscription creates a subscription for one of the scenario's customers.
+func (s *Scenario) NewSubscription(params *stripe.SubscriptionParams) (*stripe.Subscription, error) {
+	if params == nil || params.Customer == nil || !s.customers[*params.Customer] {
+		return nil, fmt.Errorf("stripetest: subscriptions must be created for a customer of the scenario")
+	}
+
+	c := subscription.Client{B: s.config.Backend, Key: s.config.Key}
+	return c.New(params)
+}
+
+//
+// Private functions
+//
+
+func (s *Scenario) clockClient() testclock.Client {
+	return testclock.Client{B: s.config.Backend, Key: s.config.Key}
+}
+
+func (s *Scenario) eventClient() event.Client {
+	return event.Client{B: s.config.Backend, Key: s.config.Key}
+}
+
+// isScenarioEvent returns whether the event is about the clock, one of the
+// scenario's customers, or an object belonging to one of them.
+func (s *Scenario) isScenarioEvent(e *stripe.Event) bool {
+	if e.Data == nil {
+		return false
+	}
+	id := e.GetObjectValue("id")
+	if id == s.Clock.ID || s.customers[id] {
+		return true
+	}
+	return s.customers[e.GetObjectValue("customer")]
+}
+
+// newEvents returns the events of the scenario produced since the previous
+// call, oldest first.
+func (s *Scenario) newEvents() ([]*stripe.Event, error) {
+	params := &stripe.EventListParams{}
+	params.Limit = stripe.Int64(100)
+	if s.eventCursor != "" {
+		params.EndingBefore = stripe.String(s.eventCursor)
+	}
+
+	// When paginating backwards with EndingBefore, the iterator returns events
+	// oldest first. Otherwise there were no events before the scenario
+	// started and they need to be reversed.
+	var all []*stripe.Event
+	iter := s.eventClient().List(params)
+	for iter.Next() {
+		all = append(all, iter.Event())
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+	if s.eventCursor == "" {
+		for i, j := 0, len(all)-1; i < j; i, j = i+1, j-1 {
+			all[i], all[j] = all[j], all[i]
+		}
+	}
+	if len(all) > 0 {
+		s.eventCursor = all[len(all)-1].ID
+	}
+
+	var events []*stripe.Event
+	for _, e := range all {
+		if s.isScenarioEvent(e) {
+			events = append(events, e)
+		}
+	}
+	return events, nil
+}
+
+func (s *Scenario) step(name string) (*ScenarioStep, error) {
+	events, err := s.newEvents()
+	if err != nil {
+		return nil, err
+	}
+	step := &ScenarioStep{
+		Events:     events,
+		FrozenTime: time.Unix(s.Clock.FrozenTime, 0),
+		Name:       name,
+	}
+	s.Steps = append(s.Steps, step)
+	return step, nil
+}
@@ -0,0 +1,132 @@
+package stripetest
+
+import (
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+	stripe "github.com/stripe/stripe-go/v81"
+	"github.com/stripe/stripe-go/v81/customer"
+	"github.com/stripe/stripe-go/v81/price"
+)
+
+func TestScenarioSubscriptionLifecycle(t *testing.T) {
+	f := NewFakeBackend()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Activity outside of the scenario isn't collected.
+	customers := customer.Client{B: f, Key: "sk_test_123"}
+	_, err := customers.New(&stripe.CustomerParams{})
+	assert.NoError(t, err)
+
+	s, err := NewScenario(&ScenarioConfig{
+		Backend:      f,
+		FrozenTime:   start,
+		Key:          "sk_test_123",
+		Name:         "Failed renewal",
+		PollInterval: time.Millisecond,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, start.Unix(), s.Clock.FrozenTime)
+
+	prices := price.Client{B: f, Key: "sk_test_123"}
+	p, err := prices.New(&stripe.PriceParams{
+		Currency:    stripe.String(string(stripe.CurrencyUSD)),
+		UnitAmount:  stripe.Int64(1000),
+		ProductData: &stripe.PriceProductDataParams{Name: stripe.String("Gold")},
+		Recurring: &stripe.PriceRecurringParams{
+			Interval: stripe.String(string(stripe.PriceRecurringIntervalMonth)),
+		},
+	})
+	assert.NoError(t, err)
+
+	cus, err := s.NewCustomer(&stripe.CustomerParams{
+		InvoiceSettings: &stripe.CustomerInvoiceSettingsParams{
+			DefaultPaymentMethod: stripe.String("pm_card_visa"),
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, s.Clock.ID, cus.TestClock.ID)
+
+	sub, err := s.NewSubscription(&stripe.SubscriptionParams{
+		Customer: stripe.String(cus.ID),
+		Items: []*stripe.SubscriptionItemsParams{
+			{Price: stripe.String(p.ID)},
+		},
+		TrialPeriodDays: stripe.Int64(14),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, stripe.SubscriptionStatusTrialing, sub.Status)
+	assert.Equal(t, start.Unix(), sub.CurrentPeriodStart)
+
+	step, err := s.Advance("Trial ends", 15*24*time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, start.AddDate(0, 0, 15).Unix(), step.FrozenTime.Unix())
+	assert.Equal(t, stripe.EventTypeTestHelpersTestClockCreated, step.Events[0].Type)
+	assert.Equal(t, 1, len(step.EventsOfType(stripe.EventTypeCustomerSubscriptionTrialWillEnd)))
+	// Both the invoice of the trial and the one of the first renewal are paid.
+	assert.Equal(t, 2, len(step.EventsOfType(stripe.EventTypeInvoicePaymentSucceeded)))
+	updated := step.EventsOfType(stripe.EventTypeCustomerSubscriptionUpdated)
+	assert.Equal(t, 1, len(updated))
+	assert.Equal(t, string(stripe.SubscriptionStatusActive), updated[0].GetObjectValue("status"))
+	types := step.EventTypes()
+	assert.Equal(t, stripe.EventTypeTestHelpersTestClockReady, types[len(types)-1])
+
+	step, err = s.Do("Card starts failing", func() error {
+		_, err := customers.Update(cus.ID, &stripe.CustomerParams{
+			InvoiceSettings: &stripe.CustomerInvoiceSettingsParams{
+				DefaultPaymentMethod: stripe.String("pm_card_chargeDeclined"),
+			},
+		})
+		return err
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []stripe.EventType{
+		stripe.EventTypePaymentMethodAttached,
+		stripe.EventTypeCustomerUpdated,
+	}, step.EventTypes())
+
+	step, err = s.Advance("Renewal fails", 30*24*time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(step.EventsOfType(stripe.EventTypeInvoicePaymentFailed)))
+	updated = step.EventsOfType(stripe.EventTypeCustomerSubscriptionUpdated)
+	assert.Equal(t, 1, len(updated))
+	assert.Equal(t, string(stripe.SubscriptionStatusPastDue), updated[0].GetObjectValue("status"))
+
+	step, err = s.Advance("Retries are exhausted", 10*24*time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(step.EventsOfType(stripe.EventTypeInvoicePaymentFailed)))
+	deleted := step.EventsOfType(stripe.EventTypeCustomerSubscriptionDeleted)
+	assert.Equal(t, 1, len(deleted))
+	assert.Equal(t, sub.ID, deleted[0].GetObjectValue("id"))
+
+	assert.Equal(t, 4, len(s.Steps))
+	total := 0
+	for _, step := range s.Steps {
+		total += len(step.Events)
+	}
+	assert.Equal(t, total, len(s.Events()))
+
+	assert.NoError(t, s.Close())
+	_, err = customers.Get(cus.ID, nil)
+	assert.Error(t, err)
+}
+
+func TestScenarioNewSubscriptionOtherCustomer(t *testing.T) {
+	f := NewFakeBackend()
+	s, err := NewScenario(&ScenarioConfig{Backend: f, Key: "sk_test_123"})
+	assert.NoError(t, err)
+
+	_, err = s.NewSubscription(&stripe.SubscriptionParams{Customer: stripe.String("cus_123")})
+	assert.Error(t, err)
+}
+
+func TestScenarioAdvanceBackwards(t *testing.T) {
+	f := NewFakeBackend()
+	s, err := NewScenario(&ScenarioConfig{Backend: f, Key: "sk_test_123"})
+	assert.NoError(t, err)
+
+	_, err = s.Advance("Backwards", -time.Hour)
+	assert.Error(t, err)
+	assert.Empty(t, s.Steps)
+}
@@ -394,7 +394,7 @@ type TaxCalculationShippingCostTaxBreakdownTaxRateDetails struct {
 	// A localized display name for tax type, intended to be human-readable. For example, "Local Sales and Use Tax", "Value-added tax (VAT)", or "Umsatzsteuer (USt.)".
 	DisplayName string `json:"display_name"`
 	// The tax rate percentage as a string. For example, 8.5% is represented as "8.5".
-	PercentageDecimal string `json:"percentage_decimal"`
+	PercentageDecimal Decimal `json:"percentage_decimal"`
 	// The tax type, such as `vat` or `sales_tax`.
 	TaxType TaxCalculationShippingCostTaxBreakdownTaxRateDetailsTaxType `json:"tax_type"`
 }
@@ -443,7 +443,7 @@ type TaxCalculationTaxBreakdownTaxRateDetails struct {
 	// The amount of the tax rate when the `rate_type` is `flat_amount`. Tax rates with `rate_type` `percentage` can vary based on the transaction, resulting in this field being `null`. This field exposes the amount and currency of the flat tax rate.
 	FlatAmount *TaxCalculationTaxBreakdownTaxRateDetailsFlatAmount `json:"flat_amount"`
 	// The tax rate percentage as a string. For example, 8.5% is represented as `"8.5"`.
-	PercentageDecimal string `json:"percentage_decimal"`
+	PercentageDecimal Decimal `json:"percentage_decimal"`
 	// Indicates the type of tax rate applied to the taxable amount. This value can be `null` when no tax applies to the location.
 	RateType TaxCalculationTaxBreakdownTaxRateDetailsRateType `json:"rate_type"`
 	// State, county, province, or region.
@@ -95,7 +95,7 @@ type TaxCalculationLineItemTaxBreakdownTaxRateDetails struct {
 	// A localized display name for tax type, intended to be human-readable. For example, "Local Sales and Use Tax", "Value-added tax (VAT)", or "Umsatzsteuer (USt.)".
 	DisplayName string `json:"display_name"`
 	// The tax rate percentage as a string. For example, 8.5% is represented as "8.5".
-	PercentageDecimal string `json:"percentage_decimal"`
+	PercentageDecimal Decimal `json:"percentage_decimal"`
 	// The tax type, such as `vat` or `sales_tax`.
 	TaxType TaxCalculationLineItemTaxBreakdownTaxRateDetailsTaxType `json:"tax_type"`
 }
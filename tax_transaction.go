@@ -248,7 +248,7 @@ type TaxTransactionCreateFromCalculationParams struct {
 	// Specifies which fields in the response should be expanded.
 	Expand []*string `form:"expand"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format. Individual keys can be unset by posting an empty value to them. All keys can be unset by posting an empty value to `metadata`.
-	Metadata map[string]string `form:"metadata"`
+	Metadata Metadata `form:"metadata"`
 	// The Unix timestamp representing when the tax liability is assumed or reduced, which determines the liability posting period and handling in tax liability reports. The timestamp must fall within the `tax_date` and the current time, unless the `tax_date` is scheduled in advance. Defaults to the current time.
 	PostedAt *int64 `form:"posted_at"`
 	// A custom order or sale identifier, such as 'myOrder_123'. Must be unique across all transactions, including reversals.
@@ -263,7 +263,7 @@ func (p *TaxTransactionCreateFromCalculationParams) AddExpand(f string) {
 // AddMetadata adds a new key-value pair to the Metadata.
 func (p *TaxTransactionCreateFromCalculationParams) AddMetadata(key string, value string) {
 	if p.Metadata == nil {
-		p.Metadata = make(map[string]string)
+		p.Metadata = make(Metadata)
 	}
 
 	p.Metadata[key] = value
@@ -276,7 +276,7 @@ type TaxTransactionCreateReversalLineItemParams struct {
 	// The amount of tax to reverse, in the [smallest currency unit](https://stripe.com/docs/currencies#zero-decimal) in negative.
 	AmountTax *int64 `form:"amount_tax"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format.
-	Metadata map[string]string `form:"metadata"`
+	Metadata Metadata `form:"metadata"`
 	// The `id` of the line item to reverse in the original transaction.
 	OriginalLineItem *string `form:"original_line_item"`
 	// The quantity reversed. Appears in [tax exports](https://stripe.com/docs/tax/reports), but does not affect the amount of tax reversed.
@@ -288,7 +288,7 @@ type TaxTransactionCreateReversalLineItemParams struct {
 // AddMetadata adds a new key-value pair to the Metadata.
 func (p *TaxTransactionCreateReversalLineItemParams) AddMetadata(key string, value string) {
 	if p.Metadata == nil {
-		p.Metadata = make(map[string]string)
+		p.Metadata = make(Metadata)
 	}
 
 	p.Metadata[key] = value
@@ -312,7 +312,7 @@ type TaxTransactionCreateReversalParams struct {
 	// The line item amounts to reverse.
 	LineItems []*TaxTransactionCreateReversalLineItemParams `form:"line_items"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format. Individual keys can be unset by posting an empty value to them. All keys can be unset by posting an empty value to `metadata`.
-	Metadata map[string]string `form:"metadata"`
+	Metadata Metadata `form:"metadata"`
 	// If `partial`, the provided line item or shipping cost amounts are reversed. If `full`, the original transaction is fully reversed.
 	Mode *string `form:"mode"`
 	// The ID of the Transaction to partially or fully reverse.
@@ -331,7 +331,7 @@ func (p *TaxTransactionCreateReversalParams) AddExpand(f string) {
 // AddMetadata adds a new key-value pair to the Metadata.
 func (p *TaxTransactionCreateReversalParams) AddMetadata(key string, value string) {
 	if p.Metadata == nil {
-		p.Metadata = make(map[string]string)
+		p.Metadata = make(Metadata)
 	}
 
 	p.Metadata[key] = value
@@ -383,7 +383,7 @@ type TaxTransactionShippingCostTaxBreakdownTaxRateDetails struct {
 	// A localized display name for tax type, intended to be human-readable. For example, "Local Sales and Use Tax", "Value-added tax (VAT)", or "Umsatzsteuer (USt.)".
 	DisplayName string `json:"display_name"`
 	// The tax rate percentage as a string. For example, 8.5% is represented as "8.5".
-	PercentageDecimal string `json:"percentage_decimal"`
+	PercentageDecimal Decimal `json:"percentage_decimal"`
 	// The tax type, such as `vat` or `sales_tax`.
 	TaxType TaxTransactionShippingCostTaxBreakdownTaxRateDetailsTaxType `json:"tax_type"`
 }
@@ -438,7 +438,7 @@ type TaxTransaction struct {
 	// Has the value `true` if the object exists in live mode or the value `false` if the object exists in test mode.
 	Livemode bool `json:"livemode"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format.
-	Metadata map[string]string `json:"metadata"`
+	Metadata Metadata `json:"metadata"`
 	// String representing the object's type. Objects of the same type share the same value.
 	Object string `json:"object"`
 	// The Unix timestamp representing when the tax liability is assumed or reduced.
@@ -79,7 +79,7 @@ type TerminalReaderParams struct {
 	// The location to assign the reader to.
 	Location *string `form:"location"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format. Individual keys can be unset by posting an empty value to them. All keys can be unset by posting an empty value to `metadata`.
-	Metadata map[string]string `form:"metadata"`
+	Metadata Metadata `form:"metadata"`
 	// A code generated by the reader used for registering to an account.
 	RegistrationCode *string `form:"registration_code"`
 }
@@ -92,7 +92,7 @@ func (p *TerminalReaderParams) AddExpand(f string) {
 // AddMetadata adds a new key-value pair to the Metadata.
 func (p *TerminalReaderParams) AddMetadata(key string, value string) {
 	if p.Metadata == nil {
-		p.Metadata = make(map[string]string)
+		p.Metadata = make(Metadata)
 	}
 
 	p.Metadata[key] = value
@@ -204,7 +204,7 @@ type TerminalReaderRefundPaymentParams struct {
 	// Specifies which fields in the response should be expanded.
 	Expand []*string `form:"expand"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format. Individual keys can be unset by posting an empty value to them. All keys can be unset by posting an empty value to `metadata`.
-	Metadata map[string]string `form:"metadata"`
+	Metadata Metadata `form:"metadata"`
 	// ID of the PaymentIntent to refund.
 	PaymentIntent *string `form:"payment_intent"`
 	// Boolean indicating whether the application fee should be refunded when refunding this charge. If a full charge refund is given, the full application fee will be refunded. Otherwise, the application fee will be refunded in an amount proportional to the amount of the charge refunded. An application fee can be refunded only by the application that created the charge.
@@ -223,7 +223,7 @@ func (p *TerminalReaderRefundPaymentParams) AddExpand(f string) {
 // AddMetadata adds a new key-value pair to the Metadata.
 func (p *TerminalReaderRefundPaymentParams) AddMetadata(key string, value string) {
 	if p.Metadata == nil {
-		p.Metadata = make(map[string]string)
+		p.Metadata = make(Metadata)
 	}
 
 	p.Metadata[key] = value
@@ -320,7 +320,7 @@ type TerminalReaderActionRefundPayment struct {
 	// Charge that is being refunded.
 	Charge *Charge `json:"charge"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format.
-	Metadata map[string]string `json:"metadata"`
+	Metadata Metadata `json:"metadata"`
 	// Payment intent that is being refunded.
 	PaymentIntent *PaymentIntent `json:"payment_intent"`
 	// The reason for the refund.
@@ -408,7 +408,7 @@ type TerminalReader struct {
 	// The location identifier of the reader.
 	Location *TerminalLocation `json:"location"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format.
-	Metadata map[string]string `json:"metadata"`
+	Metadata Metadata `json:"metadata"`
 	// String representing the object's type. Objects of the same type share the same value.
 	Object string `json:"object"`
 	// Serial number of the reader.
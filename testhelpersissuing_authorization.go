@@ -31,21 +31,21 @@ type TestHelpersIssuingAuthorizationFleetCardholderPromptDataParams struct {
 // Breakdown of fuel portion of the purchase.
 type TestHelpersIssuingAuthorizationFleetReportedBreakdownFuelParams struct {
 	// Gross fuel amount that should equal Fuel Volume multipled by Fuel Unit Cost, inclusive of taxes.
-	GrossAmountDecimal *float64 `form:"gross_amount_decimal,high_precision"`
+	GrossAmountDecimal *Decimal `form:"gross_amount_decimal"`
 }
 
 // Breakdown of non-fuel portion of the purchase.
 type TestHelpersIssuingAuthorizationFleetReportedBreakdownNonFuelParams struct {
 	// Gross non-fuel amount that should equal the sum of the line items, inclusive of taxes.
-	GrossAmountDecimal *float64 `form:"gross_amount_decimal,high_precision"`
+	GrossAmountDecimal *Decimal `form:"gross_amount_decimal"`
 }
 
 // Information about tax included in this transaction.
 type TestHelpersIssuingAuthorizationFleetReportedBreakdownTaxParams struct {
 	// Amount of state or provincial Sales Tax included in the transaction amount. Null if not reported by merchant or not subject to tax.
-	LocalAmountDecimal *float64 `form:"local_amount_decimal,high_precision"`
+	LocalAmountDecimal *Decimal `form:"local_amount_decimal"`
 	// Amount of national Sales Tax or VAT included in the transaction amount. Null if not reported by merchant or not subject to tax.
-	NationalAmountDecimal *float64 `form:"national_amount_decimal,high_precision"`
+	NationalAmountDecimal *Decimal `form:"national_amount_decimal"`
 }
 
 // More information about the total amount. This information is not guaranteed to be accurate as some merchants may provide unreliable data.
@@ -75,13 +75,13 @@ type TestHelpersIssuingAuthorizationFuelParams struct {
 	// [Conexxus Payment System Product Code](https://www.conexxus.org/conexxus-payment-system-product-codes) identifying the primary fuel product purchased.
 	IndustryProductCode *string `form:"industry_product_code"`
 	// The quantity of `unit`s of fuel that was dispensed, represented as a decimal string with at most 12 decimal places.
-	QuantityDecimal *float64 `form:"quantity_decimal,high_precision"`
+	QuantityDecimal *Decimal `form:"quantity_decimal"`
 	// The type of fuel that was purchased. One of `diesel`, `unleaded_plus`, `unleaded_regular`, `unleaded_super`, or `other`.
 	Type *string `form:"type"`
 	// The units for `quantity_decimal`. One of `charging_minute`, `imperial_gallon`, `kilogram`, `kilowatt_hour`, `liter`, `pound`, `us_gallon`, or `other`.
 	Unit *string `form:"unit"`
 	// The cost in cents per each unit of fuel, represented as a decimal string with at most 12 decimal places.
-	UnitCostDecimal *float64 `form:"unit_cost_decimal,high_precision"`
+	UnitCostDecimal *Decimal `form:"unit_cost_decimal"`
 }
 
 // Details about the seller (grocery store, e-commerce website, etc.) where the card authorization happened.
@@ -199,21 +199,21 @@ type TestHelpersIssuingAuthorizationCapturePurchaseDetailsFleetCardholderPromptD
 // Breakdown of fuel portion of the purchase.
 type TestHelpersIssuingAuthorizationCapturePurchaseDetailsFleetReportedBreakdownFuelParams struct {
 	// Gross fuel amount that should equal Fuel Volume multipled by Fuel Unit Cost, inclusive of taxes.
-	GrossAmountDecimal *float64 `form:"gross_amount_decimal,high_precision"`
+	GrossAmountDecimal *Decimal `form:"gross_amount_decimal"`
 }
 
 // Breakdown of non-fuel portion of the purchase.
 type TestHelpersIssuingAuthorizationCapturePurchaseDetailsFleetReportedBreakdownNonFuelParams struct {
 	// Gross non-fuel amount that should equal the sum of the line items, inclusive of taxes.
-	GrossAmountDecimal *float64 `form:"gross_amount_decimal,high_precision"`
+	GrossAmountDecimal *Decimal `form:"gross_amount_decimal"`
 }
 
 // Information about tax included in this transaction.
 type TestHelpersIssuingAuthorizationCapturePurchaseDetailsFleetReportedBreakdownTaxParams struct {
 	// Amount of state or provincial Sales Tax included in the transaction amount. Null if not reported by merchant or not subject to tax.
-	LocalAmountDecimal *float64 `form:"local_amount_decimal,high_precision"`
+	LocalAmountDecimal *Decimal `form:"local_amount_decimal"`
 	// Amount of national Sales Tax or VAT included in the transaction amount. Null if not reported by merchant or not subject to tax.
-	NationalAmountDecimal *float64 `form:"national_amount_decimal,high_precision"`
+	NationalAmountDecimal *Decimal `form:"national_amount_decimal"`
 }
 
 // More information about the total amount. This information is not guaranteed to be accurate as some merchants may provide unreliable data.
@@ -273,13 +273,13 @@ type TestHelpersIssuingAuthorizationCapturePurchaseDetailsFuelParams struct {
 	// [Conexxus Payment System Product Code](https://www.conexxus.org/conexxus-payment-system-product-codes) identifying the primary fuel product purchased.
 	IndustryProductCode *string `form:"industry_product_code"`
 	// The quantity of `unit`s of fuel that was dispensed, represented as a decimal string with at most 12 decimal places.
-	QuantityDecimal *float64 `form:"quantity_decimal,high_precision"`
+	QuantityDecimal *Decimal `form:"quantity_decimal"`
 	// The type of fuel that was purchased. One of `diesel`, `unleaded_plus`, `unleaded_regular`, `unleaded_super`, or `other`.
 	Type *string `form:"type"`
 	// The units for `quantity_decimal`. One of `charging_minute`, `imperial_gallon`, `kilogram`, `kilowatt_hour`, `liter`, `pound`, `us_gallon`, or `other`.
 	Unit *string `form:"unit"`
 	// The cost in cents per each unit of fuel, represented as a decimal string with at most 12 decimal places.
-	UnitCostDecimal *float64 `form:"unit_cost_decimal,high_precision"`
+	UnitCostDecimal *Decimal `form:"unit_cost_decimal"`
 }
 
 // Information about lodging that was purchased with this transaction.
@@ -361,21 +361,21 @@ type TestHelpersIssuingAuthorizationFinalizeAmountFleetCardholderPromptDataParam
 // Breakdown of fuel portion of the purchase.
 type TestHelpersIssuingAuthorizationFinalizeAmountFleetReportedBreakdownFuelParams struct {
 	// Gross fuel amount that should equal Fuel Volume multipled by Fuel Unit Cost, inclusive of taxes.
-	GrossAmountDecimal *float64 `form:"gross_amount_decimal,high_precision"`
+	GrossAmountDecimal *Decimal `form:"gross_amount_decimal"`
 }
 
 // Breakdown of non-fuel portion of the purchase.
 type TestHelpersIssuingAuthorizationFinalizeAmountFleetReportedBreakdownNonFuelParams struct {
 	// Gross non-fuel amount that should equal the sum of the line items, inclusive of taxes.
-	GrossAmountDecimal *float64 `form:"gross_amount_decimal,high_precision"`
+	GrossAmountDecimal *Decimal `form:"gross_amount_decimal"`
 }
 
 // Information about tax included in this transaction.
 type TestHelpersIssuingAuthorizationFinalizeAmountFleetReportedBreakdownTaxParams struct {
 	// Amount of state or provincial Sales Tax included in the transaction amount. Null if not reported by merchant or not subject to tax.
-	LocalAmountDecimal *float64 `form:"local_amount_decimal,high_precision"`
+	LocalAmountDecimal *Decimal `form:"local_amount_decimal"`
 	// Amount of national Sales Tax or VAT included in the transaction amount. Null if not reported by merchant or not subject to tax.
-	NationalAmountDecimal *float64 `form:"national_amount_decimal,high_precision"`
+	NationalAmountDecimal *Decimal `form:"national_amount_decimal"`
 }
 
 // More information about the total amount. This information is not guaranteed to be accurate as some merchants may provide unreliable data.
@@ -405,13 +405,13 @@ type TestHelpersIssuingAuthorizationFinalizeAmountFuelParams struct {
 	// [Conexxus Payment System Product Code](https://www.conexxus.org/conexxus-payment-system-product-codes) identifying the primary fuel product purchased.
 	IndustryProductCode *string `form:"industry_product_code"`
 	// The quantity of `unit`s of fuel that was dispensed, represented as a decimal string with at most 12 decimal places.
-	QuantityDecimal *float64 `form:"quantity_decimal,high_precision"`
+	QuantityDecimal *Decimal `form:"quantity_decimal"`
 	// The type of fuel that was purchased. One of `diesel`, `unleaded_plus`, `unleaded_regular`, `unleaded_super`, or `other`.
 	Type *string `form:"type"`
 	// The units for `quantity_decimal`. One of `charging_minute`, `imperial_gallon`, `kilogram`, `kilowatt_hour`, `liter`, `pound`, `us_gallon`, or `other`.
 	Unit *string `form:"unit"`
 	// The cost in cents per each unit of fuel, represented as a decimal string with at most 12 decimal places.
-	UnitCostDecimal *float64 `form:"unit_cost_decimal,high_precision"`
+	UnitCostDecimal *Decimal `form:"unit_cost_decimal"`
 }
 
 // Finalize the amount on an Authorization prior to capture, when the initial authorization was for an estimated amount.
@@ -59,21 +59,21 @@ type TestHelpersIssuingTransactionCreateForceCapturePurchaseDetailsFleetCardhold
 // Breakdown of fuel portion of the purchase.
 type TestHelpersIssuingTransactionCreateForceCapturePurchaseDetailsFleetReportedBreakdownFuelParams struct {
 	// Gross fuel amount that should equal Fuel Volume multipled by Fuel Unit Cost, inclusive of taxes.
-	GrossAmountDecimal *float64 `form:"gross_amount_decimal,high_precision"`
+	GrossAmountDecimal *Decimal `form:"gross_amount_decimal"`
 }
 
 // Breakdown of non-fuel portion of the purchase.
 type TestHelpersIssuingTransactionCreateForceCapturePurchaseDetailsFleetReportedBreakdownNonFuelParams struct {
 	// Gross non-fuel amount that should equal the sum of the line items, inclusive of taxes.
-	GrossAmountDecimal *float64 `form:"gross_amount_decimal,high_precision"`
+	GrossAmountDecimal *Decimal `form:"gross_amount_decimal"`
 }
 
 // Information about tax included in this transaction.
 type TestHelpersIssuingTransactionCreateForceCapturePurchaseDetailsFleetReportedBreakdownTaxParams struct {
 	// Amount of state or provincial Sales Tax included in the transaction amount. Null if not reported by merchant or not subject to tax.
-	LocalAmountDecimal *float64 `form:"local_amount_decimal,high_precision"`
+	LocalAmountDecimal *Decimal `form:"local_amount_decimal"`
 	// Amount of national Sales Tax or VAT included in the transaction amount. Null if not reported by merchant or not subject to tax.
-	NationalAmountDecimal *float64 `form:"national_amount_decimal,high_precision"`
+	NationalAmountDecimal *Decimal `form:"national_amount_decimal"`
 }
 
 // More information about the total amount. This information is not guaranteed to be accurate as some merchants may provide unreliable data.
@@ -133,13 +133,13 @@ type TestHelpersIssuingTransactionCreateForceCapturePurchaseDetailsFuelParams st
 	// [Conexxus Payment System Product Code](https://www.conexxus.org/conexxus-payment-system-product-codes) identifying the primary fuel product purchased.
 	IndustryProductCode *string `form:"industry_product_code"`
 	// The quantity of `unit`s of fuel that was dispensed, represented as a decimal string with at most 12 decimal places.
-	QuantityDecimal *float64 `form:"quantity_decimal,high_precision"`
+	QuantityDecimal *Decimal `form:"quantity_decimal"`
 	// The type of fuel that was purchased. One of `diesel`, `unleaded_plus`, `unleaded_regular`, `unleaded_super`, or `other`.
 	Type *string `form:"type"`
 	// The units for `quantity_decimal`. One of `charging_minute`, `imperial_gallon`, `kilogram`, `kilowatt_hour`, `liter`, `pound`, `us_gallon`, or `other`.
 	Unit *string `form:"unit"`
 	// The cost in cents per each unit of fuel, represented as a decimal string with at most 12 decimal places.
-	UnitCostDecimal *float64 `form:"unit_cost_decimal,high_precision"`
+	UnitCostDecimal *Decimal `form:"unit_cost_decimal"`
 }
 
 // Information about lodging that was purchased with this transaction.
@@ -235,21 +235,21 @@ type TestHelpersIssuingTransactionCreateUnlinkedRefundPurchaseDetailsFleetCardho
 // Breakdown of fuel portion of the purchase.
 type TestHelpersIssuingTransactionCreateUnlinkedRefundPurchaseDetailsFleetReportedBreakdownFuelParams struct {
 	// Gross fuel amount that should equal Fuel Volume multipled by Fuel Unit Cost, inclusive of taxes.
-	GrossAmountDecimal *float64 `form:"gross_amount_decimal,high_precision"`
+	GrossAmountDecimal *Decimal `form:"gross_amount_decimal"`
 }
 
 // Breakdown of non-fuel portion of the purchase.
 type TestHelpersIssuingTransactionCreateUnlinkedRefundPurchaseDetailsFleetReportedBreakdownNonFuelParams struct {
 	// Gross non-fuel amount that should equal the sum of the line items, inclusive of taxes.
-	GrossAmountDecimal *float64 `form:"gross_amount_decimal,high_precision"`
+	GrossAmountDecimal *Decimal `form:"gross_amount_decimal"`
 }
 
 // Information about tax included in this transaction.
 type TestHelpersIssuingTransactionCreateUnlinkedRefundPurchaseDetailsFleetReportedBreakdownTaxParams struct {
 	// Amount of state or provincial Sales Tax included in the transaction amount. Null if not reported by merchant or not subject to tax.
-	LocalAmountDecimal *float64 `form:"local_amount_decimal,high_precision"`
+	LocalAmountDecimal *Decimal `form:"local_amount_decimal"`
 	// Amount of national Sales Tax or VAT included in the transaction amount. Null if not reported by merchant or not subject to tax.
-	NationalAmountDecimal *float64 `form:"national_amount_decimal,high_precision"`
+	NationalAmountDecimal *Decimal `form:"national_amount_decimal"`
 }
 
 // More information about the total amount. This information is not guaranteed to be accurate as some merchants may provide unreliable data.
@@ -309,13 +309,13 @@ type TestHelpersIssuingTransactionCreateUnlinkedRefundPurchaseDetailsFuelParams
 	// [Conexxus Payment System Product Code](https://www.conexxus.org/conexxus-payment-system-product-codes) identifying the primary fuel product purchased.
 	IndustryProductCode *string `form:"industry_product_code"`
 	// The quantity of `unit`s of fuel that was dispensed, represented as a decimal string with at most 12 decimal places.
-	QuantityDecimal *float64 `form:"quantity_decimal,high_precision"`
+	QuantityDecimal *Decimal `form:"quantity_decimal"`
 	// The type of fuel that was purchased. One of `diesel`, `unleaded_plus`, `unleaded_regular`, `unleaded_super`, or `other`.
 	Type *string `form:"type"`
 	// The units for `quantity_decimal`. One of `charging_minute`, `imperial_gallon`, `kilogram`, `kilowatt_hour`, `liter`, `pound`, `us_gallon`, or `other`.
 	Unit *string `form:"unit"`
 	// The cost in cents per each unit of fuel, represented as a decimal string with at most 12 decimal places.
-	UnitCostDecimal *float64 `form:"unit_cost_decimal,high_precision"`
+	UnitCostDecimal *Decimal `form:"unit_cost_decimal"`
 }
 
 // Information about lodging that was purchased with this transaction.
@@ -248,7 +248,7 @@ type TreasuryFinancialAccountParams struct {
 	// A different bank account where funds can be deposited/debited in order to get the closing FA's balance to $0
 	ForwardingSettings *TreasuryFinancialAccountForwardingSettingsParams `form:"forwarding_settings"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format. Individual keys can be unset by posting an empty value to them. All keys can be unset by posting an empty value to `metadata`.
-	Metadata map[string]string `form:"metadata"`
+	Metadata Metadata `form:"metadata"`
 	// The nickname for the FinancialAccount.
 	Nickname *string `form:"nickname"`
 	// The set of functionalities that the platform can restrict on the FinancialAccount.
@@ -265,7 +265,7 @@ func (p *TreasuryFinancialAccountParams) AddExpand(f string) {
 // AddMetadata adds a new key-value pair to the Metadata.
 func (p *TreasuryFinancialAccountParams) AddMetadata(key string, value string) {
 	if p.Metadata == nil {
-		p.Metadata = make(map[string]string)
+		p.Metadata = make(Metadata)
 	}
 
 	p.Metadata[key] = value
@@ -500,7 +500,7 @@ type TreasuryFinancialAccount struct {
 	// Has the value `true` if the object exists in live mode or the value `false` if the object exists in test mode.
 	Livemode bool `json:"livemode"`
 	// Set of [key-value pairs](https://stripe.com/docs/api/metadata) that you can attach to an object. This can be useful for storing additional information about the object in a structured format.
-	Metadata map[string]string `json:"metadata"`
+	Metadata Metadata `json:"metadata"`
 	// The nickname for the FinancialAccount.
 	Nickname string `json:"nickname"`
 	// String representing the object's type. Objects of the same type share the same value.
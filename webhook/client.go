@@ -30,6 +30,7 @@ const (
 //
 
 // This block represents the list of errors that could be raised when using the webhook package.
+// ValidatePayloadWithOptions wraps them in a *SignatureVerificationError.
 var (
 	ErrInvalidHeader    = errors.New("webhook has invalid Stripe-Signature header")
 	ErrNoValidSignature = errors.New("webhook had no valid signature")
@@ -57,7 +58,7 @@ func ComputeSignature(t time.Time, payload []byte, secret string) []byte {
 // the Stripe-Signature header using the specified signing secret. Returns an error
 // if the body or Stripe-Signature header provided are unreadable, if the
 // signature doesn't match, or if the timestamp for the signature is older than
-// DefaultTolerance.
+// DefaultTolerance, in which case it's a *SignatureVerificationError.
 //
 // NOTE: Stripe will only send Webhook signing headers after you have retrieved
 // your signing secret from the Stripe dashboard:
@@ -72,7 +73,8 @@ func ConstructEvent(payload []byte, header string, secret string) (stripe.Event,
 // ConstructEventIgnoringTolerance initializes an Event object from a JSON webhook
 // payload, validating the Stripe-Signature header using the specified signing secret.
 // Returns an error if the body or Stripe-Signature header provided are unreadable or
-// if the signature doesn't match. Does not check the signature's timestamp.
+// if the signature doesn't match, in which case it's a
+// *SignatureVerificationError. Does not check the signature's timestamp.
 //
 // NOTE: Stripe will only send Webhook signing headers after you have retrieved
 // your signing secret from the Stripe dashboard:
@@ -88,7 +90,8 @@ func ConstructEventIgnoringTolerance(payload []byte, header string, secret strin
 // validating the signature in the Stripe-Signature header using the specified signing
 // secret and tolerance window. Returns an error if the body or Stripe-Signature header
 // provided are unreadable, if the signature doesn't match, or if the timestamp
-// for the signature is older than the specified tolerance.
+// for the signature is older than the specified tolerance, in which case it's
+// a *SignatureVerificationError.
 //
 // NOTE: Stripe will only send Webhook signing headers after you have retrieved
 // your signing secret from the Stripe dashboard:
@@ -113,6 +116,9 @@ func ConstructEventWithTolerance(payload []byte, header string, secret string, t
 //     does not match the API version of the stripe-go library, as defined in
 //     `stripe.APIVersion`.
 //
+// Errors of the signature or of its timestamp are a
+// *SignatureVerificationError.
+//
 // NOTE: Stripe will only send Webhook signing headers after you have retrieved
 // your signing secret from the Stripe dashboard:
 // https://dashboard.stripe.com/webhooks
@@ -156,6 +162,51 @@ func ValidatePayloadWithTolerance(payload []byte, header string, secret string,
 	return validatePayload(payload, header, secret, tolerance, true)
 }
 
+// ValidatePayloadWithOptions validates the payload against the Stripe-Signature
+// header using the specified signing secret and the tolerance window provided
+// by the options, if applicable. IgnoreAPIVersionMismatch doesn't apply.
+//
+// Unlike the other functions validating payloads, but like the functions
+// constructing events, the error returned is a *SignatureVerificationError.
+//
+// NOTE: Stripe will only send Webhook signing headers after you have retrieved
+// your signing secret from the Stripe dashboard:
+// https://dashboard.stripe.com/webhooks
+func ValidatePayloadWithOptions(payload []byte, header string, secret string, options ConstructEventOptions) error {
+	tolerance := options.Tolerance
+	if options.Tolerance == 0 && !options.IgnoreTolerance {
+		tolerance = DefaultTolerance
+	}
+
+	if err := validatePayload(payload, header, secret, tolerance, !options.IgnoreTolerance); err != nil {
+		return &SignatureVerificationError{Err: err, Header: header}
+	}
+	return nil
+}
+
+// SignatureVerificationError is the error returned by the functions
+// constructing events and by ValidatePayloadWithOptions when the signature of
+// a webhook can't be verified. It wraps one of ErrInvalidHeader,
+// ErrNoValidSignature, ErrNotSigned or ErrTooOld, which are to be matched with
+// errors.Is rather than compared.
+type SignatureVerificationError struct {
+	// Err is the reason the signature couldn't be verified.
+	Err error
+
+	// Header is the Stripe-Signature header of the webhook.
+	Header string
+}
+
+// Error returns the reason the signature couldn't be verified.
+func (e *SignatureVerificationError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the reason the signature couldn't be verified.
+func (e *SignatureVerificationError) Unwrap() error {
+	return e.Err
+}
+
 type ConstructEventOptions struct {
 	// Validates event timestamps using a custom Tolerance window. If this is
 	// not set and `IgnoreTolerance` is false, will default to
@@ -204,12 +255,7 @@ func isCompatibleAPIVersion(eventApiVersion string) bool {
 func constructEvent(payload []byte, sigHeader string, secret string, options ConstructEventOptions) (stripe.Event, error) {
 	e := stripe.Event{}
 
-	tolerance := options.Tolerance
-	if options.Tolerance == 0 && !options.IgnoreTolerance {
-		tolerance = DefaultTolerance
-	}
-
-	if err := validatePayload(payload, sigHeader, secret, tolerance, !options.IgnoreTolerance); err != nil {
+	if err := ValidatePayloadWithOptions(payload, sigHeader, secret, options); err != nil {
 		return e, err
 	}
 
@@ -2,6 +2,7 @@ package webhook
 
 import (
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
@@ -82,12 +83,12 @@ func TestTokenNew(t *testing.T) {
 		t.Errorf("Expected ErrNotSigned from missing signature, got %v", err)
 	}
 	evt, err = ConstructEvent(p.Payload, "", p.Secret)
-	if err != ErrNotSigned {
+	if !errors.Is(err, ErrNotSigned) {
 		t.Errorf("Expected ErrNotSigned from missing signature, got %v", err)
 	}
 
 	evt, err = ConstructEvent(p.Payload, "v1,t=1", p.Secret)
-	if err != ErrInvalidHeader {
+	if !errors.Is(err, ErrInvalidHeader) {
 		t.Errorf("Expected ErrInvalidHeader from bad header format, got %v", err)
 	}
 
@@ -96,7 +97,7 @@ func TestTokenNew(t *testing.T) {
 		t.Errorf("Expected ErrInvalidHeader from bad header format, got %v", err)
 	}
 	evt, err = ConstructEvent(p.Payload, "t=", p.Secret)
-	if err != ErrInvalidHeader {
+	if !errors.Is(err, ErrInvalidHeader) {
 		t.Errorf("Expected ErrInvalidHeader from bad header format, got %v", err)
 	}
 
@@ -117,7 +118,7 @@ func TestTokenNew(t *testing.T) {
 		t.Errorf("Expected error from mismatched schema, got %v", err)
 	}
 	evt, err = ConstructEvent(p.Payload, p.Header, p.Secret)
-	if err != ErrNoValidSignature {
+	if !errors.Is(err, ErrNoValidSignature) {
 		t.Errorf("Expected error from mismatched schema, got %v", err)
 	}
 
@@ -129,7 +130,7 @@ func TestTokenNew(t *testing.T) {
 		t.Errorf("Expected error from fake signature, got %v", err)
 	}
 	evt, err = ConstructEvent(p.Payload, p.Header, p.Secret)
-	if err != ErrNoValidSignature {
+	if !errors.Is(err, ErrNoValidSignature) {
 		t.Errorf("Expected error from fake signature, got %v", err)
 	}
 
@@ -167,7 +168,7 @@ func TestTokenNew(t *testing.T) {
 		t.Errorf("Received %v error when validating timestamp outside of allowed timing window", err)
 	}
 	evt, err = ConstructEventWithTolerance(p.Payload, p.Header, p.Secret, 10*time.Second)
-	if err != ErrTooOld {
+	if !errors.Is(err, ErrTooOld) {
 		t.Errorf("Received %v error when validating timestamp outside of allowed timing window", err)
 	}
 
@@ -293,7 +294,56 @@ func TestConstructEventWithOptions_UsesDefaultToleranceWhenNoneProvided(t *testi
 
 	_, err = ConstructEventWithOptions(p.Payload, p.Header, p.Secret, ConstructEventOptions{})
 
-	if err != ErrTooOld {
+	if !errors.Is(err, ErrTooOld) {
 		t.Errorf("Expected error due to being too old, but got %v.", err)
 	}
 }
+
+func TestConstructEvent_SignatureVerificationError(t *testing.T) {
+	p := newSignedPayload()
+
+	_, err := ConstructEvent(p.Payload, p.Header, "whsec_wrong")
+	var verificationErr *SignatureVerificationError
+	if !errors.As(err, &verificationErr) {
+		t.Fatalf("Expected a SignatureVerificationError, got %v", err)
+	}
+	if verificationErr.Header != p.Header {
+		t.Errorf("Expected the header %q, got %q", p.Header, verificationErr.Header)
+	}
+	if !errors.Is(err, ErrNoValidSignature) {
+		t.Errorf("Expected ErrNoValidSignature, got %v", err)
+	}
+}
+
+func TestValidatePayloadWithOptions(t *testing.T) {
+	p := newSignedPayload()
+
+	err := ValidatePayloadWithOptions(p.Payload, p.Header, p.Secret, ConstructEventOptions{})
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	err = ValidatePayloadWithOptions(p.Payload, p.Header, "whsec_wrong", ConstructEventOptions{})
+	var verificationErr *SignatureVerificationError
+	if !errors.As(err, &verificationErr) {
+		t.Fatalf("Expected a SignatureVerificationError, got %v", err)
+	}
+	if verificationErr.Header != p.Header {
+		t.Errorf("Expected the header %q, got %q", p.Header, verificationErr.Header)
+	}
+	if !errors.Is(err, ErrNoValidSignature) {
+		t.Errorf("Expected ErrNoValidSignature, got %v", err)
+	}
+
+	p = newSignedPayload(func(p *SignedPayload) {
+		p.Timestamp = time.Now().Add(-15 * time.Second)
+	})
+	err = ValidatePayloadWithOptions(p.Payload, p.Header, p.Secret, ConstructEventOptions{Tolerance: 10 * time.Second})
+	if !errors.Is(err, ErrTooOld) {
+		t.Errorf("Expected ErrTooOld, got %v", err)
+	}
+	err = ValidatePayloadWithOptions(p.Payload, p.Header, p.Secret, ConstructEventOptions{IgnoreTolerance: true})
+	if err != nil {
+		t.Errorf("Expected no error when ignoring tolerance, got %v", err)
+	}
+}